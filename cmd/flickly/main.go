@@ -5,19 +5,39 @@
 // @host localhost:8080
 // @BasePath /
 // @schemes http https
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 package main
 
 import (
+	"context"
+	"database/sql"
+	"errors"
 	"flickly/docs"
-	"flickly/internal/api/flickly"
-	"flickly/internal/api/users"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/cache"
 	"flickly/internal/infra/crosscutting/ioc"
+	"flickly/internal/infra/crosscutting/modules"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	swaggerConfig "flickly/internal/infra/crosscutting/swagger"
 	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
 	"fmt"
 	"github.com/gin-gonic/gin"
+	_ "github.com/lib/pq"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
 )
 
+// shutdownTimeout é o prazo para concluir as requisições e parar os serviços no desligamento
+const shutdownTimeout = 30 * time.Second
+
 func main() {
 	fmt.Println("Servidor iniciando em http://localhost:8080")
 
@@ -28,19 +48,82 @@ func main() {
 	router := gin.Default()
 	serviceCollection := utilities.NewServiceCollection()
 
-	ioc.InitAutomapper(serviceCollection)
-	ioc.InjectServices(serviceCollection)
-	ioc.InjectMediatorHandlers(serviceCollection)
+	if redisURL := os.Getenv("REDIS_URL"); redisURL != "" {
+		utilities.AddService[cache.Cache](serviceCollection, cache.NewRedisCache(redisURL, "flickly:"))
+	}
+	var db *sql.DB
+	if databaseURL := os.Getenv("DATABASE_URL"); databaseURL != "" {
+		var err error
+		if db, err = sql.Open("postgres", databaseURL); err != nil {
+			log.Fatalf("Erro ao abrir conexão com o banco de dados: %v", err)
+		}
+		// O pool de conexões é fechado com os demais serviços no desligamento
+		utilities.AddService[*sql.DB](serviceCollection, db)
+	}
+
+	// Cada área funcional é um módulo; DISABLED_MODULES desativa módulos pelo nome
+	catalog := modules.NewCatalog(ioc.Modules()...)
+	catalog.Register(environmentModule())
+	enabledModules, err := catalog.Resolve(modules.DisabledBy(os.Getenv(modules.DisabledModulesVariable)))
+	if err != nil {
+		log.Fatalf("Erro ao resolver os módulos: %v", err)
+	}
+	if err := modules.Setup(serviceCollection, db, enabledModules); err != nil {
+		log.Fatalf("Erro ao configurar os módulos: %v", err)
+	}
 
-	users.Startup(router, serviceCollection)
-	flickly.Startup(router)
+	// Inicia os serviços do contêiner: o relay do outbox, o worker de jobs, os executores de sagas e de tarefas
+	// agendadas, que rodam em segundo plano até o desligamento
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	if err := serviceCollection.Start(ctx); err != nil {
+		log.Fatalf("Erro ao iniciar os serviços: %v", err)
+	}
+
+	ioc.InjectRouter(router, serviceCollection, enabledModules)
 
 	// Configuração do Swagger usando o novo pacote
 	swaggerConfig.SetupSwagger(router)
 
-	// Inicia o servidor na porta 8080
-	err := router.Run(":8080")
-	if err != nil {
-		return
+	// Inicia o servidor na porta 8080 e, ao receber SIGINT ou SIGTERM, conclui as requisições em andamento e
+	// para os serviços em ordem inversa à de inicialização
+	server := &http.Server{Addr: ":8080", Handler: router}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Erro no servidor HTTP: %v", err)
+			stop()
+		}
+	}()
+	<-ctx.Done()
+
+	fmt.Println("Servidor encerrando")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Erro ao encerrar o servidor HTTP: %v", err)
+	}
+	if err := serviceCollection.Stop(shutdownCtx); err != nil {
+		log.Printf("Erro ao parar os serviços: %v", err)
+	}
+}
+
+// environmentModule substitui os serviços padrão do núcleo pelos configurados nas variáveis de ambiente
+func environmentModule() modules.Module {
+	return modules.Module{
+		Name:      "environment",
+		DependsOn: []string{ioc.CoreModuleName},
+		Services: func(serviceCollection utilities.IServiceCollection) {
+			tokenSecret := os.Getenv("TOKEN_SECRET")
+			if tokenSecret == "" {
+				log.Fatal("A variável TOKEN_SECRET deve ser configurada")
+			}
+			utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret(tokenSecret))
+			if adminEmails := os.Getenv("ADMIN_EMAILS"); adminEmails != "" {
+				utilities.AddService[security.RoleProvider](serviceCollection, infrasecurity.NewStaticRoleProvider(strings.Split(adminEmails, ",")...))
+			}
+			if natsURL := os.Getenv("NATS_URL"); natsURL != "" {
+				utilities.AddService[messaging.Sink](serviceCollection, messaging.NewNATSSink(natsURL, "flickly.events"))
+			}
+		},
 	}
 }
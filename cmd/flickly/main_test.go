@@ -4,6 +4,7 @@ import (
 	"flickly/internal/api/flickly"
 	"flickly/internal/api/users"
 	"flickly/internal/infra/crosscutting/ioc"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	"flickly/internal/infra/crosscutting/utilities"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -25,7 +26,8 @@ func TestSetupRouter(t *testing.T) {
 
 		// Injetar dependências
 		ioc.InjectServices(serviceCollection)
-		ioc.InjectMediatorHandlers(serviceCollection)
+		utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
+		assert.NoError(t, ioc.InjectMediatorHandlers(serviceCollection))
 
 		// Configurar rotas
 		users.Startup(router, serviceCollection)
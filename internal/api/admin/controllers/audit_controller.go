@@ -0,0 +1,108 @@
+package controllers
+
+import (
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Limites de registros retornados por GetAudit
+const (
+	defaultAuditLimit = 100
+	maxAuditLimit     = 1000
+)
+
+type AuditController struct {
+	controllers.Controller
+	auditStore audit.Store
+	mapper     utilities.Mapper
+}
+
+// NewAuditController cria uma nova instância de AuditController
+func NewAuditController(collection utilities.IServiceCollection) *AuditController {
+	return &AuditController{
+		Controller: controllers.NewController(collection),
+		auditStore: utilities.GetService[audit.Store](collection),
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetAudit consulta a trilha de auditoria
+// @Summary Consultar trilha de auditoria
+// @Description Lista os comandos que alteraram estado, do mais recente para o mais antigo. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param actor query string false "ID do usuário que executou o comando"
+// @Param entityId query string false "ID da entidade afetada"
+// @Param type query string false "Tipo do comando (ex.: CreateUserCommand)"
+// @Param from query string false "Início do intervalo (RFC3339, inclusivo)"
+// @Param to query string false "Fim do intervalo (RFC3339, exclusivo)"
+// @Param limit query int false "Quantidade máxima de registros (padrão 100, máximo 1000)"
+// @Success 200 {array} viewmodels.AuditEntryResponse
+// @Failure 400 {object} object
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Router /admin/audit [get]
+func (a *AuditController) GetAudit(c *gin.Context) {
+	a.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		filter, err := parseAuditFilter(c)
+		if err != nil {
+			return nil, err
+		}
+
+		entries, err := a.auditStore.Find(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		response := make([]viewmodels.AuditEntryResponse, 0, len(entries))
+		if err = a.mapper.MapSlice(entries, &response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}, http.StatusOK)
+}
+
+func parseAuditFilter(c *gin.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		Actor:       c.Query("actor"),
+		EntityID:    c.Query("entityId"),
+		RequestType: c.Query("type"),
+		Limit:       defaultAuditLimit,
+	}
+	var err error
+	if filter.From, err = parseTime(c, "from"); err != nil {
+		return filter, err
+	}
+	if filter.To, err = parseTime(c, "to"); err != nil {
+		return filter, err
+	}
+	if value := c.Query("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit <= 0 || limit > maxAuditLimit {
+			return filter, core.ErrInvalidArgument(fmt.Errorf("limit must be between 1 and %d", maxAuditLimit))
+		}
+		filter.Limit = limit
+	}
+	return filter, nil
+}
+
+func parseTime(c *gin.Context, name string) (*time.Time, error) {
+	value := c.Query(name)
+	if value == "" {
+		return nil, nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, core.ErrInvalidArgument(fmt.Errorf("%s must be a RFC3339 timestamp: %w", name, err))
+	}
+	return &parsed, nil
+}
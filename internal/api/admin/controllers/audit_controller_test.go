@@ -0,0 +1,97 @@
+package controllers
+
+import (
+	"encoding/json"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAuditStore registra o último filtro recebido
+type MockAuditStore struct {
+	LastFilter      audit.Filter
+	EntriesToReturn []audit.Entry
+	ErrorToReturn   error
+}
+
+func (s *MockAuditStore) Append(entry *audit.Entry) error {
+	return nil
+}
+
+func (s *MockAuditStore) Find(filter audit.Filter) ([]audit.Entry, error) {
+	s.LastFilter = filter
+	return s.EntriesToReturn, s.ErrorToReturn
+}
+
+func setupAuditController(store *MockAuditStore) *AuditController {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[audit.Store](serviceCollection, store)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	return NewAuditController(serviceCollection)
+}
+
+func getAudit(controller *AuditController, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/admin/audit?"+query, nil)
+	controller.GetAudit(c)
+	return w
+}
+
+func TestGetAudit(t *testing.T) {
+	// Configuração
+	entry, _ := audit.NewEntry("user-1", "CreateUserCommand", map[string]string{"name": "a"}, nil, nil, "corr-1")
+	store := &MockAuditStore{EntriesToReturn: []audit.Entry{*entry}}
+	controller := setupAuditController(store)
+
+	// Execução
+	w := getAudit(controller, "actor=user-1&entityId=e-1&type=CreateUserCommand&from=2026-01-01T00:00:00Z&to=2026-02-01T00:00:00Z&limit=10")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	assert.Equal(t, "user-1", store.LastFilter.Actor, "O filtro por ator deve ser repassado")
+	assert.Equal(t, "e-1", store.LastFilter.EntityID, "O filtro por entidade deve ser repassado")
+	assert.Equal(t, "CreateUserCommand", store.LastFilter.RequestType, "O filtro por tipo deve ser repassado")
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), *store.LastFilter.From, "O início do intervalo deve ser repassado")
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), *store.LastFilter.To, "O fim do intervalo deve ser repassado")
+	assert.Equal(t, 10, store.LastFilter.Limit)
+
+	var response []viewmodels.AuditEntryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, entry.ID, response[0].ID)
+	assert.JSONEq(t, `{"name":"a"}`, string(response[0].Payload), "O payload deve ser retornado como JSON")
+}
+
+func TestGetAudit_Defaults(t *testing.T) {
+	// Configuração
+	store := &MockAuditStore{}
+	controller := setupAuditController(store)
+
+	// Execução
+	w := getAudit(controller, "")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "[]", w.Body.String(), "Sem registros deve retornar uma lista vazia")
+	assert.Equal(t, defaultAuditLimit, store.LastFilter.Limit, "O limite padrão deve ser aplicado")
+	assert.Nil(t, store.LastFilter.From)
+}
+
+func TestGetAudit_InvalidParameters(t *testing.T) {
+	// Configuração
+	controller := setupAuditController(&MockAuditStore{})
+
+	// Execução e verificações
+	for _, query := range []string{"from=yesterday", "to=2026-13-01", "limit=0", "limit=5000", "limit=abc"} {
+		assert.Equal(t, http.StatusBadRequest, getAudit(controller, query).Code, "A consulta %q deve ser rejeitada", query)
+	}
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// Limites de registros retornados por GetDeadLetters
+const (
+	defaultDeadLetterLimit = 100
+	maxDeadLetterLimit     = 1000
+)
+
+type JobController struct {
+	controllers.Controller
+	jobQueue jobs.Queue
+	mapper   utilities.Mapper
+}
+
+// NewJobController cria uma nova instância de JobController
+func NewJobController(collection utilities.IServiceCollection) *JobController {
+	return &JobController{
+		Controller: controllers.NewController(collection),
+		jobQueue:   utilities.GetService[jobs.Queue](collection),
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetDeadLetters lista os jobs que esgotaram as tentativas
+// @Summary Consultar jobs com falha
+// @Description Lista os jobs que foram para o dead-letter, do mais recente para o mais antigo. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param limit query int false "Quantidade máxima de registros (padrão 100, máximo 1000)"
+// @Success 200 {array} viewmodels.DeadLetterJobResponse
+// @Failure 400 {object} object
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Router /admin/jobs/dead-letters [get]
+func (j *JobController) GetDeadLetters(c *gin.Context) {
+	j.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		limit := defaultDeadLetterLimit
+		if value := c.Query("limit"); value != "" {
+			parsed, err := strconv.Atoi(value)
+			if err != nil || parsed <= 0 || parsed > maxDeadLetterLimit {
+				return nil, core.ErrInvalidArgument(fmt.Errorf("limit must be between 1 and %d", maxDeadLetterLimit))
+			}
+			limit = parsed
+		}
+
+		deadLetters, err := j.jobQueue.DeadLetters(limit)
+		if err != nil {
+			return nil, err
+		}
+
+		response := make([]viewmodels.DeadLetterJobResponse, 0, len(deadLetters))
+		if err = j.mapper.MapSlice(deadLetters, &response); err != nil {
+			return nil, err
+		}
+		return response, nil
+	}, http.StatusOK)
+}
@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"encoding/json"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJobController(queue jobs.Queue) *JobController {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[jobs.Queue](serviceCollection, queue)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	return NewJobController(serviceCollection)
+}
+
+func getDeadLetters(controller *JobController, query string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/admin/jobs/dead-letters?"+query, nil)
+	controller.GetDeadLetters(c)
+	return w
+}
+
+func TestGetDeadLetters(t *testing.T) {
+	// Configuração
+	queue := messaging.NewJobMemoryQueue()
+	failed, _ := jobs.NewJob("commands.ExportCommand", map[string]string{"format": "csv"}, security.Principal{}, "corr-1")
+	pending, _ := jobs.NewJob("commands.ExportCommand", nil, security.Principal{}, "")
+	_ = queue.Enqueue(failed)
+	_ = queue.Enqueue(pending)
+	_ = queue.DeadLetter(failed.ID, "boom", time.Now())
+	controller := setupJobController(queue)
+
+	// Execução
+	w := getDeadLetters(controller, "limit=10")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response []viewmodels.DeadLetterJobResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1, "Apenas os jobs no dead-letter devem ser listados")
+	assert.Equal(t, failed.ID, response[0].ID)
+	assert.Equal(t, "boom", response[0].LastError, "O último erro deve ser retornado")
+	assert.JSONEq(t, `{"format":"csv"}`, string(response[0].Payload), "O payload deve ser retornado como JSON")
+}
+
+func TestGetDeadLetters_InvalidLimit(t *testing.T) {
+	// Configuração
+	controller := setupJobController(messaging.NewJobMemoryQueue())
+
+	// Execução e verificações
+	assert.Equal(t, "[]", getDeadLetters(controller, "").Body.String(), "Sem registros deve retornar uma lista vazia")
+	for _, query := range []string{"limit=0", "limit=5000", "limit=abc"} {
+		assert.Equal(t, http.StatusBadRequest, getDeadLetters(controller, query).Code, "A consulta %q deve ser rejeitada", query)
+	}
+}
@@ -0,0 +1,117 @@
+package controllers
+
+import (
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+	"strconv"
+)
+
+// Limites de registros retornados por GetSagas
+const (
+	defaultSagaLimit = 100
+	maxSagaLimit     = 1000
+)
+
+type SagaController struct {
+	controllers.Controller
+	sagaStore saga.Store
+	mapper    utilities.Mapper
+}
+
+// NewSagaController cria uma nova instância de SagaController
+func NewSagaController(collection utilities.IServiceCollection) *SagaController {
+	return &SagaController{
+		Controller: controllers.NewController(collection),
+		sagaStore:  utilities.GetService[saga.Store](collection),
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetSagas lista as sagas
+// @Summary Consultar sagas
+// @Description Lista as sagas, da atualização mais recente para a mais antiga. Sagas com status failed esgotaram as tentativas de compensação e exigem intervenção. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name query string false "Nome da saga"
+// @Param status query string false "Status (running, compensating, completed, compensated, failed)"
+// @Param limit query int false "Quantidade máxima de registros (padrão 100, máximo 1000)"
+// @Success 200 {array} viewmodels.SagaResponse
+// @Failure 400 {object} object
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Router /admin/sagas [get]
+func (s *SagaController) GetSagas(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		filter := saga.Filter{Name: c.Query("name"), Status: c.Query("status"), Limit: defaultSagaLimit}
+		if value := c.Query("limit"); value != "" {
+			limit, err := strconv.Atoi(value)
+			if err != nil || limit <= 0 || limit > maxSagaLimit {
+				return nil, core.ErrInvalidArgument(fmt.Errorf("limit must be between 1 and %d", maxSagaLimit))
+			}
+			filter.Limit = limit
+		}
+
+		states, err := s.sagaStore.Find(filter)
+		if err != nil {
+			return nil, err
+		}
+
+		response := make([]viewmodels.SagaResponse, 0, len(states))
+		for _, state := range states {
+			sagaResponse, err := s.toResponse(state)
+			if err != nil {
+				return nil, err
+			}
+			response = append(response, sagaResponse)
+		}
+		return response, nil
+	}, http.StatusOK)
+}
+
+// GetSaga consulta o estado e o histórico de uma saga
+// @Summary Consultar saga
+// @Description Retorna o estado, os dados e o histórico de passos de uma saga. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID da saga"
+// @Success 200 {object} viewmodels.SagaResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Router /admin/sagas/{id} [get]
+func (s *SagaController) GetSaga(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return nil, core.ErrSagaNotFound(err)
+		}
+
+		state, err := s.sagaStore.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		if state == nil {
+			return nil, core.ErrSagaNotFound(fmt.Errorf("saga %s not found", id))
+		}
+		return s.toResponse(*state)
+	}, http.StatusOK)
+}
+
+// toResponse mapeia a saga e o seu histórico, retornando um histórico vazio em vez de nulo
+func (s *SagaController) toResponse(state saga.State) (viewmodels.SagaResponse, error) {
+	var response viewmodels.SagaResponse
+	err := s.mapper.Map(state, &response)
+	if response.History == nil {
+		response.History = []viewmodels.SagaTransitionResponse{}
+	}
+	return response, err
+}
@@ -0,0 +1,99 @@
+package controllers
+
+import (
+	"encoding/json"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	infrasaga "flickly/internal/infra/data/saga"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupSagaController(store saga.Store) *SagaController {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[saga.Store](serviceCollection, store)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	return NewSagaController(serviceCollection)
+}
+
+func serveSaga(handler gin.HandlerFunc, target string, params gin.Params) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = params
+	c.Request = httptest.NewRequest(http.MethodGet, target, nil)
+	handler(c)
+	return w
+}
+
+func newFailedSaga() *saga.State {
+	state := saga.NewState("DeleteAccount", []byte(`{"userId":"1"}`), time.Minute, security.Principal{Subject: "user-1"}, "corr-1")
+	state.Status = saga.StatusFailed
+	state.LastError = "undo content failed"
+	state.History = []saga.Transition{{Step: "content", Action: saga.ActionCompensate, Error: "undo content failed", At: time.Now()}}
+	return state
+}
+
+func TestGetSagas(t *testing.T) {
+	// Configuração
+	store := infrasaga.NewSagaMemoryStore()
+	failed := newFailedSaga()
+	_ = store.Create(failed)
+	_ = store.Create(saga.NewState("DeleteAccount", []byte(`{}`), time.Minute, security.Principal{}, ""))
+	controller := setupSagaController(store)
+
+	// Execução
+	w := serveSaga(controller.GetSagas, "/admin/sagas?status=failed&limit=10", nil)
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response []viewmodels.SagaResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1, "O filtro por status deve ser aplicado")
+	assert.Equal(t, failed.ID, response[0].ID)
+	assert.Equal(t, "undo content failed", response[0].LastError, "O último erro deve ser retornado")
+	assert.JSONEq(t, `{"userId":"1"}`, string(response[0].Data), "Os dados devem ser retornados como JSON")
+	assert.Equal(t, "content", response[0].History[0].Step, "O histórico deve ser retornado")
+}
+
+func TestGetSagas_InvalidLimit(t *testing.T) {
+	// Configuração
+	controller := setupSagaController(infrasaga.NewSagaMemoryStore())
+
+	// Execução e verificações
+	assert.Equal(t, "[]", serveSaga(controller.GetSagas, "/admin/sagas", nil).Body.String(), "Sem registros deve retornar uma lista vazia")
+	for _, query := range []string{"limit=0", "limit=5000", "limit=abc"} {
+		assert.Equal(t, http.StatusBadRequest, serveSaga(controller.GetSagas, "/admin/sagas?"+query, nil).Code, "A consulta %q deve ser rejeitada", query)
+	}
+}
+
+func TestGetSaga(t *testing.T) {
+	// Configuração
+	store := infrasaga.NewSagaMemoryStore()
+	failed := newFailedSaga()
+	_ = store.Create(failed)
+	controller := setupSagaController(store)
+
+	// Execução
+	w := serveSaga(controller.GetSaga, "/admin/sagas/"+failed.ID.String(), gin.Params{{Key: "id", Value: failed.ID.String()}})
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response viewmodels.SagaResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, saga.StatusFailed, response.Status)
+	assert.Equal(t, saga.ActionCompensate, response.History[0].Action)
+
+	for _, id := range []string{"not-a-uuid", uuid.New().String()} {
+		w = serveSaga(controller.GetSaga, "/admin/sagas/"+id, gin.Params{{Key: "id", Value: id}})
+		assert.Equal(t, http.StatusNotFound, w.Code, "Sagas inexistentes devem retornar 404 (%s)", id)
+	}
+}
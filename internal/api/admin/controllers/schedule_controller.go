@@ -0,0 +1,156 @@
+package controllers
+
+import (
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"net/http"
+	"strconv"
+)
+
+// defaultScheduleRunLimit é a quantidade de execuções retornada por GetScheduledTaskRuns sem limit
+const defaultScheduleRunLimit = 20
+
+type ScheduleController struct {
+	controllers.Controller
+	scheduler *schedule.Scheduler
+	mapper    utilities.Mapper
+}
+
+// NewScheduleController cria uma nova instância de ScheduleController
+func NewScheduleController(collection utilities.IServiceCollection) *ScheduleController {
+	return &ScheduleController{
+		Controller: controllers.NewController(collection),
+		scheduler:  utilities.GetService[*schedule.Scheduler](collection),
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetScheduledTasks lista as tarefas agendadas
+// @Summary Consultar tarefas agendadas
+// @Description Lista as tarefas agendadas com a expressão, a próxima execução e o resultado da última. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} viewmodels.ScheduledTaskResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Router /admin/schedules [get]
+func (s *ScheduleController) GetScheduledTasks(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		states, err := s.scheduler.List()
+		if err != nil {
+			return nil, err
+		}
+		response := make([]viewmodels.ScheduledTaskResponse, 0, len(states))
+		err = s.mapper.MapSlice(states, &response)
+		return response, err
+	}, http.StatusOK)
+}
+
+// GetScheduledTaskRuns consulta o histórico de execuções de uma tarefa
+// @Summary Consultar execuções de uma tarefa agendada
+// @Description Retorna as últimas execuções da tarefa, das mais recentes para as mais antigas. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome da tarefa"
+// @Param limit query int false "Quantidade máxima de registros (padrão 20, máximo 100)"
+// @Success 200 {array} viewmodels.ScheduledTaskRunResponse
+// @Failure 400 {object} object
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Router /admin/schedules/{name}/runs [get]
+func (s *ScheduleController) GetScheduledTaskRuns(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		limit := defaultScheduleRunLimit
+		if value := c.Query("limit"); value != "" {
+			var err error
+			limit, err = strconv.Atoi(value)
+			if err != nil || limit <= 0 || limit > schedule.MaxRuns {
+				return nil, core.ErrInvalidArgument(fmt.Errorf("limit must be between 1 and %d", schedule.MaxRuns))
+			}
+		}
+
+		runs, err := s.scheduler.Runs(c.Param("name"), limit)
+		if err != nil {
+			return nil, err
+		}
+		response := make([]viewmodels.ScheduledTaskRunResponse, 0, len(runs))
+		err = s.mapper.MapSlice(runs, &response)
+		return response, err
+	}, http.StatusOK)
+}
+
+// PauseScheduledTask pausa uma tarefa agendada
+// @Summary Pausar tarefa agendada
+// @Description Suspende as execuções agendadas da tarefa em todas as instâncias; execuções manuais continuam permitidas. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome da tarefa"
+// @Success 200 {object} viewmodels.ScheduledTaskResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Router /admin/schedules/{name}/pause [post]
+func (s *ScheduleController) PauseScheduledTask(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		return s.update(c.Param("name"), s.scheduler.Pause)
+	}, http.StatusOK)
+}
+
+// ResumeScheduledTask retoma uma tarefa agendada
+// @Summary Retomar tarefa agendada
+// @Description Retoma as execuções agendadas a partir do próximo horário, sem repetir as perdidas durante a pausa. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome da tarefa"
+// @Success 200 {object} viewmodels.ScheduledTaskResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Router /admin/schedules/{name}/resume [post]
+func (s *ScheduleController) ResumeScheduledTask(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		return s.update(c.Param("name"), s.scheduler.Resume)
+	}, http.StatusOK)
+}
+
+// TriggerScheduledTask solicita a execução imediata de uma tarefa agendada
+// @Summary Executar tarefa agendada
+// @Description Solicita uma execução imediata da tarefa, feita em segundo plano por uma única instância, mesmo com a tarefa pausada. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Nome da tarefa"
+// @Success 202 {object} viewmodels.ScheduledTaskResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Router /admin/schedules/{name}/trigger [post]
+func (s *ScheduleController) TriggerScheduledTask(c *gin.Context) {
+	s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		return s.update(c.Param("name"), s.scheduler.Trigger)
+	}, http.StatusAccepted)
+}
+
+// update aplica a alteração à tarefa e retorna o seu novo estado
+func (s *ScheduleController) update(name string, update func(name string) error) (interface{}, error) {
+	if err := update(name); err != nil {
+		return nil, err
+	}
+	state, err := s.scheduler.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	var response viewmodels.ScheduledTaskResponse
+	err = s.mapper.Map(*state, &response)
+	return response, err
+}
@@ -0,0 +1,116 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/infra/crosscutting/utilities"
+	infraschedule "flickly/internal/infra/data/schedule"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// cleanupCommand é a requisição agendada nos testes do controller
+type cleanupCommand struct{}
+
+func setupScheduleController() (*ScheduleController, *schedule.Scheduler) {
+	mediatR := mediator.NewMediatR()
+	mediator.Register[cleanupCommand, int](mediatR, mediator.RequestHandlerFunc[cleanupCommand, int](
+		func(ctx context.Context, request cleanupCommand) (int, error) {
+			return 1, nil
+		}))
+	scheduler := schedule.NewScheduler(infraschedule.NewScheduleMemoryStore(), mediatR)
+	_ = scheduler.Register("cleanup", "@hourly", cleanupCommand{})
+	_ = scheduler.Sync()
+
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[*schedule.Scheduler](serviceCollection, scheduler)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	return NewScheduleController(serviceCollection), scheduler
+}
+
+func serveSchedule(handler gin.HandlerFunc, method string, target string, name string) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "name", Value: name}}
+	c.Request = httptest.NewRequest(method, target, nil)
+	handler(c)
+	return w
+}
+
+func TestGetScheduledTasks(t *testing.T) {
+	// Configuração
+	controller, _ := setupScheduleController()
+
+	// Execução
+	w := serveSchedule(controller.GetScheduledTasks, http.MethodGet, "/admin/schedules", "")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response []viewmodels.ScheduledTaskResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 1)
+	assert.Equal(t, "cleanup", response[0].Name)
+	assert.Equal(t, "@hourly", response[0].Spec, "A expressão deve ser retornada")
+	assert.False(t, response[0].NextRunAt.IsZero(), "A próxima execução deve ser retornada")
+}
+
+func TestPauseResumeScheduledTask(t *testing.T) {
+	// Configuração
+	controller, scheduler := setupScheduleController()
+
+	// Execução
+	paused := serveSchedule(controller.PauseScheduledTask, http.MethodPost, "/admin/schedules/cleanup/pause", "cleanup")
+	state, _ := scheduler.Get("cleanup")
+	resumed := serveSchedule(controller.ResumeScheduledTask, http.MethodPost, "/admin/schedules/cleanup/resume", "cleanup")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, paused.Code, "O código de status deve ser 200 OK")
+	assert.Contains(t, paused.Body.String(), `"paused":true`, "O novo estado deve ser retornado")
+	assert.True(t, state.Paused, "A tarefa deve ser pausada")
+	assert.Equal(t, http.StatusOK, resumed.Code)
+	assert.Contains(t, resumed.Body.String(), `"paused":false`)
+}
+
+func TestTriggerScheduledTask(t *testing.T) {
+	// Configuração
+	controller, scheduler := setupScheduleController()
+
+	// Execução
+	w := serveSchedule(controller.TriggerScheduledTask, http.MethodPost, "/admin/schedules/cleanup/trigger", "cleanup")
+	claimed, _ := scheduler.Claim(time.Minute, 1)
+	_ = scheduler.Execute(context.Background(), claimed[0])
+	runs := serveSchedule(controller.GetScheduledTaskRuns, http.MethodGet, "/admin/schedules/cleanup/runs?limit=5", "cleanup")
+
+	// Verificações
+	assert.Equal(t, http.StatusAccepted, w.Code, "O código de status deve ser 202 Accepted")
+	assert.Contains(t, w.Body.String(), `"triggered":true`)
+	assert.Equal(t, http.StatusOK, runs.Code)
+	var response []viewmodels.ScheduledTaskRunResponse
+	assert.NoError(t, json.Unmarshal(runs.Body.Bytes(), &response))
+	assert.Len(t, response, 1, "A execução manual deve aparecer no histórico")
+	assert.Equal(t, schedule.TriggerManual, response[0].Trigger)
+	assert.Equal(t, schedule.RunSucceeded, response[0].Status)
+}
+
+func TestScheduledTask_Errors(t *testing.T) {
+	// Configuração
+	controller, _ := setupScheduleController()
+
+	// Execução e verificações
+	assert.Equal(t, http.StatusNotFound, serveSchedule(controller.TriggerScheduledTask, http.MethodPost, "/admin/schedules/unknown/trigger", "unknown").Code,
+		"Tarefas inexistentes devem retornar 404")
+	assert.Equal(t, http.StatusNotFound, serveSchedule(controller.GetScheduledTaskRuns, http.MethodGet, "/admin/schedules/unknown/runs", "unknown").Code)
+	for _, query := range []string{"limit=0", "limit=500", "limit=abc"} {
+		assert.Equal(t, http.StatusBadRequest, serveSchedule(controller.GetScheduledTaskRuns, http.MethodGet, "/admin/schedules/cleanup/runs?"+query, "cleanup").Code,
+			"A consulta %q deve ser rejeitada", query)
+	}
+}
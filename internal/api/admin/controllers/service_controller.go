@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"bytes"
+	"errors"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/infra/crosscutting/utilities"
+	"github.com/gin-gonic/gin"
+	"net/http"
+)
+
+// Formatos aceitos por GetServices
+const (
+	serviceFormatJSON = "json"
+	serviceFormatText = "text"
+	serviceFormatDOT  = "dot"
+)
+
+type ServiceController struct {
+	controllers.Controller
+	services utilities.IServiceCollection
+	mapper   utilities.Mapper
+}
+
+// NewServiceController cria uma nova instância de ServiceController
+func NewServiceController(collection utilities.IServiceCollection) *ServiceController {
+	return &ServiceController{
+		Controller: controllers.NewController(collection),
+		services:   collection,
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetServices descreve os serviços registrados no contêiner
+// @Summary Consultar serviços registrados
+// @Description Lista os serviços do contêiner com o tempo de vida, a implementação, as dependências e os decoradores, em JSON, em uma tabela de texto ou no formato DOT do Graphviz. Exige o papel admin
+// @Tags admin
+// @Produce json
+// @Produce plain
+// @Param format query string false "Formato (json, text ou dot; padrão json)"
+// @Security BearerAuth
+// @Success 200 {array} viewmodels.ServiceResponse
+// @Failure 400 {object} object
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Router /admin/services [get]
+func (s *ServiceController) GetServices(c *gin.Context) {
+	services := s.services.Describe()
+	// A escrita em bytes.Buffer não falha
+	var buffer bytes.Buffer
+	switch c.DefaultQuery("format", serviceFormatJSON) {
+	case serviceFormatText:
+		_ = utilities.WriteServicesText(&buffer, services)
+		c.Data(http.StatusOK, "text/plain; charset=utf-8", buffer.Bytes())
+	case serviceFormatDOT:
+		_ = utilities.WriteServicesDOT(&buffer, services)
+		c.Data(http.StatusOK, "text/vnd.graphviz; charset=utf-8", buffer.Bytes())
+	case serviceFormatJSON:
+		s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+			response := make([]viewmodels.ServiceResponse, 0, len(services))
+			err := s.mapper.MapSlice(services, &response)
+			return response, err
+		}, http.StatusOK)
+	default:
+		s.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+			return nil, core.ErrInvalidArgument(errors.New("format must be json, text or dot"))
+		}, http.StatusOK)
+	}
+}
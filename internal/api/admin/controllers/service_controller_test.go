@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"encoding/json"
+	viewmodels "flickly/internal/api/admin/viewmodels"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/infra/crosscutting/utilities"
+	infrasaga "flickly/internal/infra/data/saga"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func serveServices(query string) *httptest.ResponseRecorder {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	utilities.AddService[saga.Store](serviceCollection, infrasaga.NewSagaMemoryStore())
+	utilities.AddConstructor[*saga.Manager](serviceCollection, utilities.Singleton, saga.NewManager)
+	controller := NewServiceController(serviceCollection)
+
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/admin/services"+query, nil)
+	controller.GetServices(c)
+	return w
+}
+
+func TestGetServices(t *testing.T) {
+	// Execução
+	w := serveServices("")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response []viewmodels.ServiceResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Len(t, response, 3, "Todos os serviços registrados devem ser retornados")
+	assert.Equal(t, "*saga.Manager", response[2].Service)
+	assert.Equal(t, "singleton", response[2].Lifetime)
+	assert.Equal(t, "saga.NewManager", response[2].Implementation, "O construtor deve ser retornado")
+	assert.Equal(t, []string{"saga.Store", "mediator.Sender"}, response[2].Dependencies, "As dependências do construtor devem ser retornadas")
+}
+
+func TestGetServices_Formats(t *testing.T) {
+	// Execução
+	text := serveServices("?format=text")
+	dot := serveServices("?format=dot")
+	invalid := serveServices("?format=xml")
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, text.Code)
+	assert.True(t, strings.HasPrefix(text.Body.String(), "SERVICE"), "O formato text deve retornar uma tabela")
+	assert.Equal(t, http.StatusOK, dot.Code)
+	assert.Contains(t, dot.Header().Get("Content-Type"), "text/vnd.graphviz")
+	assert.Contains(t, dot.Body.String(), `"*saga.Manager" -> "saga.Store";`, "O formato dot deve retornar o grafo de dependências")
+	assert.Equal(t, http.StatusBadRequest, invalid.Code, "Formatos desconhecidos devem ser rejeitados")
+}
@@ -0,0 +1,33 @@
+package admin
+
+import (
+	"flickly/internal/api/admin/controllers"
+	"flickly/internal/api/commons/middlewares"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"github.com/gin-gonic/gin"
+)
+
+// Startup configura as rotas administrativas, restritas ao papel admin.
+// Espera que o middleware de autenticação já esteja registrado no roteador.
+func Startup(router *gin.Engine, serviceCollection utilities.IServiceCollection) {
+	auditController := controllers.NewAuditController(serviceCollection)
+	jobController := controllers.NewJobController(serviceCollection)
+	sagaController := controllers.NewSagaController(serviceCollection)
+	scheduleController := controllers.NewScheduleController(serviceCollection)
+	serviceController := controllers.NewServiceController(serviceCollection)
+
+	adminGroup := router.Group("/admin", middlewares.RequireRole(security.RoleAdmin))
+	{
+		adminGroup.GET("/audit", auditController.GetAudit)
+		adminGroup.GET("/jobs/dead-letters", jobController.GetDeadLetters)
+		adminGroup.GET("/sagas", sagaController.GetSagas)
+		adminGroup.GET("/sagas/:id", sagaController.GetSaga)
+		adminGroup.GET("/schedules", scheduleController.GetScheduledTasks)
+		adminGroup.GET("/schedules/:name/runs", scheduleController.GetScheduledTaskRuns)
+		adminGroup.POST("/schedules/:name/pause", scheduleController.PauseScheduledTask)
+		adminGroup.POST("/schedules/:name/resume", scheduleController.ResumeScheduledTask)
+		adminGroup.POST("/schedules/:name/trigger", scheduleController.TriggerScheduledTask)
+		adminGroup.GET("/services", serviceController.GetServices)
+	}
+}
@@ -0,0 +1,79 @@
+package admin
+
+import (
+	"flickly/internal/api/commons/middlewares"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	infrasaga "flickly/internal/infra/data/saga"
+	infraschedule "flickly/internal/infra/data/schedule"
+	"flickly/internal/infra/messaging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAuditStoreForRouterTest é uma trilha de auditoria vazia
+type MockAuditStoreForRouterTest struct{}
+
+func (s *MockAuditStoreForRouterTest) Append(entry *audit.Entry) error {
+	return nil
+}
+
+func (s *MockAuditStoreForRouterTest) Find(filter audit.Filter) ([]audit.Entry, error) {
+	return nil, nil
+}
+
+// MockTokenServiceForRouterTest aceita o token "admin" como administrador e "user" como usuário comum
+type MockTokenServiceForRouterTest struct{}
+
+func (s *MockTokenServiceForRouterTest) Issue(principal security.Principal) (string, time.Duration, error) {
+	return "", 0, nil
+}
+
+func (s *MockTokenServiceForRouterTest) Validate(token string) (security.Principal, error) {
+	switch token {
+	case "admin":
+		return security.Principal{Subject: "admin", Roles: []string{security.RoleAdmin}}, nil
+	case "user":
+		return security.Principal{Subject: "user"}, nil
+	}
+	return security.Principal{}, security.ErrInvalidToken
+}
+
+func TestStartup(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(middlewares.Authentication(&MockTokenServiceForRouterTest{}))
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[audit.Store](serviceCollection, &MockAuditStoreForRouterTest{})
+	utilities.AddService[jobs.Queue](serviceCollection, messaging.NewJobMemoryQueue())
+	utilities.AddService[saga.Store](serviceCollection, infrasaga.NewSagaMemoryStore())
+	utilities.AddService[*schedule.Scheduler](serviceCollection, schedule.NewScheduler(infraschedule.NewScheduleMemoryStore(), nil))
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+
+	// Execução
+	Startup(router, serviceCollection)
+
+	// Verificações
+	expected := map[string]int{"": http.StatusUnauthorized, "user": http.StatusForbidden, "admin": http.StatusOK}
+	for _, path := range []string{"/admin/audit", "/admin/jobs/dead-letters", "/admin/sagas", "/admin/schedules", "/admin/services"} {
+		for token, status := range expected {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+			router.ServeHTTP(w, req)
+			assert.Equal(t, status, w.Code, "Status incorreto em %s para o token %q", path, token)
+		}
+	}
+}
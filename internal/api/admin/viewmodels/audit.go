@@ -0,0 +1,19 @@
+package view_models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type AuditEntryResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Actor         string          `json:"actor"`
+	RequestType   string          `json:"requestType"`
+	EntityID      string          `json:"entityId,omitempty"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Result        string          `json:"result"`
+	Error         string          `json:"error,omitempty"`
+	CorrelationID string          `json:"correlationId,omitempty"`
+}
@@ -0,0 +1,18 @@
+package view_models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type DeadLetterJobResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	RequestType   string          `json:"requestType"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	Attempts      int             `json:"attempts"`
+	LastError     string          `json:"lastError"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+	CorrelationID string          `json:"correlationId,omitempty"`
+}
@@ -0,0 +1,30 @@
+package view_models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type SagaTransitionResponse struct {
+	Step   string    `json:"step"`
+	Action string    `json:"action"`
+	Error  string    `json:"error,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+type SagaResponse struct {
+	ID            uuid.UUID                `json:"id"`
+	Name          string                   `json:"name"`
+	Status        string                   `json:"status"`
+	Step          int                      `json:"step"`
+	Data          json.RawMessage          `json:"data" swaggertype:"object"`
+	Attempts      int                      `json:"attempts"`
+	LastError     string                   `json:"lastError,omitempty"`
+	History       []SagaTransitionResponse `json:"history"`
+	Deadline      time.Time                `json:"deadline"`
+	NextAttemptAt time.Time                `json:"nextAttemptAt"`
+	CreatedAt     time.Time                `json:"createdAt"`
+	UpdatedAt     time.Time                `json:"updatedAt"`
+	CorrelationID string                   `json:"correlationId,omitempty"`
+}
@@ -0,0 +1,27 @@
+package view_models
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+type ScheduledTaskResponse struct {
+	Name       string    `json:"name"`
+	Spec       string    `json:"spec"`
+	Paused     bool      `json:"paused"`
+	Triggered  bool      `json:"triggered"`
+	NextRunAt  time.Time `json:"nextRunAt"`
+	LastRunAt  time.Time `json:"lastRunAt"`
+	LastStatus string    `json:"lastStatus,omitempty"`
+	LastError  string    `json:"lastError,omitempty"`
+}
+
+type ScheduledTaskRunResponse struct {
+	ID          uuid.UUID `json:"id"`
+	Trigger     string    `json:"trigger"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+}
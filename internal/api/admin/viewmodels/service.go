@@ -0,0 +1,11 @@
+package view_models
+
+type ServiceResponse struct {
+	Service               string   `json:"service"`
+	Lifetime              string   `json:"lifetime"`
+	Implementation        string   `json:"implementation"`
+	Dependencies          []string `json:"dependencies,omitempty"`
+	Decorators            []string `json:"decorators,omitempty"`
+	DecoratorDependencies []string `json:"decoratorDependencies,omitempty"`
+	Overridden            bool     `json:"overridden,omitempty"`
+}
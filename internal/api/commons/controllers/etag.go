@@ -0,0 +1,40 @@
+package controllers
+
+import (
+	"errors"
+	"flickly/internal/domain/core"
+	"strconv"
+	"strings"
+)
+
+// ETag formata a versão de uma entidade como uma ETag forte
+func ETag(version int64) string {
+	return `"` + strconv.FormatInt(version, 10) + `"`
+}
+
+// ParseIfMatch extrai a versão esperada do cabeçalho If-Match.
+// Retorna zero quando o cabeçalho está ausente ou é "*", e ErrPreconditionFailed quando
+// o valor não pode corresponder a nenhuma ETag emitida pela API.
+func ParseIfMatch(header string) (int64, error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, nil
+	}
+	if len(header) < 2 || !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) {
+		return 0, core.ErrPreconditionFailed(errors.New("If-Match must be a single strong ETag"))
+	}
+	version, err := strconv.ParseInt(header[1:len(header)-1], 10, 64)
+	if err != nil || version <= 0 {
+		return 0, core.ErrPreconditionFailed(errors.New("If-Match does not match any version"))
+	}
+	return version, nil
+}
+
+// RequireIfMatch é como ParseIfMatch, mas retorna ErrPreconditionRequired quando o cabeçalho está ausente,
+// impedindo que a atualização ignore a verificação de concorrência. "*" continua aceito e dispensa a verificação.
+func RequireIfMatch(header string) (int64, error) {
+	if strings.TrimSpace(header) == "" {
+		return 0, core.ErrPreconditionRequired(errors.New("If-Match header is required"))
+	}
+	return ParseIfMatch(header)
+}
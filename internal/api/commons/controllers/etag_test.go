@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"flickly/internal/domain/core"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestETag(t *testing.T) {
+	assert.Equal(t, `"3"`, ETag(3), "A ETag deve ser a versão entre aspas")
+}
+
+func TestParseIfMatch(t *testing.T) {
+	// Valores aceitos
+	accepted := map[string]int64{
+		"":       0,
+		"*":      0,
+		`"1"`:    1,
+		` "42" `: 42,
+	}
+	for header, expected := range accepted {
+		version, err := ParseIfMatch(header)
+		assert.NoError(t, err, "If-Match %q deve ser aceito", header)
+		assert.Equal(t, expected, version, "If-Match %q deve resultar na versão %d", header, expected)
+	}
+
+	// Valores que nunca correspondem a uma ETag emitida
+	for _, header := range []string{`W/"1"`, `"abc"`, `"1", "2"`, `1`, `"0"`, `"`} {
+		_, err := ParseIfMatch(header)
+		domainError, ok := err.(*core.DomainError)
+		assert.True(t, ok, "If-Match %q deve retornar DomainError", header)
+		assert.Equal(t, 412, domainError.StatusCode, "If-Match %q deve retornar 412", header)
+	}
+}
+
+func TestRequireIfMatch(t *testing.T) {
+	// Cabeçalho ausente
+	_, err := RequireIfMatch(" ")
+	domainError, ok := err.(*core.DomainError)
+	assert.True(t, ok, "A ausência do If-Match deve retornar DomainError")
+	assert.Equal(t, 428, domainError.StatusCode, "A ausência do If-Match deve retornar 428")
+
+	// Cabeçalho presente
+	version, err := RequireIfMatch(`"2"`)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), version, "A versão informada deve ser retornada")
+}
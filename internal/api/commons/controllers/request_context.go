@@ -0,0 +1,24 @@
+package controllers
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequestContext retorna o contexto da requisição HTTP, que carrega o usuário autenticado, o ID de correlação
+// e o cancelamento da requisição. É o contexto repassado ao mediator e aos repositórios.
+func RequestContext(c *gin.Context) context.Context {
+	if c.Request == nil {
+		return context.Background()
+	}
+	return c.Request.Context()
+}
+
+// SetRequestContext substitui o contexto da requisição HTTP; usado pelos middlewares para acrescentar valores
+func SetRequestContext(c *gin.Context, ctx context.Context) {
+	if c.Request == nil {
+		return
+	}
+	c.Request = c.Request.WithContext(ctx)
+}
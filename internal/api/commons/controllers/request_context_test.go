@@ -0,0 +1,27 @@
+package controllers
+
+import (
+	"context"
+	"flickly/internal/domain/core"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestContext(t *testing.T) {
+	// Configuração
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+
+	// Execução e verificações sem requisição HTTP
+	assert.Equal(t, context.Background(), RequestContext(c), "Sem requisição deve retornar um contexto vazio")
+	SetRequestContext(c, core.WithCorrelationID(context.Background(), "ignored"))
+	assert.Empty(t, core.CorrelationIDFromContext(RequestContext(c)))
+
+	// Execução e verificações com requisição HTTP
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	SetRequestContext(c, core.WithCorrelationID(RequestContext(c), "abc-123"))
+	assert.Equal(t, "abc-123", core.CorrelationIDFromContext(RequestContext(c)), "Os valores acrescentados devem ser lidos do contexto da requisição")
+}
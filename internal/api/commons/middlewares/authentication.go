@@ -0,0 +1,49 @@
+package middlewares
+
+import (
+	"errors"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/api/commons/view_model"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/security"
+	"github.com/gin-gonic/gin"
+	"strings"
+)
+
+// Authentication identifica o usuário pelo token Bearer do cabeçalho Authorization.
+// Requisições sem token válido seguem como anônimas; use RequireRole para restringir o acesso.
+func Authentication(tokenService security.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+		if found && tokenService != nil {
+			if principal, err := tokenService.Validate(strings.TrimSpace(token)); err == nil {
+				controllers.SetRequestContext(c, security.WithPrincipal(controllers.RequestContext(c), principal))
+			}
+		}
+		c.Next()
+	}
+}
+
+// RequireRole responde 401 para requisições anônimas e 403 para usuários sem o papel informado
+func RequireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		principal := security.PrincipalFromContext(controllers.RequestContext(c))
+		if !principal.IsAuthenticated() {
+			abortWithError(c, core.ErrUnauthorized(errors.New("missing or invalid access token")))
+			return
+		}
+		if !principal.HasRole(role) {
+			abortWithError(c, core.ErrForbidden(errors.New("role "+role+" required")))
+			return
+		}
+		c.Next()
+	}
+}
+
+func abortWithError(c *gin.Context, domainError *core.DomainError) {
+	c.AbortWithStatusJSON(domainError.StatusCode, view_model.ErrorResponse{
+		Code:            domainError.Code,
+		Message:         domainError.Message,
+		InternalMessage: domainError.Error(),
+	})
+}
@@ -0,0 +1,87 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/api/commons/view_model"
+	"flickly/internal/domain/core/security"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockTokenService aceita apenas os tokens cadastrados
+type MockTokenService struct {
+	Principals map[string]security.Principal
+}
+
+func (s *MockTokenService) Issue(principal security.Principal) (string, time.Duration, error) {
+	return principal.Subject, time.Hour, nil
+}
+
+func (s *MockTokenService) Validate(token string) (security.Principal, error) {
+	if principal, ok := s.Principals[token]; ok {
+		return principal, nil
+	}
+	return security.Principal{}, security.ErrInvalidToken
+}
+
+func setupAuthenticationRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	tokenService := &MockTokenService{Principals: map[string]security.Principal{
+		"admin-token": {Subject: "admin", Roles: []string{security.RoleAdmin}},
+		"user-token":  {Subject: "user"},
+	}}
+	router := gin.New()
+	router.Use(Authentication(tokenService))
+	router.GET("/public", func(c *gin.Context) {
+		c.String(http.StatusOK, security.PrincipalFromContext(controllers.RequestContext(c)).Subject)
+	})
+	router.GET("/admin", RequireRole(security.RoleAdmin), func(c *gin.Context) {
+		c.String(http.StatusOK, "ok")
+	})
+	return router
+}
+
+func request(router *gin.Engine, path string, token string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestAuthentication(t *testing.T) {
+	// Configuração
+	router := setupAuthenticationRouter()
+
+	// Execução e verificações
+	assert.Equal(t, "user", request(router, "/public", "user-token").Body.String(), "O usuário do token deve ser identificado")
+	invalid := request(router, "/public", "token-simulado")
+	assert.Equal(t, http.StatusOK, invalid.Code, "Tokens inválidos não devem bloquear rotas públicas")
+	assert.Empty(t, invalid.Body.String(), "Tokens inválidos devem resultar em requisição anônima")
+}
+
+func TestRequireRole(t *testing.T) {
+	// Configuração
+	router := setupAuthenticationRouter()
+
+	// Execução
+	anonymous := request(router, "/admin", "")
+	user := request(router, "/admin", "user-token")
+	admin := request(router, "/admin", "admin-token")
+
+	// Verificações
+	assert.Equal(t, http.StatusUnauthorized, anonymous.Code, "Requisições anônimas devem receber 401")
+	var errorResponse view_model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(anonymous.Body.Bytes(), &errorResponse))
+	assert.Equal(t, 6, errorResponse.Code, "O erro deve seguir o formato padrão")
+	assert.Equal(t, http.StatusForbidden, user.Code, "Usuários sem o papel devem receber 403")
+	assert.Equal(t, http.StatusOK, admin.Code, "Administradores devem ter acesso")
+}
@@ -0,0 +1,24 @@
+package middlewares
+
+import (
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// CorrelationIDHeader é o cabeçalho usado para propagar o ID de correlação
+const CorrelationIDHeader = "X-Correlation-ID"
+
+// CorrelationID usa o ID de correlação recebido ou gera um novo, devolvendo-o na resposta
+func CorrelationID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		correlationID := c.GetHeader(CorrelationIDHeader)
+		if correlationID == "" || len(correlationID) > 128 {
+			correlationID = uuid.NewString()
+		}
+		controllers.SetRequestContext(c, core.WithCorrelationID(controllers.RequestContext(c), correlationID))
+		c.Header(CorrelationIDHeader, correlationID)
+		c.Next()
+	}
+}
@@ -0,0 +1,41 @@
+package middlewares
+
+import (
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationID(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var received string
+	router.Use(CorrelationID())
+	router.GET("/", func(c *gin.Context) {
+		received = core.CorrelationIDFromContext(controllers.RequestContext(c))
+	})
+
+	// Execução - ID informado pelo cliente
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set(CorrelationIDHeader, "abc-123")
+	router.ServeHTTP(w, req)
+
+	// Verificações
+	assert.Equal(t, "abc-123", received, "O ID recebido deve ser usado")
+	assert.Equal(t, "abc-123", w.Header().Get(CorrelationIDHeader), "O ID deve ser devolvido na resposta")
+
+	// Execução - sem ID
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Verificações
+	assert.NotEmpty(t, received, "Um novo ID deve ser gerado")
+	assert.Equal(t, received, w.Header().Get(CorrelationIDHeader))
+}
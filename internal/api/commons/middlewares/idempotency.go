@@ -0,0 +1,144 @@
+package middlewares
+
+import (
+	"bytes"
+	"errors"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/security"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Cabeçalhos usados pelo middleware de idempotência
+const (
+	IdempotencyKeyHeader     = "Idempotency-Key"
+	IdempotentReplayedHeader = "Idempotent-Replayed"
+	idempotencyScope         = "http"
+	// MaxIdempotentBodySize é o tamanho máximo do corpo das requisições com Idempotency-Key,
+	// que é lido inteiro para calcular o hash
+	MaxIdempotentBodySize = 1 << 20
+)
+
+// replayedHeaders são os cabeçalhos da primeira resposta repetidos junto com o corpo
+var replayedHeaders = []string{"Content-Type", "Location", "ETag"}
+
+// Idempotency faz com que requisições POST, PUT, PATCH e DELETE com o cabeçalho Idempotency-Key sejam
+// executadas uma única vez por usuário: repetições recebem a primeira resposta (com Idempotent-Replayed: true),
+// a mesma chave com outro conteúdo recebe 422 e uma repetição enquanto a primeira executa recebe 409.
+// Respostas 5xx não são guardadas, permitindo repetir a requisição. Corpos maiores que MaxIdempotentBodySize recebem 413.
+// Deve ser registrado depois de Authentication.
+func Idempotency(store idempotency.Store, ttl time.Duration) gin.HandlerFunc {
+	if ttl <= 0 {
+		ttl = idempotency.DefaultTTL
+	}
+	return func(c *gin.Context) {
+		key := c.GetHeader(IdempotencyKeyHeader)
+		if key == "" || store == nil || !isMutating(c.Request.Method) {
+			c.Next()
+			return
+		}
+		if len(key) > idempotency.MaxKeyLength {
+			abortWithError(c, core.ErrInvalidArgument(fmt.Errorf("%s must have at most %d characters", IdempotencyKeyHeader, idempotency.MaxKeyLength)))
+			return
+		}
+		body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, MaxIdempotentBodySize))
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				abortWithError(c, core.ErrPayloadTooLarge(err))
+				return
+			}
+			abortWithError(c, core.ErrInvalidArgument(err))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		fingerprint := idempotency.Fingerprint([]byte(c.Request.Method), []byte(c.Request.URL.RequestURI()), body)
+		subject := security.PrincipalFromContext(controllers.RequestContext(c)).Subject
+		scopedKey := idempotency.ScopedKey(idempotencyScope, subject, key)
+		existing, reserved, err := store.Reserve(idempotency.NewRecord(scopedKey, fingerprint, idempotency.DefaultLockTimeout))
+		if err != nil {
+			abortWithError(c, core.ErrInternal(err))
+			return
+		}
+		if !reserved {
+			replay(c, existing, fingerprint)
+			return
+		}
+
+		completed := false
+		defer func() {
+			if !completed {
+				if releaseErr := store.Release(scopedKey); releaseErr != nil {
+					log.Printf("idempotency: failed to release key %s: %v", scopedKey, releaseErr)
+				}
+			}
+		}()
+
+		writer := &recordingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+
+		if writer.Status() >= http.StatusInternalServerError {
+			return
+		}
+		headers := make(map[string]string)
+		for _, name := range replayedHeaders {
+			if value := writer.Header().Get(name); value != "" {
+				headers[name] = value
+			}
+		}
+		response := idempotency.Response{StatusCode: writer.Status(), Headers: headers, Body: writer.body.Bytes()}
+		if err := store.Complete(scopedKey, response, time.Now().Add(ttl)); err != nil {
+			log.Printf("idempotency: failed to store response for key %s: %v", scopedKey, err)
+			return
+		}
+		completed = true
+	}
+}
+
+// replay devolve a resposta guardada ou o erro que impede repeti-la
+func replay(c *gin.Context, record *idempotency.Record, fingerprint string) {
+	if err := record.Check(fingerprint); err != nil {
+		var domainError *core.DomainError
+		if !errors.As(err, &domainError) {
+			domainError = core.ErrInternal(err)
+		}
+		abortWithError(c, domainError)
+		return
+	}
+	for name, value := range record.Headers {
+		c.Header(name, value)
+	}
+	c.Header(IdempotentReplayedHeader, "true")
+	c.Status(record.StatusCode)
+	_, _ = c.Writer.Write(record.Body)
+	c.Abort()
+}
+
+func isMutating(method string) bool {
+	return method == http.MethodPost || method == http.MethodPut || method == http.MethodPatch || method == http.MethodDelete
+}
+
+// recordingWriter guarda uma cópia do corpo escrito na resposta
+type recordingWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *recordingWriter) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *recordingWriter) WriteString(data string) (int, error) {
+	w.body.WriteString(data)
+	return w.ResponseWriter.WriteString(data)
+}
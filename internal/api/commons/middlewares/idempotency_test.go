@@ -0,0 +1,191 @@
+package middlewares
+
+import (
+	"encoding/json"
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/api/commons/view_model"
+	"flickly/internal/domain/core/security"
+	infraidempotency "flickly/internal/infra/data/idempotency"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupIdempotencyRouter cria um roteador cujo POST /items conta as execuções; a resposta depende de status
+func setupIdempotencyRouter(executions *int, status *int, handler gin.HandlerFunc) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		if subject := c.GetHeader("X-Test-User"); subject != "" {
+			controllers.SetRequestContext(c, security.WithPrincipal(controllers.RequestContext(c), security.Principal{Subject: subject}))
+		}
+	}, Idempotency(infraidempotency.NewIdempotencyMemoryStore(), time.Hour))
+	if handler == nil {
+		handler = func(c *gin.Context) {
+			*executions++
+			body, _ := io.ReadAll(c.Request.Body)
+			c.Header("Location", "/items/1")
+			c.JSON(*status, gin.H{"execution": *executions, "body": string(body)})
+		}
+	}
+	router.POST("/items", handler)
+	router.GET("/items", handler)
+	return router
+}
+
+func sendIdempotent(router *gin.Engine, method string, key string, body string, user string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "/items", strings.NewReader(body))
+	if key != "" {
+		req.Header.Set(IdempotencyKeyHeader, key)
+	}
+	if user != "" {
+		req.Header.Set("X-Test-User", user)
+	}
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestIdempotency_Replay(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusCreated
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	first := sendIdempotent(router, http.MethodPost, "key-1", `{"name":"a"}`, "")
+	second := sendIdempotent(router, http.MethodPost, "key-1", `{"name":"a"}`, "")
+
+	// Verificações
+	assert.Equal(t, 1, executions, "A requisição deve ser executada uma única vez")
+	assert.Equal(t, http.StatusCreated, second.Code, "A repetição deve receber o status da primeira resposta")
+	assert.Equal(t, first.Body.String(), second.Body.String(), "A repetição deve receber o corpo da primeira resposta")
+	assert.Equal(t, "/items/1", second.Header().Get("Location"), "Os cabeçalhos relevantes devem ser repetidos")
+	assert.Equal(t, "application/json; charset=utf-8", second.Header().Get("Content-Type"))
+	assert.Equal(t, "true", second.Header().Get(IdempotentReplayedHeader), "A repetição deve ser sinalizada")
+	assert.Empty(t, first.Header().Get(IdempotentReplayedHeader))
+	assert.JSONEq(t, `{"execution":1,"body":"{\"name\":\"a\"}"}`, first.Body.String(), "O corpo deve continuar disponível ao handler")
+}
+
+func TestIdempotency_KeyReused(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusCreated
+	router := setupIdempotencyRouter(&executions, &status, nil)
+	sendIdempotent(router, http.MethodPost, "key-1", `{"name":"a"}`, "")
+
+	// Execução
+	w := sendIdempotent(router, http.MethodPost, "key-1", `{"name":"b"}`, "")
+
+	// Verificações
+	var response view_model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, http.StatusUnprocessableEntity, w.Code, "Outro conteúdo com a mesma chave deve receber 422")
+	assert.Equal(t, 13, response.Code)
+	assert.Equal(t, 1, executions)
+}
+
+func TestIdempotency_InProgress(t *testing.T) {
+	// Configuração
+	var router *gin.Engine
+	var duplicate *httptest.ResponseRecorder
+	executions := 0
+	router = setupIdempotencyRouter(nil, nil, func(c *gin.Context) {
+		executions++
+		if duplicate == nil {
+			duplicate = sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+		}
+		c.Status(http.StatusNoContent)
+	})
+
+	// Execução
+	first := sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+
+	// Verificações
+	assert.Equal(t, http.StatusNoContent, first.Code)
+	assert.Equal(t, http.StatusConflict, duplicate.Code, "Uma repetição durante a execução deve receber 409")
+	assert.Equal(t, 1, executions)
+}
+
+func TestIdempotency_ServerErrorIsNotStored(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusInternalServerError
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	failed := sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+	status = http.StatusCreated
+	retried := sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+
+	// Verificações
+	assert.Equal(t, http.StatusInternalServerError, failed.Code)
+	assert.Equal(t, http.StatusCreated, retried.Code, "Após um erro 5xx, a requisição deve ser executada de novo")
+	assert.Equal(t, 2, executions)
+}
+
+func TestIdempotency_ClientErrorIsStored(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusBadRequest
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+	status = http.StatusCreated
+	replayed := sendIdempotent(router, http.MethodPost, "key-1", `{}`, "")
+
+	// Verificações
+	assert.Equal(t, http.StatusBadRequest, replayed.Code, "Respostas 4xx devem ser repetidas")
+	assert.Equal(t, 1, executions)
+}
+
+func TestIdempotency_Bypass(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusOK
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	sendIdempotent(router, http.MethodPost, "", `{}`, "")
+	sendIdempotent(router, http.MethodPost, "", `{}`, "")
+	sendIdempotent(router, http.MethodGet, "key-1", ``, "")
+	sendIdempotent(router, http.MethodGet, "key-1", ``, "")
+	sendIdempotent(router, http.MethodPost, "key-1", `{}`, "user-1")
+	sendIdempotent(router, http.MethodPost, "key-1", `{}`, "user-2")
+
+	// Verificações
+	assert.Equal(t, 6, executions, "Requisições sem chave, de leitura ou de outro usuário não devem ser repetidas")
+}
+
+func TestIdempotency_KeyTooLong(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusOK
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	w := sendIdempotent(router, http.MethodPost, strings.Repeat("a", 256), `{}`, "")
+
+	// Verificações
+	assert.Equal(t, http.StatusBadRequest, w.Code, "Chaves longas demais devem ser rejeitadas")
+	assert.Equal(t, 0, executions)
+}
+
+func TestIdempotency_BodyTooLarge(t *testing.T) {
+	// Configuração
+	executions, status := 0, http.StatusOK
+	router := setupIdempotencyRouter(&executions, &status, nil)
+
+	// Execução
+	w := sendIdempotent(router, http.MethodPost, "key-1", strings.Repeat("a", MaxIdempotentBodySize+1), "")
+	accepted := sendIdempotent(router, http.MethodPost, "key-2", strings.Repeat("a", MaxIdempotentBodySize), "")
+
+	// Verificações
+	assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code, "Corpos maiores que o limite devem ser rejeitados")
+	var response view_model.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, 17, response.Code)
+	assert.Equal(t, http.StatusOK, accepted.Code, "Corpos dentro do limite devem ser aceitos")
+	assert.Equal(t, 1, executions, "A requisição rejeitada não deve ser executada")
+}
@@ -0,0 +1,25 @@
+package middlewares
+
+import (
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"github.com/gin-gonic/gin"
+)
+
+// ServiceScope cria um escopo de serviços por requisição, com o security.Principal autenticado registrado nele,
+// para que os serviços Scoped (unidade de trabalho, usuário atual) sejam resolvidos uma vez por requisição.
+// Deve ser usado depois de Authentication.
+func ServiceScope(collection utilities.IServiceCollection) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		ctx := controllers.RequestContext(c)
+		scope := collection.CreateScope()
+		utilities.AddService[security.Principal](scope, security.PrincipalFromContext(ctx))
+		controllers.SetRequestContext(c, utilities.WithScope(ctx, scope))
+		c.Next()
+		// Fecha os serviços Scoped criados durante a requisição
+		if err := scope.Stop(ctx); err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
@@ -0,0 +1,82 @@
+package middlewares
+
+import (
+	"flickly/internal/api/commons/controllers"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// requestCounter é um serviço Scoped usado para verificar a instância por requisição
+type requestCounter struct {
+	calls int
+}
+
+func TestServiceScope(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	collection := utilities.NewServiceCollection()
+	created := 0
+	utilities.AddScoped[*requestCounter](collection, func(collection utilities.IServiceCollection) *requestCounter {
+		created++
+		return &requestCounter{}
+	})
+	router := gin.New()
+	var subject string
+	var calls int
+	router.Use(func(c *gin.Context) {
+		controllers.SetRequestContext(c, security.WithPrincipal(controllers.RequestContext(c), security.Principal{Subject: "user-1"}))
+	}, ServiceScope(collection))
+	router.GET("/", func(c *gin.Context) {
+		scope := utilities.ScopeFromContext(controllers.RequestContext(c))
+		subject = utilities.GetService[security.Principal](scope).Subject
+		utilities.GetService[*requestCounter](scope).calls++
+		utilities.GetService[*requestCounter](scope).calls++
+		calls = utilities.GetService[*requestCounter](scope).calls
+	})
+
+	// Execução
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Verificações
+	assert.Equal(t, "user-1", subject, "O principal da requisição deve ser registrado no escopo")
+	assert.Equal(t, 2, calls, "A mesma instância deve ser usada durante a requisição")
+	assert.Equal(t, 2, created, "Cada requisição deve ter a sua própria instância")
+}
+
+// closableService registra se foi fechado ao fim da requisição
+type closableService struct {
+	closed bool
+}
+
+func (s *closableService) Close() error {
+	s.closed = true
+	return nil
+}
+
+func TestServiceScope_ClosesScopedServices(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	collection := utilities.NewServiceCollection()
+	utilities.AddScoped[*closableService](collection, func(collection utilities.IServiceCollection) *closableService {
+		return &closableService{}
+	})
+	router := gin.New()
+	var service *closableService
+	router.Use(ServiceScope(collection))
+	router.GET("/", func(c *gin.Context) {
+		service = utilities.GetService[*closableService](utilities.ScopeFromContext(controllers.RequestContext(c)))
+	})
+
+	// Execução
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Verificações
+	assert.True(t, service.closed, "Os serviços Scoped devem ser fechados ao fim da requisição")
+}
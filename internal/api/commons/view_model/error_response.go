@@ -1,7 +1,16 @@
 package view_model
 
+// ErrorResponse é o corpo das respostas de erro, mapeado de core.DomainError pelo AutoMapper:
+// InternalMessage recebe o Error() do erro e Violations, as violações campo a campo.
 type ErrorResponse struct {
-	Code            int    `json:"code"`
-	Message         string `json:"message"`
-	InternalMessage string `json:"internalMessage,omitempty"`
+	Code            int                 `json:"code"`
+	Message         string              `json:"message"`
+	InternalMessage string              `json:"internalMessage,omitempty" mapper:"Error"`
+	Violations      []ViolationResponse `json:"violations,omitempty"`
+}
+
+type ViolationResponse struct {
+	Field   string `json:"field,omitempty"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
 }
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"flickly/internal/api/commons/controllers"
+	viewmodels "flickly/internal/api/jobs/viewmodels"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"net/http"
+)
+
+type JobController struct {
+	controllers.Controller
+	jobQueue jobs.Queue
+	mapper   utilities.Mapper
+}
+
+// NewJobController cria uma nova instância de JobController
+func NewJobController(collection utilities.IServiceCollection) *JobController {
+	return &JobController{
+		Controller: controllers.NewController(collection),
+		jobQueue:   utilities.GetService[jobs.Queue](collection),
+		mapper:     utilities.GetService[utilities.Mapper](collection),
+	}
+}
+
+// GetJob consulta o status e o resultado de um job
+// @Summary Consultar job
+// @Description Retorna o status de um job enfileirado e, quando concluído, o resultado. Jobs enfileirados por um usuário só podem ser consultados por ele ou por um admin
+// @Tags jobs
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do job"
+// @Success 200 {object} viewmodels.JobResponse
+// @Failure 404 {object} object
+// @Router /jobs/{id} [get]
+func (j *JobController) GetJob(c *gin.Context) {
+	j.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return nil, core.ErrJobNotFound(err)
+		}
+
+		job, err := j.jobQueue.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		// Jobs de outros usuários são tratados como inexistentes para não revelar seus IDs
+		if job == nil || !canRead(security.PrincipalFromContext(controllers.RequestContext(c)), job) {
+			return nil, core.ErrJobNotFound(fmt.Errorf("job %s not found", id))
+		}
+
+		var jobResponse viewmodels.JobResponse
+		if err = j.mapper.Map(job, &jobResponse); err != nil {
+			return nil, err
+		}
+		return jobResponse, nil
+	}, http.StatusOK)
+}
+
+// canRead indica se o usuário pode consultar o job
+func canRead(principal security.Principal, job *jobs.Job) bool {
+	if job.Principal.Subject == "" {
+		return true
+	}
+	return principal.Subject == job.Principal.Subject || principal.HasRole(security.RoleAdmin)
+}
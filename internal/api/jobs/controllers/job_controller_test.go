@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"encoding/json"
+	"flickly/internal/api/commons/auto_mapper"
+	viewmodels "flickly/internal/api/jobs/viewmodels"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func setupJobController(queue jobs.Queue) *JobController {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[jobs.Queue](serviceCollection, queue)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+	auto_mapper.ViewModelAutomapperConfig(serviceCollection)
+	return NewJobController(serviceCollection)
+}
+
+func getJob(controller *JobController, id string, principal security.Principal) *httptest.ResponseRecorder {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/jobs/"+id, nil)
+	c.Request = c.Request.WithContext(security.WithPrincipal(c.Request.Context(), principal))
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	controller.GetJob(c)
+	return w
+}
+
+func newOwnedJob(t *testing.T, queue jobs.Queue, subject string) *jobs.Job {
+	job, err := jobs.NewJob("commands.ExportCommand", map[string]string{"format": "csv"}, security.Principal{Subject: subject}, "corr-1")
+	assert.NoError(t, err)
+	assert.NoError(t, queue.Enqueue(job))
+	return job
+}
+
+func TestGetJob(t *testing.T) {
+	// Configuração
+	queue := messaging.NewJobMemoryQueue()
+	job := newOwnedJob(t, queue, "user-1")
+	_ = queue.Complete(job.ID, []byte(`{"url":"/exports/1.csv"}`), time.Now())
+	controller := setupJobController(queue)
+
+	// Execução
+	w := getJob(controller, job.ID.String(), security.Principal{Subject: "user-1"})
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	var response viewmodels.JobResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, job.ID, response.ID)
+	assert.Equal(t, jobs.StatusSucceeded, response.Status, "O status do job deve ser retornado")
+	assert.JSONEq(t, `{"url":"/exports/1.csv"}`, string(response.Result), "O resultado deve ser retornado como JSON")
+}
+
+func TestGetJob_OtherUsers(t *testing.T) {
+	// Configuração
+	queue := messaging.NewJobMemoryQueue()
+	job := newOwnedJob(t, queue, "user-1")
+	anonymousJob := newOwnedJob(t, queue, "")
+	controller := setupJobController(queue)
+	admin := security.Principal{Subject: "admin", Roles: []string{security.RoleAdmin}}
+
+	// Execução e verificações
+	assert.Equal(t, http.StatusNotFound, getJob(controller, job.ID.String(), security.Principal{Subject: "user-2"}).Code,
+		"Jobs de outros usuários não devem ser revelados")
+	assert.Equal(t, http.StatusNotFound, getJob(controller, job.ID.String(), security.Principal{}).Code,
+		"Jobs de usuários não devem ser revelados a requisições anônimas")
+	assert.Equal(t, http.StatusOK, getJob(controller, job.ID.String(), admin).Code, "Administradores podem consultar qualquer job")
+	assert.Equal(t, http.StatusOK, getJob(controller, anonymousJob.ID.String(), security.Principal{}).Code,
+		"Jobs enfileirados sem usuário podem ser consultados por qualquer um")
+}
+
+func TestGetJob_NotFound(t *testing.T) {
+	// Configuração
+	controller := setupJobController(messaging.NewJobMemoryQueue())
+
+	// Execução e verificações
+	assert.Equal(t, http.StatusNotFound, getJob(controller, uuid.New().String(), security.Principal{}).Code, "Jobs inexistentes devem retornar 404")
+	assert.Equal(t, http.StatusNotFound, getJob(controller, "abc", security.Principal{}).Code, "IDs inválidos devem retornar 404")
+}
@@ -0,0 +1,14 @@
+package jobs
+
+import (
+	"flickly/internal/api/jobs/controllers"
+	"flickly/internal/infra/crosscutting/utilities"
+	"github.com/gin-gonic/gin"
+)
+
+// Startup configura as rotas de consulta de jobs
+func Startup(router *gin.Engine, serviceCollection utilities.IServiceCollection) {
+	jobController := controllers.NewJobController(serviceCollection)
+
+	router.GET("/jobs/:id", jobController.GetJob)
+}
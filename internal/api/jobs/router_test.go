@@ -0,0 +1,39 @@
+package jobs
+
+import (
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStartup(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	serviceCollection := utilities.NewServiceCollection()
+	queue := messaging.NewJobMemoryQueue()
+	job, _ := jobs.NewJob("commands.ExportCommand", nil, security.Principal{}, "")
+	_ = queue.Enqueue(job)
+	utilities.AddService[jobs.Queue](serviceCollection, queue)
+	utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+
+	// Execução
+	Startup(router, serviceCollection)
+
+	// Verificações
+	routes := router.Routes()
+	assert.Len(t, routes, 1, "Deve haver uma rota registrada")
+	assert.Equal(t, http.MethodGet, routes[0].Method)
+	assert.Equal(t, "/jobs/:id", routes[0].Path)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/jobs/"+job.ID.String(), nil))
+	assert.Equal(t, http.StatusOK, w.Code, "A rota deve ser atendida pelo controlador")
+}
@@ -0,0 +1,21 @@
+package view_models
+
+import (
+	"encoding/json"
+	"github.com/google/uuid"
+	"time"
+)
+
+type JobResponse struct {
+	ID            uuid.UUID       `json:"id"`
+	RequestType   string          `json:"requestType"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts"`
+	MaxAttempts   int             `json:"maxAttempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	UpdatedAt     time.Time       `json:"updatedAt"`
+	LastError     string          `json:"lastError,omitempty"`
+	Result        json.RawMessage `json:"result,omitempty" swaggertype:"object"`
+	CorrelationID string          `json:"correlationId,omitempty"`
+}
@@ -1,31 +1,44 @@
 package controllers
 
 import (
+	"errors"
 	"flickly/internal/api/commons/controllers"
 	viewmodels "flickly/internal/api/users/viewmodels"
 	"flickly/internal/domain/core"
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
 	"flickly/internal/domain/users/commands"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/queries"
+	"flickly/internal/domain/users/readmodels"
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"net/http"
 )
 
 type UserController struct {
 	controllers.Controller
-	mediator       mediator.Mediator
+	mediator       mediator.Sender
 	userRepository repositories.IUserRepository
 	mapper         utilities.Mapper
+	tokenService   security.TokenService
+	roleProvider   security.RoleProvider
+	passwordHasher security.PasswordHasher
 }
 
 // NewUserController cria uma nova instância de UserController
 func NewUserController(collection utilities.IServiceCollection) *UserController {
 	return &UserController{
 		Controller:     controllers.NewController(collection),
-		mediator:       utilities.GetService[mediator.Mediator](collection),
+		mediator:       utilities.GetService[mediator.Sender](collection),
 		userRepository: utilities.GetService[repositories.IUserRepository](collection),
 		mapper:         utilities.GetService[utilities.Mapper](collection),
+		tokenService:   utilities.GetService[security.TokenService](collection),
+		roleProvider:   utilities.GetService[security.RoleProvider](collection),
+		passwordHasher: utilities.GetService[security.PasswordHasher](collection),
 	}
 }
 
@@ -52,19 +65,119 @@ func (u *UserController) PostUser(c *gin.Context) {
 			return nil, err
 		}
 
-		response, err := u.mediator.Send(c, createUserCommand)
+		user, err := mediator.Send[*entities.User](controllers.RequestContext(c), u.mediator, createUserCommand)
 		if err != nil {
 			return nil, err
 		}
 
 		var userResponse viewmodels.CreateUserResponse
-		if err = u.mapper.Map(response, &userResponse); err != nil {
+		if err = u.mapper.Map(user, &userResponse); err != nil {
 			return nil, err
 		}
+		c.Header("ETag", controllers.ETag(userResponse.Version))
 		return userResponse, nil
 	}, http.StatusCreated)
 }
 
+// GetUser retorna um usuário pelo ID
+// @Summary Obter usuário
+// @Description Retorna o usuário, lido do modelo de leitura, e sua versão atual no cabeçalho ETag
+// @Tags users
+// @Produce json
+// @Param id path string true "ID do usuário"
+// @Success 200 {object} viewmodels.UserResponse
+// @Failure 404 {object} object
+// @Router /user/{id} [get]
+func (u *UserController) GetUser(c *gin.Context) {
+	u.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return nil, core.ErrUserNotFound(err)
+		}
+
+		userView, err := mediator.Send[*readmodels.UserView](controllers.RequestContext(c), u.mediator, queries.GetUserQuery{ID: id})
+		if err != nil {
+			return nil, err
+		}
+
+		var userResponse viewmodels.UserResponse
+		if err = u.mapper.Map(userView, &userResponse); err != nil {
+			return nil, err
+		}
+		c.Header("ETag", controllers.ETag(userResponse.Version))
+		return userResponse, nil
+	}, http.StatusOK)
+}
+
+// PutUser atualiza um usuário
+// @Summary Atualizar usuário
+// @Description Atualiza o usuário. Exige o token do próprio usuário ou o papel admin, e a ETag obtida no GET em If-Match para evitar sobrescrever alterações concorrentes
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "ID do usuário"
+// @Param If-Match header string true "ETag da versão lida"
+// @Param user body viewmodels.UpdateUserRequest true "Dados do usuário"
+// @Success 200 {object} viewmodels.UserResponse
+// @Failure 401 {object} object
+// @Failure 403 {object} object
+// @Failure 404 {object} object
+// @Failure 409 {object} object
+// @Failure 412 {object} object
+// @Failure 428 {object} object
+// @Router /user/{id} [put]
+func (u *UserController) PutUser(c *gin.Context) {
+	u.SuccessOrErrorResponse(c, func(ct *gin.Context) (interface{}, error) {
+		id, err := uuid.Parse(c.Param("id"))
+		if err != nil {
+			return nil, core.ErrUserNotFound(err)
+		}
+		if err = canUpdate(security.PrincipalFromContext(controllers.RequestContext(c)), id); err != nil {
+			return nil, err
+		}
+		expectedVersion, err := controllers.RequireIfMatch(c.GetHeader("If-Match"))
+		if err != nil {
+			return nil, err
+		}
+
+		var updateUserRequest viewmodels.UpdateUserRequest
+		if err := c.ShouldBindJSON(&updateUserRequest); err != nil {
+			return nil, err
+		}
+
+		var updateUserCommand commands.UpdateUserCommand
+		if err := u.mapper.Map(updateUserRequest, &updateUserCommand); err != nil {
+			return nil, err
+		}
+		updateUserCommand.ID = id
+		updateUserCommand.ExpectedVersion = expectedVersion
+
+		user, err := mediator.Send[*entities.User](controllers.RequestContext(c), u.mediator, updateUserCommand)
+		if err != nil {
+			return nil, err
+		}
+
+		var userResponse viewmodels.UserResponse
+		if err = u.mapper.Map(user, &userResponse); err != nil {
+			return nil, err
+		}
+		c.Header("ETag", controllers.ETag(userResponse.Version))
+		return userResponse, nil
+	}, http.StatusOK)
+}
+
+// canUpdate permite a atualização apenas pelo próprio usuário ou por administradores
+func canUpdate(principal security.Principal, id uuid.UUID) error {
+	if !principal.IsAuthenticated() {
+		return core.ErrUnauthorized(errors.New("missing or invalid access token"))
+	}
+	if principal.Subject != id.String() && !principal.HasRole(security.RoleAdmin) {
+		return core.ErrForbidden(fmt.Errorf("user %s cannot update user %s", principal.Subject, id))
+	}
+	return nil
+}
+
 // PostOauthToken autentica um usuário e gera um token
 // @Summary Gerar token de autenticação
 // @Description Autentica um usuário e retorna um token de acesso
@@ -87,7 +200,6 @@ func (u *UserController) PostOauthToken(c *gin.Context) {
 		username := c.PostForm("username")
 		password := c.PostForm("password")
 
-		// Aqui você deve implementar a lógica para verificar as credenciais
 		if grantType == "password" && clientID == "my_client_id" && clientSecret == "my_client_secret" {
 
 			user, err := u.userRepository.GetUserByEmail(username)
@@ -96,12 +208,21 @@ func (u *UserController) PostOauthToken(c *gin.Context) {
 				return nil, err
 			}
 
-			if user != nil && password != "" {
-				// Se as credenciais estão corretas, retornar um token de exemplo
+			// Usuários sem senha cadastrada não podem se autenticar
+			if user != nil && u.passwordHasher.Verify(user.PasswordHash, password) {
+				// Se as credenciais estão corretas, emitir o token com a identidade e os papéis do usuário
+				principal := security.Principal{Subject: user.ID.String(), Name: user.Name}
+				if u.roleProvider != nil {
+					principal.Roles = u.roleProvider.RolesFor(user.Email)
+				}
+				accessToken, expiresIn, err := u.tokenService.Issue(principal)
+				if err != nil {
+					return nil, err
+				}
 				response := viewmodels.TokenResponse{
-					AccessToken: "some_generated_token",
-					TokenType:   "Bearer " + user.Name,
-					ExpiresIn:   3600, // Expires in 1 hour
+					AccessToken: accessToken,
+					TokenType:   "Bearer",
+					ExpiresIn:   int(expiresIn.Seconds()),
 				}
 				return response, nil
 			}
@@ -2,11 +2,16 @@ package controllers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	viewmodels "flickly/internal/api/users/viewmodels"
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
 	"flickly/internal/domain/users/commands"
 	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/queries"
+	"flickly/internal/domain/users/readmodels"
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
 	"net/http"
@@ -15,31 +20,33 @@ import (
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"flickly/internal/domain/core"
 
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
-// MockMediatorForControllerTest é um mock do mediator para testes do controlador
-type MockMediatorForControllerTest struct {
+// MockSenderForControllerTest é um mock do mediator.Sender para testes do controlador
+type MockSenderForControllerTest struct {
 	SendCalled       bool
+	LastRequest      mediator.Request
 	ResponseToReturn mediator.Response
 	ErrorToReturn    error
 }
 
-func (m *MockMediatorForControllerTest) Register(requestName string, handler mediator.Handler) {
-}
-
-func (m *MockMediatorForControllerTest) Send(c *gin.Context, request mediator.Request) (mediator.Response, error) {
+func (m *MockSenderForControllerTest) Send(ctx context.Context, request mediator.Request) (mediator.Response, error) {
 	m.SendCalled = true
+	m.LastRequest = request
 	return m.ResponseToReturn, m.ErrorToReturn
 }
 
 // MockUserRepositoryForControllerTest é um mock do repositório de usuários para testes
 type MockUserRepositoryForControllerTest struct {
 	GetUserByEmailCalled bool
+	GetUserByIDCalled    bool
 	CreateUserCalled     bool
 	UserToReturn         *entities.User
 	ErrorToReturn        error
@@ -55,6 +62,15 @@ func (m *MockUserRepositoryForControllerTest) GetUserByEmail(email string) (*ent
 	return m.UserToReturn, m.ErrorToReturn
 }
 
+func (m *MockUserRepositoryForControllerTest) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	m.GetUserByIDCalled = true
+	return m.UserToReturn, m.ErrorToReturn
+}
+
+func (m *MockUserRepositoryForControllerTest) UpdateUser(user *entities.User) error {
+	return m.ErrorToReturn
+}
+
 // MockMapperForControllerTest é um mock do mapper para testes do controlador
 type MockMapperForControllerTest struct {
 	MapCalled     bool
@@ -72,6 +88,25 @@ func (m *MockMapperForControllerTest) Map(source, destination interface{}) error
 		if user, ok := source.(*entities.User); ok {
 			dest.Name = user.Name
 			dest.Email = user.Email
+			dest.Version = user.Version
+		}
+	case *viewmodels.UserResponse:
+		if user, ok := source.(*entities.User); ok {
+			dest.ID = user.ID
+			dest.Name = user.Name
+			dest.Email = user.Email
+			dest.Version = user.Version
+		}
+		if userView, ok := source.(*readmodels.UserView); ok {
+			dest.ID = userView.ID
+			dest.Name = userView.Name
+			dest.Email = userView.Email
+			dest.Version = userView.Version
+		}
+	case *commands.UpdateUserCommand:
+		if request, ok := source.(viewmodels.UpdateUserRequest); ok {
+			dest.Name = request.Name
+			dest.Email = request.Email
 		}
 	}
 	return nil
@@ -84,25 +119,73 @@ func (m *MockMapperForControllerTest) MapSlice(source, destination interface{})
 	return m.ErrorToReturn
 }
 
+func (m *MockMapperForControllerTest) AddConverter(sourceType, destType reflect.Type, converter utilities.Converter) {
+}
+
+// MockTokenServiceForControllerTest registra o último principal recebido
+type MockTokenServiceForControllerTest struct {
+	LastPrincipal security.Principal
+}
+
+func (m *MockTokenServiceForControllerTest) Issue(principal security.Principal) (string, time.Duration, error) {
+	m.LastPrincipal = principal
+	return "some_generated_token", time.Hour, nil
+}
+
+func (m *MockTokenServiceForControllerTest) Validate(token string) (security.Principal, error) {
+	return m.LastPrincipal, nil
+}
+
+// MockRoleProviderForControllerTest concede o papel admin a admin@example.com
+type MockRoleProviderForControllerTest struct{}
+
+func (m *MockRoleProviderForControllerTest) RolesFor(email string) []string {
+	if email == "admin@example.com" {
+		return []string{security.RoleAdmin}
+	}
+	return nil
+}
+
+// MockPasswordHasherForControllerTest gera hashes previsíveis para os testes
+type MockPasswordHasherForControllerTest struct{}
+
+func (m *MockPasswordHasherForControllerTest) Hash(password string) (string, error) {
+	return "hash:" + password, nil
+}
+
+func (m *MockPasswordHasherForControllerTest) Verify(hash string, password string) bool {
+	return hash != "" && hash == "hash:"+password
+}
+
+// userWithPassword cria um usuário com a senha informada cadastrada
+func userWithPassword(name string, email string, password string) *entities.User {
+	user := entities.NewUser(name, email)
+	user.SetPasswordHash("hash:" + password)
+	return user
+}
+
 // Função para configurar as dependências de teste
 func setupTestDependencies(
-	mockMediator *MockMediatorForControllerTest,
+	mockSender *MockSenderForControllerTest,
 	mockRepo *MockUserRepositoryForControllerTest,
 	mockMapper *MockMapperForControllerTest,
 ) utilities.IServiceCollection {
 	serviceCollection := utilities.NewServiceCollection()
-	utilities.AddService[mediator.Mediator](serviceCollection, mockMediator)
+	utilities.AddService[mediator.Sender](serviceCollection, mockSender)
 	utilities.AddService[repositories.IUserRepository](serviceCollection, mockRepo)
 	utilities.AddService[utilities.Mapper](serviceCollection, mockMapper)
+	utilities.AddService[security.TokenService](serviceCollection, &MockTokenServiceForControllerTest{})
+	utilities.AddService[security.RoleProvider](serviceCollection, &MockRoleProviderForControllerTest{})
+	utilities.AddService[security.PasswordHasher](serviceCollection, &MockPasswordHasherForControllerTest{})
 	return serviceCollection
 }
 
 func TestNewUserController(t *testing.T) {
 	// Configuração
-	mockMediator := &MockMediatorForControllerTest{}
+	mockSender := &MockSenderForControllerTest{}
 	mockRepo := &MockUserRepositoryForControllerTest{}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	// Execução
 	controller := NewUserController(serviceCollection)
@@ -117,12 +200,12 @@ func TestNewUserController(t *testing.T) {
 func TestPostUser_Success(t *testing.T) {
 	// Configuração
 	gin.SetMode(gin.TestMode)
-	mockMediator := &MockMediatorForControllerTest{
+	mockSender := &MockSenderForControllerTest{
 		ResponseToReturn: entities.NewUser("Test User", "test@example.com"),
 	}
 	mockRepo := &MockUserRepositoryForControllerTest{}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -144,7 +227,7 @@ func TestPostUser_Success(t *testing.T) {
 
 	// Verificações
 	assert.Equal(t, http.StatusCreated, w.Code, "O código de status deve ser 201 Created")
-	assert.True(t, mockMediator.SendCalled, "O método Send do mediator deve ser chamado")
+	assert.True(t, mockSender.SendCalled, "O método Send do mediator deve ser chamado")
 	assert.True(t, mockMapper.MapCalled, "O método Map do mapper deve ser chamado")
 
 	// Verificar o corpo da resposta
@@ -158,10 +241,10 @@ func TestPostUser_Success(t *testing.T) {
 func TestPostUser_BindError(t *testing.T) {
 	// Configuração
 	gin.SetMode(gin.TestMode)
-	mockMediator := &MockMediatorForControllerTest{}
+	mockSender := &MockSenderForControllerTest{}
 	mockRepo := &MockUserRepositoryForControllerTest{}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -176,7 +259,7 @@ func TestPostUser_BindError(t *testing.T) {
 
 	// Verificações
 	assert.Equal(t, 418, w.Code, "O código de status deve ser 418 I'm a teapot para erro de binding")
-	assert.False(t, mockMediator.SendCalled, "O método Send do mediator não deve ser chamado")
+	assert.False(t, mockSender.SendCalled, "O método Send do mediator não deve ser chamado")
 	assert.False(t, mockMapper.MapCalled, "O método Map do mapper não deve ser chamado")
 }
 
@@ -188,12 +271,12 @@ func TestPostUser_MediatorError(t *testing.T) {
 		WithMessage("mediator error").
 		WithErrorCode(1).
 		Build()
-	mockMediator := &MockMediatorForControllerTest{
+	mockSender := &MockSenderForControllerTest{
 		ErrorToReturn: expectedError,
 	}
 	mockRepo := &MockUserRepositoryForControllerTest{}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -215,19 +298,19 @@ func TestPostUser_MediatorError(t *testing.T) {
 
 	// Verificações
 	assert.Equal(t, http.StatusBadRequest, w.Code, "O código de status deve ser 400 Bad Request")
-	assert.True(t, mockMediator.SendCalled, "O método Send do mediator deve ser chamado")
+	assert.True(t, mockSender.SendCalled, "O método Send do mediator deve ser chamado")
 	assert.True(t, mockMapper.MapCalled, "O método Map do mapper deve ser chamado")
 }
 
 func TestPostOauthToken_Success(t *testing.T) {
 	// Configuração
 	gin.SetMode(gin.TestMode)
-	mockMediator := &MockMediatorForControllerTest{}
+	mockSender := &MockSenderForControllerTest{}
 	mockRepo := &MockUserRepositoryForControllerTest{
-		UserToReturn: entities.NewUser("Test User", "test@example.com"),
+		UserToReturn: userWithPassword("Test User", "test@example.com", "password123"),
 	}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -258,19 +341,81 @@ func TestPostOauthToken_Success(t *testing.T) {
 	err := json.Unmarshal(w.Body.Bytes(), &response)
 	assert.NoError(t, err, "Não deve ocorrer erro ao desserializar a resposta JSON")
 	assert.Equal(t, "some_generated_token", response.AccessToken, "O token de acesso deve ser correto")
-	assert.Equal(t, "Bearer Test User", response.TokenType, "O tipo do token deve ser correto")
+	assert.Equal(t, "Bearer", response.TokenType, "O tipo do token deve ser correto")
 	assert.Equal(t, 3600, response.ExpiresIn, "O tempo de expiração deve ser correto")
 }
 
+func TestPostOauthToken_IssuesPrincipalWithRoles(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	admin := userWithPassword("Admin", "admin@example.com", "password123")
+	mockRepo := &MockUserRepositoryForControllerTest{UserToReturn: admin}
+	serviceCollection := setupTestDependencies(&MockSenderForControllerTest{}, mockRepo, &MockMapperForControllerTest{})
+	tokenService := &MockTokenServiceForControllerTest{}
+	utilities.AddService[security.TokenService](serviceCollection, tokenService)
+	controller := NewUserController(serviceCollection)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	form := url.Values{}
+	form.Add("grant_type", "password")
+	form.Add("client_id", "my_client_id")
+	form.Add("client_secret", "my_client_secret")
+	form.Add("username", "admin@example.com")
+	form.Add("password", "password123")
+	c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	// Execução
+	controller.PostOauthToken(c)
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, admin.ID.String(), tokenService.LastPrincipal.Subject, "O token deve identificar o usuário pelo ID")
+	assert.Equal(t, []string{security.RoleAdmin}, tokenService.LastPrincipal.Roles, "Os papéis do usuário devem ser incluídos no token")
+}
+
+func TestPostOauthToken_WrongPassword(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	tokenService := &MockTokenServiceForControllerTest{}
+	for _, user := range []*entities.User{
+		userWithPassword("Admin", "admin@example.com", "password123"),
+		entities.NewUser("Admin", "admin@example.com"),
+	} {
+		serviceCollection := setupTestDependencies(&MockSenderForControllerTest{}, &MockUserRepositoryForControllerTest{UserToReturn: user}, &MockMapperForControllerTest{})
+		utilities.AddService[security.TokenService](serviceCollection, tokenService)
+		controller := NewUserController(serviceCollection)
+
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		form := url.Values{}
+		form.Add("grant_type", "password")
+		form.Add("client_id", "my_client_id")
+		form.Add("client_secret", "my_client_secret")
+		form.Add("username", "admin@example.com")
+		form.Add("password", "senha-incorreta")
+		c.Request = httptest.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+		c.Request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		// Execução
+		controller.PostOauthToken(c)
+
+		// Verificações
+		assert.Equal(t, http.StatusUnauthorized, w.Code, "Senhas incorretas ou não cadastradas devem ser rejeitadas")
+	}
+	assert.Empty(t, tokenService.LastPrincipal.Subject, "Nenhum token deve ser emitido")
+}
+
 func TestPostOauthToken_InvalidCredentials(t *testing.T) {
 	// Configuração
 	gin.SetMode(gin.TestMode)
-	mockMediator := &MockMediatorForControllerTest{}
+	mockSender := &MockSenderForControllerTest{}
 	mockRepo := &MockUserRepositoryForControllerTest{
 		UserToReturn: nil, // Usuário não encontrado
 	}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -310,12 +455,12 @@ func TestPostOauthToken_RepositoryError(t *testing.T) {
 		WithMessage("repository error").
 		WithErrorCode(2).
 		Build()
-	mockMediator := &MockMediatorForControllerTest{}
+	mockSender := &MockSenderForControllerTest{}
 	mockRepo := &MockUserRepositoryForControllerTest{
 		ErrorToReturn: expectedError,
 	}
 	mockMapper := &MockMapperForControllerTest{}
-	serviceCollection := setupTestDependencies(mockMediator, mockRepo, mockMapper)
+	serviceCollection := setupTestDependencies(mockSender, mockRepo, mockMapper)
 
 	controller := NewUserController(serviceCollection)
 
@@ -341,3 +486,164 @@ func TestPostOauthToken_RepositoryError(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, w.Code, "O código de status deve ser 401 Unauthorized")
 	assert.True(t, mockRepo.GetUserByEmailCalled, "O método GetUserByEmail do repositório deve ser chamado")
 }
+
+func TestGetUser_Success(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	userView := &readmodels.UserView{ID: uuid.New(), Name: "Test User", Email: "test@example.com", Version: 4}
+	mockSender := &MockSenderForControllerTest{ResponseToReturn: userView}
+	mockRepo := &MockUserRepositoryForControllerTest{}
+	controller := NewUserController(setupTestDependencies(mockSender, mockRepo, &MockMapperForControllerTest{}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: userView.ID.String()}}
+	c.Request = httptest.NewRequest(http.MethodGet, "/user/"+userView.ID.String(), nil)
+
+	// Execução
+	controller.GetUser(c)
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	assert.Equal(t, queries.GetUserQuery{ID: userView.ID}, mockSender.LastRequest, "O usuário deve ser lido pela consulta GetUserQuery")
+	assert.False(t, mockRepo.GetUserByIDCalled, "O repositório de escrita não deve ser usado na leitura")
+	assert.Equal(t, `"4"`, w.Header().Get("ETag"), "A ETag deve conter a versão do usuário")
+
+	var response viewmodels.UserResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, userView.ID, response.ID, "O ID do usuário na resposta deve ser correto")
+	assert.Equal(t, int64(4), response.Version, "A versão do usuário na resposta deve ser correta")
+}
+
+func TestGetUser_NotFound(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	mockSender := &MockSenderForControllerTest{ErrorToReturn: core.ErrUserNotFound(errors.New("user not found"))}
+	controller := NewUserController(setupTestDependencies(mockSender, &MockUserRepositoryForControllerTest{}, &MockMapperForControllerTest{}))
+
+	for _, id := range []string{"not-a-uuid", uuid.New().String()} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodGet, "/user/"+id, nil)
+
+		// Execução
+		controller.GetUser(c)
+
+		// Verificações
+		assert.Equal(t, http.StatusNotFound, w.Code, "O código de status deve ser 404 para o ID %s", id)
+	}
+}
+
+func TestPutUser_Success(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	updated := entities.NewUser("New Name", "new@example.com")
+	updated.Version = 3
+	mockSender := &MockSenderForControllerTest{ResponseToReturn: updated}
+	controller := NewUserController(setupTestDependencies(mockSender, &MockUserRepositoryForControllerTest{}, &MockMapperForControllerTest{}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: updated.ID.String()}}
+	jsonData, _ := json.Marshal(viewmodels.UpdateUserRequest{Name: "New Name", Email: "new@example.com"})
+	c.Request = httptest.NewRequest(http.MethodPut, "/user/"+updated.ID.String(), bytes.NewBuffer(jsonData))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"2"`)
+	c.Request = c.Request.WithContext(security.WithPrincipal(c.Request.Context(), security.Principal{Subject: updated.ID.String()}))
+
+	// Execução
+	controller.PutUser(c)
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, w.Code, "O código de status deve ser 200 OK")
+	command, ok := mockSender.LastRequest.(commands.UpdateUserCommand)
+	assert.True(t, ok, "O comando enviado deve ser UpdateUserCommand")
+	assert.Equal(t, updated.ID, command.ID, "O ID da rota deve ser enviado no comando")
+	assert.Equal(t, int64(2), command.ExpectedVersion, "A versão do If-Match deve ser enviada no comando")
+	assert.Equal(t, "New Name", command.Name, "O nome do corpo deve ser enviado no comando")
+	assert.Equal(t, `"3"`, w.Header().Get("ETag"), "A ETag deve conter a nova versão")
+}
+
+func TestPutUser_InvalidIfMatch(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	mockSender := &MockSenderForControllerTest{}
+	controller := NewUserController(setupTestDependencies(mockSender, &MockUserRepositoryForControllerTest{}, &MockMapperForControllerTest{}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	id := uuid.New().String()
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	c.Request = httptest.NewRequest(http.MethodPut, "/user/"+id, strings.NewReader(`{"name":"New"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `W/"2"`)
+	c.Request = c.Request.WithContext(security.WithPrincipal(c.Request.Context(), security.Principal{Subject: id}))
+
+	// Execução
+	controller.PutUser(c)
+
+	// Verificações
+	assert.Equal(t, http.StatusPreconditionFailed, w.Code, "O código de status deve ser 412 Precondition Failed")
+	assert.False(t, mockSender.SendCalled, "O método Send do mediator não deve ser chamado")
+}
+
+func TestPutUser_Conflict(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	mockSender := &MockSenderForControllerTest{ErrorToReturn: core.ErrConcurrencyConflict(nil)}
+	controller := NewUserController(setupTestDependencies(mockSender, &MockUserRepositoryForControllerTest{}, &MockMapperForControllerTest{}))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	id := uuid.New().String()
+	c.Params = gin.Params{{Key: "id", Value: id}}
+	c.Request = httptest.NewRequest(http.MethodPut, "/user/"+id, strings.NewReader(`{"name":"New"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Request.Header.Set("If-Match", `"1"`)
+	c.Request = c.Request.WithContext(security.WithPrincipal(c.Request.Context(), security.Principal{Subject: id}))
+
+	// Execução
+	controller.PutUser(c)
+
+	// Verificações
+	assert.Equal(t, http.StatusConflict, w.Code, "O código de status deve ser 409 Conflict")
+}
+
+func TestPutUser_Authorization(t *testing.T) {
+	// Configuração
+	gin.SetMode(gin.TestMode)
+	id := uuid.New().String()
+	cases := []struct {
+		name      string
+		principal security.Principal
+		ifMatch   string
+		expected  int
+	}{
+		{"anônimo", security.Principal{}, `"1"`, http.StatusUnauthorized},
+		{"outro usuário", security.Principal{Subject: uuid.New().String()}, `"1"`, http.StatusForbidden},
+		{"sem If-Match", security.Principal{Subject: id}, "", http.StatusPreconditionRequired},
+		{"administrador", security.Principal{Subject: uuid.New().String(), Roles: []string{security.RoleAdmin}}, `"1"`, http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		mockSender := &MockSenderForControllerTest{ResponseToReturn: entities.NewUser("New", "new@example.com")}
+		controller := NewUserController(setupTestDependencies(mockSender, &MockUserRepositoryForControllerTest{}, &MockMapperForControllerTest{}))
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: id}}
+		c.Request = httptest.NewRequest(http.MethodPut, "/user/"+id, strings.NewReader(`{"name":"New"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		if tc.ifMatch != "" {
+			c.Request.Header.Set("If-Match", tc.ifMatch)
+		}
+		c.Request = c.Request.WithContext(security.WithPrincipal(c.Request.Context(), tc.principal))
+
+		// Execução
+		controller.PutUser(c)
+
+		// Verificações
+		assert.Equal(t, tc.expected, w.Code, "Caso %s: status incorreto", tc.name)
+		assert.Equal(t, tc.expected == http.StatusOK, mockSender.SendCalled, "Caso %s: o comando só deve ser enviado quando autorizado", tc.name)
+	}
+}
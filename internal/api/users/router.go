@@ -14,4 +14,6 @@ func Startup(router *gin.Engine, serviceCollection utilities.IServiceCollection)
 	// Configurando rotas
 	router.POST("/oauth/token", userController.PostOauthToken)
 	router.POST("/user", userController.PostUser)
+	router.GET("/user/:id", userController.GetUser)
+	router.PUT("/user/:id", userController.PutUser)
 }
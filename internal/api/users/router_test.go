@@ -6,19 +6,11 @@ import (
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
-// MockMediatorForRouterTest é um mock do mediator para testes do roteador
-type MockMediatorForRouterTest struct{}
-
-func (m *MockMediatorForRouterTest) Register(requestName string, handler mediator.Handler) {}
-
-func (m *MockMediatorForRouterTest) Send(c *gin.Context, request mediator.Request) (mediator.Response, error) {
-	return nil, nil
-}
-
 // MockUserRepositoryForRouterTest é um mock do repositório de usuários para testes
 type MockUserRepositoryForRouterTest struct{}
 
@@ -30,6 +22,14 @@ func (m *MockUserRepositoryForRouterTest) GetUserByEmail(email string) (*entitie
 	return nil, nil
 }
 
+func (m *MockUserRepositoryForRouterTest) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepositoryForRouterTest) UpdateUser(user *entities.User) error {
+	return nil
+}
+
 func TestStartup(t *testing.T) {
 	// Configuração
 	gin.SetMode(gin.TestMode)
@@ -37,7 +37,7 @@ func TestStartup(t *testing.T) {
 	serviceCollection := utilities.NewServiceCollection()
 
 	// Registrar as dependências necessárias
-	utilities.AddService[mediator.Mediator](serviceCollection, &MockMediatorForRouterTest{})
+	utilities.AddService[mediator.Sender](serviceCollection, mediator.NewMediatR())
 	utilities.AddService[repositories.IUserRepository](serviceCollection, &MockUserRepositoryForRouterTest{})
 
 	// Execução
@@ -47,8 +47,14 @@ func TestStartup(t *testing.T) {
 	routes := router.Routes()
 
 	// Verificar se as rotas foram registradas
-	var foundPostUser, foundPostOauthToken bool
+	var foundPostUser, foundPostOauthToken, foundGetUser, foundPutUser bool
 	for _, route := range routes {
+		if route.Path == "/user/:id" && route.Method == "GET" {
+			foundGetUser = true
+		}
+		if route.Path == "/user/:id" && route.Method == "PUT" {
+			foundPutUser = true
+		}
 		if route.Path == "/user" && route.Method == "POST" {
 			foundPostUser = true
 		}
@@ -59,4 +65,6 @@ func TestStartup(t *testing.T) {
 
 	assert.True(t, foundPostUser, "A rota POST /user deve estar registrada")
 	assert.True(t, foundPostOauthToken, "A rota POST /oauth/token deve estar registrada")
+	assert.True(t, foundGetUser, "A rota GET /user/:id deve estar registrada")
+	assert.True(t, foundPutUser, "A rota PUT /user/:id deve estar registrada")
 }
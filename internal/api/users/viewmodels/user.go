@@ -10,6 +10,7 @@ type CreateUserResponse struct {
 	CreatedAt time.Time `json:"createdAt"`
 	Name      string    `json:"name"`
 	Email     string    `json:"email"`
+	Version   int64     `json:"version"`
 }
 
 type CreateUserRequest struct {
@@ -17,3 +18,17 @@ type CreateUserRequest struct {
 	Email    string `json:"email"`
 	Password string `json:"password"`
 }
+
+type UserResponse struct {
+	ID           uuid.UUID  `json:"id"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUpdateAt *time.Time `json:"lastUpdateAt,omitempty"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	Version      int64      `json:"version"`
+}
+
+type UpdateUserRequest struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
@@ -0,0 +1,29 @@
+package core
+
+// EventSource é implementado pelos agregados que registram eventos de domínio
+type EventSource interface {
+	PullEvents() []DomainEvent
+}
+
+// AggregateRoot acumula os eventos de domínio registrados pelo agregado até que sejam despachados.
+// O mediator recolhe os eventos do agregado retornado pelo handler depois que ele conclui com sucesso.
+type AggregateRoot struct {
+	events []DomainEvent
+}
+
+// RecordEvent registra um evento ocorrido no agregado
+func (a *AggregateRoot) RecordEvent(event DomainEvent) {
+	a.events = append(a.events, event)
+}
+
+// Events retorna os eventos registrados e ainda não despachados
+func (a *AggregateRoot) Events() []DomainEvent {
+	return a.events
+}
+
+// PullEvents retorna os eventos registrados e os remove do agregado
+func (a *AggregateRoot) PullEvents() []DomainEvent {
+	events := a.events
+	a.events = nil
+	return events
+}
@@ -0,0 +1,31 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockEvent é um evento de domínio para os testes
+type mockEvent struct {
+	Data string
+}
+
+func (e mockEvent) EventName() string {
+	return "mock.happened"
+}
+
+func TestAggregateRoot_PullEvents(t *testing.T) {
+	// Configuração
+	var aggregate AggregateRoot
+	aggregate.RecordEvent(mockEvent{Data: "a"})
+	aggregate.RecordEvent(mockEvent{Data: "b"})
+
+	// Execução
+	events := aggregate.PullEvents()
+
+	// Verificações
+	assert.Equal(t, []DomainEvent{mockEvent{Data: "a"}, mockEvent{Data: "b"}}, events, "Os eventos devem ser retornados na ordem de registro")
+	assert.Empty(t, aggregate.Events(), "Os eventos devem ser removidos do agregado")
+	assert.Empty(t, aggregate.PullEvents())
+}
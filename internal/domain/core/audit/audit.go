@@ -0,0 +1,150 @@
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"github.com/google/uuid"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Resultados possíveis de um comando auditado
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Anonymous identifica comandos executados sem usuário autenticado
+const Anonymous = "anonymous"
+
+// redacted substitui o valor de campos sensíveis no payload
+const redacted = "***"
+
+// sensitiveFields são trechos de nomes de campos cujo valor nunca é gravado
+var sensitiveFields = []string{"password", "secret", "token"}
+
+// Entry é o registro de um comando que alterou (ou tentou alterar) o estado da aplicação
+type Entry struct {
+	ID            uuid.UUID       `json:"id"`
+	Timestamp     time.Time       `json:"timestamp"`
+	Actor         string          `json:"actor"`
+	RequestType   string          `json:"requestType"`
+	EntityID      string          `json:"entityId,omitempty"`
+	Payload       json.RawMessage `json:"payload"`
+	Result        string          `json:"result"`
+	Error         string          `json:"error,omitempty"`
+	CorrelationID string          `json:"correlationId,omitempty"`
+}
+
+// Filter seleciona registros da trilha de auditoria; campos vazios não filtram
+type Filter struct {
+	Actor       string
+	EntityID    string
+	RequestType string
+	From        *time.Time
+	To          *time.Time
+	Limit       int
+}
+
+// Matches indica se o registro atende ao filtro
+func (f Filter) Matches(entry Entry) bool {
+	return (f.Actor == "" || entry.Actor == f.Actor) &&
+		(f.EntityID == "" || entry.EntityID == f.EntityID) &&
+		(f.RequestType == "" || entry.RequestType == f.RequestType) &&
+		(f.From == nil || !entry.Timestamp.Before(*f.From)) &&
+		(f.To == nil || entry.Timestamp.Before(*f.To))
+}
+
+// Store é o armazenamento somente de inclusão da trilha de auditoria
+type Store interface {
+	Append(entry *Entry) error
+	// Find retorna os registros que atendem ao filtro, do mais recente para o mais antigo
+	Find(filter Filter) ([]Entry, error)
+}
+
+// NewEntry cria o registro do comando com o payload sanitizado e o ID da entidade afetada
+func NewEntry(actor string, requestType string, request interface{}, response interface{}, err error, correlationID string) (*Entry, error) {
+	payload, sanitizeErr := Sanitize(request)
+	if sanitizeErr != nil {
+		return nil, sanitizeErr
+	}
+	if actor == "" {
+		actor = Anonymous
+	}
+	entry := &Entry{
+		ID:            uuid.New(),
+		Timestamp:     time.Now().UTC(),
+		Actor:         actor,
+		RequestType:   requestType,
+		EntityID:      EntityID(response, request),
+		Payload:       payload,
+		Result:        ResultSuccess,
+		CorrelationID: correlationID,
+	}
+	if err != nil {
+		entry.Result = ResultFailure
+		entry.Error = err.Error()
+	}
+	return entry, nil
+}
+
+// Sanitize serializa a requisição substituindo o valor de campos sensíveis (como Password)
+func Sanitize(request interface{}) (json.RawMessage, error) {
+	data, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	var value interface{}
+	if err = json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(redact(value))
+}
+
+func redact(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for key, item := range typed {
+			if isSensitive(key) {
+				typed[key] = redacted
+			} else {
+				typed[key] = redact(item)
+			}
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = redact(item)
+		}
+	}
+	return value
+}
+
+func isSensitive(field string) bool {
+	field = strings.ToLower(field)
+	for _, sensitive := range sensitiveFields {
+		if strings.Contains(field, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// EntityID retorna o campo ID (não vazio) do primeiro valor que o possuir
+func EntityID(values ...interface{}) string {
+	for _, value := range values {
+		v := reflect.ValueOf(value)
+		for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+			v = v.Elem()
+		}
+		if !v.IsValid() || v.Kind() != reflect.Struct {
+			continue
+		}
+		field := v.FieldByName("ID")
+		if !field.IsValid() || field.IsZero() {
+			continue
+		}
+		return fmt.Sprint(field.Interface())
+	}
+	return ""
+}
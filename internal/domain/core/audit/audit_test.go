@@ -0,0 +1,83 @@
+package audit
+
+import (
+	"errors"
+	"flickly/internal/domain/core"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type auditedCommand struct {
+	Name        string            `json:"name"`
+	Password    string            `json:"password"`
+	Credentials map[string]string `json:"credentials"`
+}
+
+type auditedEntity struct {
+	core.Entity
+	Name string
+}
+
+func TestSanitize(t *testing.T) {
+	// Configuração
+	command := auditedCommand{
+		Name:        "Test User",
+		Password:    "Senha@123",
+		Credentials: map[string]string{"client_secret": "abc", "accessToken": "xyz", "scope": "read"},
+	}
+
+	// Execução
+	payload, err := Sanitize(command)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"name":"Test User","password":"***","credentials":{"client_secret":"***","accessToken":"***","scope":"read"}}`,
+		string(payload), "Campos sensíveis devem ser substituídos em qualquer nível")
+	assert.NotContains(t, string(payload), "Senha@123", "A senha nunca deve ser gravada")
+}
+
+func TestEntityID(t *testing.T) {
+	// Configuração
+	entity := &auditedEntity{Entity: core.NewEntity()}
+	id := uuid.New()
+
+	// Verificações
+	assert.Equal(t, entity.ID.String(), EntityID(entity, nil), "O ID da entidade retornada deve ser usado")
+	assert.Equal(t, id.String(), EntityID(nil, struct{ ID uuid.UUID }{ID: id}), "O ID da requisição deve ser usado quando não há resposta")
+	assert.Empty(t, EntityID(nil, auditedCommand{}, struct{ ID uuid.UUID }{}), "IDs vazios devem ser ignorados")
+}
+
+func TestNewEntry(t *testing.T) {
+	// Execução
+	success, err := NewEntry("user-1", "auditedCommand", auditedCommand{Password: "x"}, &auditedEntity{Entity: core.NewEntity()}, nil, "corr-1")
+	assert.NoError(t, err)
+	failure, _ := NewEntry("", "auditedCommand", auditedCommand{}, nil, errors.New("boom"), "")
+
+	// Verificações
+	assert.Equal(t, ResultSuccess, success.Result)
+	assert.Equal(t, "user-1", success.Actor)
+	assert.Equal(t, "corr-1", success.CorrelationID)
+	assert.NotEmpty(t, success.EntityID, "O ID da entidade afetada deve ser registrado")
+	assert.Equal(t, ResultFailure, failure.Result, "Comandos com erro devem ser registrados como falha")
+	assert.Equal(t, "boom", failure.Error)
+	assert.Equal(t, Anonymous, failure.Actor, "Comandos sem usuário autenticado devem ser registrados como anônimos")
+}
+
+func TestFilter_Matches(t *testing.T) {
+	// Configuração
+	now := time.Now()
+	entry := Entry{Actor: "user-1", EntityID: "e-1", RequestType: "CreateUserCommand", Timestamp: now}
+	before := now.Add(-time.Minute)
+	after := now.Add(time.Minute)
+
+	// Verificações
+	assert.True(t, Filter{}.Matches(entry), "Filtro vazio deve aceitar qualquer registro")
+	assert.True(t, Filter{Actor: "user-1", EntityID: "e-1", RequestType: "CreateUserCommand", From: &before, To: &after}.Matches(entry))
+	assert.False(t, Filter{Actor: "user-2"}.Matches(entry), "Filtro por ator deve ser aplicado")
+	assert.False(t, Filter{RequestType: "UpdateUserCommand"}.Matches(entry), "Filtro por tipo deve ser aplicado")
+	assert.False(t, Filter{From: &after}.Matches(entry), "Registros anteriores ao início devem ser ignorados")
+	assert.False(t, Filter{To: &now}.Matches(entry), "O fim do intervalo é exclusivo")
+}
@@ -0,0 +1,20 @@
+package core
+
+import "context"
+
+// correlationIDKey é a chave usada para guardar o ID de correlação da requisição no contexto
+type correlationIDKey struct{}
+
+// WithCorrelationID retorna uma cópia do contexto com o ID de correlação informado
+func WithCorrelationID(ctx context.Context, correlationID string) context.Context {
+	return context.WithValue(ctx, correlationIDKey{}, correlationID)
+}
+
+// CorrelationIDFromContext retorna o ID de correlação da requisição ou vazio quando não há
+func CorrelationIDFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	correlationID, _ := ctx.Value(correlationIDKey{}).(string)
+	return correlationID
+}
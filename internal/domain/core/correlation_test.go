@@ -0,0 +1,19 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCorrelationIDFromContext(t *testing.T) {
+	// Configuração
+	ctx := context.Background()
+
+	// Execução e verificações
+	assert.Empty(t, CorrelationIDFromContext(ctx), "Sem ID de correlação deve retornar vazio")
+	assert.Empty(t, CorrelationIDFromContext(nil), "Sem contexto deve retornar vazio")
+	assert.Equal(t, "abc-123", CorrelationIDFromContext(WithCorrelationID(ctx, "abc-123")), "O ID de correlação guardado deve ser retornado")
+	assert.Empty(t, CorrelationIDFromContext(context.WithValue(ctx, "flickly.correlationId", "abc-123")), "Chaves do tipo string não devem ser lidas")
+}
@@ -1,12 +1,17 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+)
 
 type DomainError struct {
 	error
 	Code       int
 	Message    string
 	StatusCode int
+	// Violations lista os campos inválidos quando o erro é de validação
+	Violations Violations
 }
 
 type DomainErrorBuilder struct {
@@ -41,6 +46,11 @@ func (b *DomainErrorBuilder) WithStatusCode(statusCode int) *DomainErrorBuilder
 	return b
 }
 
+func (b *DomainErrorBuilder) WithViolations(violations Violations) *DomainErrorBuilder {
+	b.DomainError.Violations = violations
+	return b
+}
+
 func (b *DomainErrorBuilder) Build() *DomainError {
 	return &b.DomainError
 }
@@ -49,4 +59,49 @@ var (
 	ErrUserAlreadyExist = func(err error) *DomainError {
 		return NewDomainErrorBuilder(err).WithMessage("Usuário já cadastrado").WithErrorCode(1).Build()
 	}
+	ErrConcurrencyConflict = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("O registro foi alterado por outra requisição").WithErrorCode(3).WithStatusCode(http.StatusConflict).Build()
+	}
+	ErrPreconditionFailed = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("A versão informada não corresponde à versão atual do registro").WithErrorCode(4).WithStatusCode(http.StatusPreconditionFailed).Build()
+	}
+	ErrUserNotFound = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Usuário não encontrado").WithErrorCode(5).WithStatusCode(http.StatusNotFound).Build()
+	}
+	ErrUnauthorized = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Autenticação necessária").WithErrorCode(6).WithStatusCode(http.StatusUnauthorized).Build()
+	}
+	ErrForbidden = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Acesso negado").WithErrorCode(7).WithStatusCode(http.StatusForbidden).Build()
+	}
+	ErrInvalidArgument = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Parâmetro inválido").WithErrorCode(8).Build()
+	}
+	ErrInternal = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Erro interno").WithErrorCode(9).WithStatusCode(http.StatusInternalServerError).Build()
+	}
+	ErrJobNotFound = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Job não encontrado").WithErrorCode(10).WithStatusCode(http.StatusNotFound).Build()
+	}
+	ErrValidation = func(violations Violations) *DomainError {
+		return NewDomainErrorBuilder(violations).WithMessage("Dados inválidos").WithErrorCode(11).WithStatusCode(http.StatusUnprocessableEntity).WithViolations(violations).Build()
+	}
+	ErrIdempotencyInProgress = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Requisição com a mesma chave de idempotência em andamento").WithErrorCode(12).WithStatusCode(http.StatusConflict).Build()
+	}
+	ErrIdempotencyKeyReused = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Chave de idempotência usada com outra requisição").WithErrorCode(13).WithStatusCode(http.StatusUnprocessableEntity).Build()
+	}
+	ErrSagaNotFound = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Saga não encontrada").WithErrorCode(14).WithStatusCode(http.StatusNotFound).Build()
+	}
+	ErrScheduledTaskNotFound = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Tarefa agendada não encontrada").WithErrorCode(15).WithStatusCode(http.StatusNotFound).Build()
+	}
+	ErrPreconditionRequired = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Informe no cabeçalho If-Match a versão lida do registro").WithErrorCode(16).WithStatusCode(http.StatusPreconditionRequired).Build()
+	}
+	ErrPayloadTooLarge = func(err error) *DomainError {
+		return NewDomainErrorBuilder(err).WithMessage("Corpo da requisição grande demais").WithErrorCode(17).WithStatusCode(http.StatusRequestEntityTooLarge).Build()
+	}
 )
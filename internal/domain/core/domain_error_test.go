@@ -112,3 +112,118 @@ func TestErrUserAlreadyExist(t *testing.T) {
 	assert.Equal(t, "Usuário já cadastrado", domainError.Message, "A mensagem deve ser 'Usuário já cadastrado'")
 	assert.Equal(t, originalError.Error(), domainError.Error(), "O erro original deve ser armazenado")
 }
+
+func TestErrConcurrencyConflict(t *testing.T) {
+	// Execução
+	domainError := ErrConcurrencyConflict(errors.New("version mismatch"))
+
+	// Verificações
+	assert.Equal(t, 3, domainError.Code, "O código de erro deve ser 3")
+	assert.Equal(t, 409, domainError.StatusCode, "O código de status deve ser 409 Conflict")
+	assert.Equal(t, "version mismatch", domainError.Error(), "O erro original deve ser armazenado")
+}
+
+func TestErrPreconditionFailed(t *testing.T) {
+	// Execução
+	domainError := ErrPreconditionFailed(nil)
+
+	// Verificações
+	assert.Equal(t, 4, domainError.Code, "O código de erro deve ser 4")
+	assert.Equal(t, 412, domainError.StatusCode, "O código de status deve ser 412 Precondition Failed")
+}
+
+func TestErrUserNotFound(t *testing.T) {
+	// Execução
+	domainError := ErrUserNotFound(nil)
+
+	// Verificações
+	assert.Equal(t, 5, domainError.Code, "O código de erro deve ser 5")
+	assert.Equal(t, 404, domainError.StatusCode, "O código de status deve ser 404 Not Found")
+	assert.Equal(t, "Usuário não encontrado", domainError.Message)
+}
+
+func TestErrUnauthorizedAndForbidden(t *testing.T) {
+	// Execução
+	unauthorized := ErrUnauthorized(nil)
+	forbidden := ErrForbidden(nil)
+
+	// Verificações
+	assert.Equal(t, 6, unauthorized.Code, "O código de erro deve ser 6")
+	assert.Equal(t, 401, unauthorized.StatusCode, "O código de status deve ser 401 Unauthorized")
+	assert.Equal(t, 7, forbidden.Code, "O código de erro deve ser 7")
+	assert.Equal(t, 403, forbidden.StatusCode, "O código de status deve ser 403 Forbidden")
+}
+
+func TestErrInvalidArgument(t *testing.T) {
+	// Execução
+	domainError := ErrInvalidArgument(nil)
+
+	// Verificações
+	assert.Equal(t, 8, domainError.Code, "O código de erro deve ser 8")
+	assert.Equal(t, 400, domainError.StatusCode, "O código de status deve ser 400 Bad Request")
+}
+
+func TestErrInternal(t *testing.T) {
+	// Execução
+	domainError := ErrInternal(nil)
+
+	// Verificações
+	assert.Equal(t, 9, domainError.Code, "O código de erro deve ser 9")
+	assert.Equal(t, 500, domainError.StatusCode, "O código de status deve ser 500 Internal Server Error")
+}
+
+func TestErrJobNotFound(t *testing.T) {
+	// Execução
+	domainError := ErrJobNotFound(nil)
+
+	// Verificações
+	assert.Equal(t, 10, domainError.Code, "O código de erro deve ser 10")
+	assert.Equal(t, 404, domainError.StatusCode, "O código de status deve ser 404 Not Found")
+}
+
+func TestErrValidation(t *testing.T) {
+	// Configuração
+	violations := Violations{
+		{Field: "email", Rule: "email", Message: "deve ser um email válido"},
+		{Rule: "validate", Message: "as senhas não conferem"},
+	}
+
+	// Execução
+	domainError := ErrValidation(violations)
+
+	// Verificações
+	assert.Equal(t, 11, domainError.Code, "O código de erro deve ser 11")
+	assert.Equal(t, 422, domainError.StatusCode, "O código de status deve ser 422 Unprocessable Entity")
+	assert.Equal(t, violations, domainError.Violations, "As violações devem ser mantidas no erro")
+	assert.Equal(t, "validation failed: email: deve ser um email válido; as senhas não conferem", domainError.Error())
+}
+
+func TestErrIdempotency(t *testing.T) {
+	// Execução
+	inProgress := ErrIdempotencyInProgress(nil)
+	reused := ErrIdempotencyKeyReused(nil)
+
+	// Verificações
+	assert.Equal(t, 12, inProgress.Code, "O código de erro deve ser 12")
+	assert.Equal(t, 409, inProgress.StatusCode, "O código de status deve ser 409 Conflict")
+	assert.Equal(t, 13, reused.Code, "O código de erro deve ser 13")
+	assert.Equal(t, 422, reused.StatusCode, "O código de status deve ser 422 Unprocessable Entity")
+}
+
+func TestErrSagaNotFound(t *testing.T) {
+	// Execução
+	domainError := ErrSagaNotFound(nil)
+
+	// Verificações
+	assert.Equal(t, 14, domainError.Code, "O código de erro deve ser 14")
+	assert.Equal(t, 404, domainError.StatusCode, "O código de status deve ser 404 Not Found")
+}
+
+func TestErrScheduledTaskNotFound(t *testing.T) {
+	// Execução
+	domainError := ErrScheduledTaskNotFound(nil)
+
+	// Verificações
+	assert.Equal(t, 15, domainError.Code, "O código de erro deve ser 15")
+	assert.Equal(t, 404, domainError.StatusCode, "O código de status deve ser 404 Not Found")
+}
@@ -0,0 +1,6 @@
+package core
+
+// DomainEvent representa um fato de negócio ocorrido no domínio
+type DomainEvent interface {
+	EventName() string
+}
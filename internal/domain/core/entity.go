@@ -10,11 +10,20 @@ type Entity struct {
 	CreatedAt    time.Time  `json:"createdAt"`
 	LastUpdateAt *time.Time `json:"lastUpdateAt,omitempty"`
 	DeletedAt    *time.Time `json:"deletedAt,omitempty"`
+	// Version é incrementada pelo repositório a cada atualização e usada no controle de concorrência otimista
+	Version int64 `json:"version"`
 }
 
 func NewEntity() Entity {
 	return Entity{
 		ID:        uuid.New(),
 		CreatedAt: time.Now(),
+		Version:   1,
 	}
 }
+
+// Touch registra o momento da última alteração da entidade
+func (e *Entity) Touch() {
+	now := time.Now()
+	e.LastUpdateAt = &now
+}
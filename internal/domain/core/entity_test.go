@@ -15,4 +15,17 @@ func TestNewEntity(t *testing.T) {
 	assert.False(t, entity.CreatedAt.IsZero(), "CreatedAt deve ser inicializado com a data atual")
 	assert.Nil(t, entity.LastUpdateAt, "LastUpdateAt deve ser nulo para uma nova entidade")
 	assert.Nil(t, entity.DeletedAt, "DeletedAt deve ser nulo para uma nova entidade")
+	assert.Equal(t, int64(1), entity.Version, "Version deve começar em 1 para uma nova entidade")
+}
+
+func TestEntity_Touch(t *testing.T) {
+	// Configuração
+	entity := NewEntity()
+
+	// Execução
+	entity.Touch()
+
+	// Verificações
+	assert.NotNil(t, entity.LastUpdateAt, "Touch deve preencher LastUpdateAt")
+	assert.Equal(t, int64(1), entity.Version, "Touch não deve alterar a versão, que é controlada pelo repositório")
 } 
\ No newline at end of file
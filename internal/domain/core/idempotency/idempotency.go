@@ -0,0 +1,123 @@
+package idempotency
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"flickly/internal/domain/core"
+	"time"
+)
+
+// Estados de um registro de idempotência
+const (
+	StatusInProgress = "in_progress"
+	StatusCompleted  = "completed"
+)
+
+// MaxKeyLength é o tamanho máximo aceito para uma chave de idempotência
+const MaxKeyLength = 255
+
+// Padrões de validade dos registros
+const (
+	// DefaultTTL é por quanto tempo a resposta fica guardada para ser repetida
+	DefaultTTL = 24 * time.Hour
+	// DefaultLockTimeout é por quanto tempo uma execução em andamento bloqueia a chave;
+	// depois disso (ex.: o processo caiu) a chave pode ser usada novamente
+	DefaultLockTimeout = time.Minute
+)
+
+// Record é a execução de uma requisição identificada por uma chave de idempotência
+type Record struct {
+	Key string
+	// Fingerprint é o hash do conteúdo da requisição, usado para detectar a reutilização da chave
+	Fingerprint string
+	Status      string
+	// StatusCode, Headers e Body guardam a primeira resposta; StatusCode é zero fora do HTTP
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+	CreatedAt  time.Time
+	// ExpiresAt é o fim do bloqueio enquanto em andamento ou o fim da validade depois de concluído
+	ExpiresAt time.Time
+}
+
+// Response é a resposta guardada ao concluir uma execução
+type Response struct {
+	StatusCode int
+	Headers    map[string]string
+	Body       []byte
+}
+
+// Store guarda os registros de idempotência. As operações devem ser atômicas entre processos.
+type Store interface {
+	// Reserve grava o registro em andamento e retorna true se a chave estiver livre ou expirada;
+	// caso contrário retorna o registro existente e false
+	Reserve(record Record) (*Record, bool, error)
+	// Complete guarda a resposta e mantém o registro até expiresAt
+	Complete(key string, response Response, expiresAt time.Time) error
+	// Release libera a chave, permitindo que a requisição seja repetida
+	Release(key string) error
+}
+
+// keyKey é a chave usada para guardar a chave de idempotência no contexto
+type keyKey struct{}
+
+// WithKey retorna uma cópia do contexto com a chave de idempotência dos comandos enviados ao mediator
+func WithKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, keyKey{}, key)
+}
+
+// KeyFromContext retorna a chave de idempotência ou vazio quando não há
+func KeyFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	key, _ := ctx.Value(keyKey{}).(string)
+	return key
+}
+
+// NewRecord cria o registro em andamento da chave, bloqueado por lockTimeout
+func NewRecord(key string, fingerprint string, lockTimeout time.Duration) Record {
+	now := time.Now()
+	return Record{
+		Key:         key,
+		Fingerprint: fingerprint,
+		Status:      StatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(lockTimeout),
+	}
+}
+
+// ScopedKey isola as chaves por origem e por usuário, evitando que um cliente repita a resposta de outro
+func ScopedKey(scope string, subject string, key string) string {
+	return scope + ":" + subject + ":" + key
+}
+
+// Fingerprint calcula o hash das partes da requisição
+func Fingerprint(parts ...[]byte) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		hash.Write(part)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// Expired indica se o registro não vale mais no instante informado
+func (r *Record) Expired(now time.Time) bool {
+	return !now.Before(r.ExpiresAt)
+}
+
+// Check decide o que fazer com uma requisição cuja chave já está registrada: nil quando a resposta
+// guardada pode ser repetida, core.ErrIdempotencyKeyReused quando o conteúdo difere e
+// core.ErrIdempotencyInProgress quando a primeira execução ainda não terminou
+func (r *Record) Check(fingerprint string) error {
+	if r.Fingerprint != fingerprint {
+		return core.ErrIdempotencyKeyReused(errors.New("idempotency key reused with a different request"))
+	}
+	if r.Status != StatusCompleted {
+		return core.ErrIdempotencyInProgress(errors.New("request with the same idempotency key is in progress"))
+	}
+	return nil
+}
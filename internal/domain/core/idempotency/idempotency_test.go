@@ -0,0 +1,63 @@
+package idempotency
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithKey(t *testing.T) {
+	// Execução
+	ctx := WithKey(context.Background(), "key-1")
+
+	// Verificações
+	assert.Equal(t, "key-1", KeyFromContext(ctx), "A chave deve ser lida do contexto")
+	assert.Empty(t, KeyFromContext(context.Background()), "Sem chave, deve retornar vazio")
+	assert.Empty(t, KeyFromContext(nil), "Um contexto nil não deve causar panic")
+}
+
+func TestNewRecord(t *testing.T) {
+	// Execução
+	record := NewRecord("key-1", "abc", time.Minute)
+
+	// Verificações
+	assert.Equal(t, StatusInProgress, record.Status, "Um novo registro deve estar em andamento")
+	assert.Equal(t, record.CreatedAt.Add(time.Minute), record.ExpiresAt, "O bloqueio deve durar o tempo informado")
+	assert.False(t, record.Expired(record.CreatedAt))
+	assert.True(t, record.Expired(record.ExpiresAt), "O registro deve expirar no fim do bloqueio")
+}
+
+func TestFingerprint(t *testing.T) {
+	assert.Equal(t, Fingerprint([]byte("POST"), []byte("/user")), Fingerprint([]byte("POST"), []byte("/user")), "O hash deve ser determinístico")
+	assert.NotEqual(t, Fingerprint([]byte("ab"), []byte("c")), Fingerprint([]byte("a"), []byte("bc")), "As partes devem ser separadas no hash")
+}
+
+func TestScopedKey(t *testing.T) {
+	assert.NotEqual(t, ScopedKey("http", "user-1", "key"), ScopedKey("http", "user-2", "key"), "Usuários diferentes não devem compartilhar chaves")
+}
+
+func TestRecord_Check(t *testing.T) {
+	// Configuração
+	var domainError *core.DomainError
+	completed := &Record{Fingerprint: "abc", Status: StatusCompleted}
+	inProgress := &Record{Fingerprint: "abc", Status: StatusInProgress}
+
+	// Execução e verificações
+	assert.NoError(t, completed.Check("abc"), "A mesma requisição concluída deve ser repetida")
+
+	err := completed.Check("def")
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, 13, domainError.Code, "Conteúdo diferente com a mesma chave deve ser rejeitado")
+
+	err = inProgress.Check("abc")
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, 12, domainError.Code, "Uma execução em andamento deve ser informada")
+
+	err = inProgress.Check("def")
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, 13, domainError.Code, "A divergência de conteúdo tem prioridade sobre a execução em andamento")
+}
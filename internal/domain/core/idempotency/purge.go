@@ -0,0 +1,28 @@
+package idempotency
+
+import (
+	"context"
+	"time"
+)
+
+// Purger remove os registros expirados; implementado pelos armazenamentos de idempotência
+type Purger interface {
+	Purge(now time.Time) (int64, error)
+}
+
+// PurgeExpiredCommand remove os registros de idempotência expirados. É agendado periodicamente e não
+// participa de transações, por isso não é um comando transacional.
+type PurgeExpiredCommand struct{}
+
+type PurgeExpiredCommandHandler struct {
+	purger Purger
+}
+
+func NewPurgeExpiredCommandHandler(purger Purger) *PurgeExpiredCommandHandler {
+	return &PurgeExpiredCommandHandler{purger: purger}
+}
+
+// Handle retorna a quantidade de registros removidos
+func (h *PurgeExpiredCommandHandler) Handle(ctx context.Context, command PurgeExpiredCommand) (int64, error) {
+	return h.purger.Purge(time.Now())
+}
@@ -0,0 +1,33 @@
+package idempotency
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockPurger registra o horário usado na remoção
+type MockPurger struct {
+	PurgedAt time.Time
+}
+
+func (p *MockPurger) Purge(now time.Time) (int64, error) {
+	p.PurgedAt = now
+	return 2, nil
+}
+
+func TestPurgeExpiredCommandHandler(t *testing.T) {
+	// Configuração
+	purger := &MockPurger{}
+	handler := NewPurgeExpiredCommandHandler(purger)
+
+	// Execução
+	removed, err := handler.Handle(context.Background(), PurgeExpiredCommand{})
+
+	// Verificações
+	assert.NoError(t, err, "Handle não deve retornar erro")
+	assert.Equal(t, int64(2), removed, "A quantidade de registros removidos deve ser retornada")
+	assert.WithinDuration(t, time.Now(), purger.PurgedAt, time.Second, "Os registros devem ser removidos com o horário atual")
+}
@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"encoding/json"
+	"flickly/internal/domain/core/security"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Estados de um job
+const (
+	StatusPending    = "pending"
+	StatusRunning    = "running"
+	StatusSucceeded  = "succeeded"
+	StatusDeadLetter = "dead_letter"
+)
+
+// DefaultMaxAttempts é o número de execuções de um job antes de ele ir para o dead-letter
+const DefaultMaxAttempts = 5
+
+// Job é uma requisição do mediator enfileirada para execução em segundo plano
+type Job struct {
+	ID          uuid.UUID       `json:"id"`
+	RequestType string          `json:"requestType"`
+	Payload     json.RawMessage `json:"payload"`
+	Status      string          `json:"status"`
+	Attempts    int             `json:"attempts"`
+	MaxAttempts int             `json:"maxAttempts"`
+	// NextAttemptAt é quando o job pode ser executado; durante a execução, é o fim da reserva do worker
+	NextAttemptAt time.Time          `json:"nextAttemptAt"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+	LastError     string             `json:"lastError,omitempty"`
+	Result        json.RawMessage    `json:"result,omitempty"`
+	Principal     security.Principal `json:"principal"`
+	CorrelationID string             `json:"correlationId,omitempty"`
+}
+
+// NewJob serializa a requisição em um job pendente, guardando quem a enfileirou
+func NewJob(requestType string, request interface{}, principal security.Principal, correlationID string) (*Job, error) {
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &Job{
+		ID:            uuid.New(),
+		RequestType:   requestType,
+		Payload:       payload,
+		Status:        StatusPending,
+		MaxAttempts:   DefaultMaxAttempts,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Principal:     principal,
+		CorrelationID: correlationID,
+	}, nil
+}
+
+// Finished indica se o job não será mais executado
+func (j Job) Finished() bool {
+	return j.Status == StatusSucceeded || j.Status == StatusDeadLetter
+}
+
+// Queue guarda os jobs. Enqueue pode ser chamado na mesma transação do comando que enfileirou o job.
+type Queue interface {
+	Enqueue(job *Job) error
+	// Claim reserva até limit jobs prontos para execução, em ordem de criação, marcando-os como em execução
+	// até now+lease e contando a tentativa. Jobs em execução cuja reserva venceu (worker interrompido) são reservados de novo.
+	Claim(now time.Time, lease time.Duration, limit int) ([]Job, error)
+	Complete(id uuid.UUID, result json.RawMessage, completedAt time.Time) error
+	Retry(id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+	DeadLetter(id uuid.UUID, lastError string, failedAt time.Time) error
+	// Get retorna o job ou nil quando ele não existe
+	Get(id uuid.UUID) (*Job, error)
+	// DeadLetters retorna os jobs que esgotaram as tentativas, dos mais recentes para os mais antigos
+	DeadLetters(limit int) ([]Job, error)
+}
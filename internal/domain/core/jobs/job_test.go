@@ -0,0 +1,44 @@
+package jobs
+
+import (
+	"flickly/internal/domain/core/security"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleRequest struct {
+	Value string `json:"value"`
+}
+
+func TestNewJob(t *testing.T) {
+	// Execução
+	job, err := NewJob("sample.Request", sampleRequest{Value: "test"}, security.Principal{Subject: "user-1"}, "corr-1")
+
+	// Verificações
+	assert.NoError(t, err, "NewJob não deve retornar erro")
+	assert.NotEqual(t, uuid.Nil, job.ID, "O job deve ter um ID")
+	assert.Equal(t, "sample.Request", job.RequestType)
+	assert.JSONEq(t, `{"value":"test"}`, string(job.Payload), "A requisição deve ser serializada em JSON")
+	assert.Equal(t, StatusPending, job.Status, "Um novo job deve estar pendente")
+	assert.Equal(t, DefaultMaxAttempts, job.MaxAttempts)
+	assert.Equal(t, job.CreatedAt, job.NextAttemptAt, "A primeira execução deve ser imediata")
+	assert.Equal(t, "user-1", job.Principal.Subject, "O usuário que enfileirou deve ser guardado")
+	assert.Equal(t, "corr-1", job.CorrelationID)
+	assert.False(t, job.Finished())
+}
+
+func TestNewJob_InvalidRequest(t *testing.T) {
+	// Execução
+	_, err := NewJob("sample.Request", make(chan int), security.Principal{}, "")
+
+	// Verificações
+	assert.Error(t, err, "Requisições que não podem ser serializadas devem ser rejeitadas")
+}
+
+func TestJob_Finished(t *testing.T) {
+	assert.True(t, Job{Status: StatusSucceeded}.Finished(), "Jobs concluídos não são mais executados")
+	assert.True(t, Job{Status: StatusDeadLetter}.Finished(), "Jobs no dead-letter não são mais executados")
+	assert.False(t, Job{Status: StatusRunning}.Finished())
+}
@@ -0,0 +1,42 @@
+package mediator
+
+import (
+	"context"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"log"
+)
+
+// auditBehavior grava na trilha de auditoria o resultado de cada comando, inclusive falhas e panics.
+// Uma falha na gravação não altera o resultado do comando.
+type auditBehavior struct {
+	store audit.Store
+}
+
+func (b *auditBehavior) Handle(ctx context.Context, request Request, next Next) (response Response, err error) {
+	if !isCommand(request) {
+		return next(ctx)
+	}
+	requestType := utilities.GetStructName(request)
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			b.record(ctx, requestType, request, nil, fmt.Errorf("panic: %v", recovered))
+			panic(recovered)
+		}
+		b.record(ctx, requestType, request, response, err)
+	}()
+	return next(ctx)
+}
+
+func (b *auditBehavior) record(ctx context.Context, requestType string, request Request, response Response, err error) {
+	entry, entryErr := audit.NewEntry(security.PrincipalFromContext(ctx).Subject, requestType, request, response, err, core.CorrelationIDFromContext(ctx))
+	if entryErr == nil {
+		entryErr = b.store.Append(entry)
+	}
+	if entryErr != nil {
+		log.Printf("audit: failed to record %s: %v", requestType, entryErr)
+	}
+}
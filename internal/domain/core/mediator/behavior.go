@@ -0,0 +1,30 @@
+package mediator
+
+import "context"
+
+// Next executa o restante da cadeia: o próximo behavior ou, no fim, o handler.
+// O contexto informado é repassado adiante, permitindo que o behavior acrescente valores a ele.
+type Next func(ctx context.Context) (Response, error)
+
+// Behavior envolve a execução do handler. Pode agir antes e depois de chamar next,
+// alterar a resposta ou interromper a cadeia retornando sem chamar next.
+type Behavior interface {
+	Handle(ctx context.Context, request Request, next Next) (Response, error)
+}
+
+// BehaviorFunc permite usar uma função como Behavior
+type BehaviorFunc func(ctx context.Context, request Request, next Next) (Response, error)
+
+func (f BehaviorFunc) Handle(ctx context.Context, request Request, next Next) (Response, error) {
+	return f(ctx, request, next)
+}
+
+// invoke executa os behaviors em ordem, terminando em handle
+func invoke(ctx context.Context, request Request, behaviors []Behavior, handle Next) (Response, error) {
+	if len(behaviors) == 0 {
+		return handle(ctx)
+	}
+	return behaviors[0].Handle(ctx, request, func(ctx context.Context) (Response, error) {
+		return invoke(ctx, request, behaviors[1:], handle)
+	})
+}
@@ -0,0 +1,110 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"reflect"
+	"testing"
+)
+
+// recordingBehavior registra a ordem de entrada e saída dos behaviors
+func recordingBehavior(name string, calls *[]string) Behavior {
+	return BehaviorFunc(func(ctx context.Context, request Request, next Next) (Response, error) {
+		*calls = append(*calls, "before "+name)
+		response, err := next(ctx)
+		*calls = append(*calls, "after "+name)
+		return response, err
+	})
+}
+
+// recordingHandler registra a chamada do handler na mesma lista dos behaviors
+type recordingHandler struct {
+	calls *[]string
+}
+
+func (h *recordingHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	*h.calls = append(*h.calls, "handler")
+	return MockResponse{Result: "success"}, nil
+}
+
+func TestSend_BehaviorsRunInOrder(t *testing.T) {
+	// Configuração
+	var calls []string
+	mediator := NewMediatR(
+		WithRequestBehavior[MockRequest](recordingBehavior("request", &calls)),
+		WithBehavior(recordingBehavior("first", &calls)),
+		WithBehavior(recordingBehavior("second", &calls)),
+	)
+	mediator.Register(reflect.TypeFor[MockRequest](), &recordingHandler{calls: &calls})
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, MockResponse{Result: "success"}, response)
+	assert.Equal(t, []string{
+		"before first", "before second", "before request",
+		"handler",
+		"after request", "after second", "after first",
+	}, calls, "Behaviors globais devem envolver os behaviors do tipo da requisição, na ordem de registro")
+}
+
+func TestSend_RequestBehaviorOnlyForItsRequestType(t *testing.T) {
+	// Configuração
+	var calls []string
+	mediator := NewMediatR(WithRequestBehavior[MockTransactionalRequest](recordingBehavior("request", &calls)))
+	mediator.Register(reflect.TypeFor[MockRequest](), &recordingHandler{calls: &calls})
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"handler"}, calls, "O behavior não deve ser executado para outros tipos de requisição")
+}
+
+func TestSend_BehaviorShortCircuits(t *testing.T) {
+	// Configuração
+	var calls []string
+	expectedError := errors.New("blocked")
+	mediator := NewMediatR(WithBehavior(BehaviorFunc(func(ctx context.Context, request Request, next Next) (Response, error) {
+		return nil, expectedError
+	})))
+	mediator.Register(reflect.TypeFor[MockRequest](), &recordingHandler{calls: &calls})
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.Equal(t, expectedError, err, "O erro do behavior deve ser retornado")
+	assert.Nil(t, response)
+	assert.Empty(t, calls, "O handler não deve ser executado quando o behavior interrompe a cadeia")
+}
+
+func TestSend_BehaviorSeesRequestAndResponse(t *testing.T) {
+	// Configuração
+	var seenRequest Request
+	var seenResponse Response
+	mediator := NewMediatR(WithBehavior(BehaviorFunc(func(ctx context.Context, request Request, next Next) (Response, error) {
+		seenRequest = request
+		response, err := next(ctx)
+		seenResponse = response
+		return MockResponse{Result: "replaced"}, err
+	})))
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockHandler{ReturnResponse: MockResponse{Result: "success"}})
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, MockRequest{Data: "test"}, seenRequest, "O behavior deve receber a requisição")
+	assert.Equal(t, MockResponse{Result: "success"}, seenResponse, "O behavior deve receber a resposta do handler")
+	assert.Equal(t, MockResponse{Result: "replaced"}, response, "O behavior pode substituir a resposta")
+}
@@ -0,0 +1,63 @@
+package mediator
+
+import (
+	"context"
+	"flickly/internal/domain/core"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"time"
+)
+
+// TimingObserver recebe a duração de cada requisição processada pelo TimingBehavior
+type TimingObserver func(requestType string, elapsed time.Duration, err error)
+
+// LoggingBehavior registra no log o início e o resultado de cada requisição. Se logger for nil, usa o log padrão.
+func LoggingBehavior(logger *log.Logger) Behavior {
+	if logger == nil {
+		logger = log.Default()
+	}
+	return BehaviorFunc(func(ctx context.Context, request Request, next Next) (Response, error) {
+		requestType := utilities.GetStructName(request)
+		correlationID := core.CorrelationIDFromContext(ctx)
+		logger.Printf("mediator: handling %s (correlation %s)", requestType, correlationID)
+		response, err := next(ctx)
+		if err != nil {
+			logger.Printf("mediator: %s failed (correlation %s): %v", requestType, correlationID, err)
+		} else {
+			logger.Printf("mediator: %s succeeded (correlation %s)", requestType, correlationID)
+		}
+		return response, err
+	})
+}
+
+// TimingBehavior mede a duração de cada requisição e a repassa ao observer. Se observer for nil, a duração vai para o log.
+func TimingBehavior(observer TimingObserver) Behavior {
+	if observer == nil {
+		observer = func(requestType string, elapsed time.Duration, err error) {
+			log.Printf("mediator: %s took %s", requestType, elapsed)
+		}
+	}
+	return BehaviorFunc(func(ctx context.Context, request Request, next Next) (response Response, err error) {
+		start := time.Now()
+		defer func() {
+			observer(utilities.GetStructName(request), time.Since(start), err)
+		}()
+		return next(ctx)
+	})
+}
+
+// RecoveryBehavior converte um panic ocorrido no restante da cadeia em core.ErrInternal.
+// Deve ser registrado antes dos demais behaviors para que eles também sejam protegidos.
+func RecoveryBehavior() Behavior {
+	return BehaviorFunc(func(ctx context.Context, request Request, next Next) (response Response, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				log.Printf("mediator: panic handling %s: %v\n%s", utilities.GetStructName(request), recovered, debug.Stack())
+				response, err = nil, core.ErrInternal(fmt.Errorf("panic: %v", recovered))
+			}
+		}()
+		return next(ctx)
+	})
+}
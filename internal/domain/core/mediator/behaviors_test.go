@@ -0,0 +1,81 @@
+package mediator
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/uow"
+	"github.com/stretchr/testify/assert"
+	"log"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestLoggingBehavior(t *testing.T) {
+	// Configuração
+	var output bytes.Buffer
+	mediator := NewMediatR(WithBehavior(LoggingBehavior(log.New(&output, "", 0))))
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockHandler{ReturnError: errors.New("handler error")})
+	ctx := context.Background()
+	ctx = core.WithCorrelationID(ctx, "corr-1")
+
+	// Execução
+	_, _ = mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.Contains(t, output.String(), "handling MockRequest (correlation corr-1)", "O início da requisição deve ser registrado")
+	assert.Contains(t, output.String(), "MockRequest failed (correlation corr-1): handler error", "A falha deve ser registrada")
+}
+
+func TestTimingBehavior(t *testing.T) {
+	// Configuração
+	var observedType string
+	var observedElapsed time.Duration
+	var observedErr error
+	expectedError := errors.New("handler error")
+	mediator := NewMediatR(WithBehavior(TimingBehavior(func(requestType string, elapsed time.Duration, err error) {
+		observedType, observedElapsed, observedErr = requestType, elapsed, err
+	})))
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockHandler{ReturnError: expectedError})
+	ctx := context.Background()
+
+	// Execução
+	_, _ = mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.Equal(t, "MockRequest", observedType, "O tipo da requisição deve ser informado")
+	assert.GreaterOrEqual(t, observedElapsed, time.Duration(0))
+	assert.Equal(t, expectedError, observedErr, "O erro da requisição deve ser informado")
+}
+
+func TestRecoveryBehavior(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockAuditStore{}
+	mediator := NewMediatR(
+		WithBehavior(RecoveryBehavior()),
+		WithUnitOfWork(uow.NewFactory(provider)),
+		WithAuditTrail(store),
+	)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{Panic: true})
+	ctx := context.Background()
+
+	// Execução
+	var response Response
+	var err error
+	assert.NotPanics(t, func() {
+		response, err = mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+	}, "O panic deve ser convertido em erro")
+
+	// Verificações
+	assert.Nil(t, response)
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "O erro deve ser um DomainError")
+	assert.Equal(t, 500, domainError.StatusCode)
+	assert.True(t, provider.Transactions[0].RolledBack, "A transação deve ser desfeita")
+	assert.Len(t, store.Entries, 1, "O panic deve ser auditado")
+	assert.Equal(t, audit.ResultFailure, store.Entries[0].Result)
+}
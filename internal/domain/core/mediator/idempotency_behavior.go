@@ -0,0 +1,102 @@
+package mediator
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/security"
+	"log"
+	"reflect"
+	"time"
+)
+
+// idempotencyScope separa as chaves do mediator das usadas pelo middleware HTTP
+const idempotencyScope = "mediator"
+
+// WithIdempotency faz com que comandos enviados com uma chave de idempotência (veja idempotency.WithKey)
+// sejam executados uma única vez: repetições recebem a resposta guardada por ttl (padrão: idempotency.DefaultTTL)
+func WithIdempotency(store idempotency.Store, ttl time.Duration) Option {
+	if ttl <= 0 {
+		ttl = idempotency.DefaultTTL
+	}
+	return func(m *MediatR) {
+		m.idempotencyStore = store
+		m.idempotencyTTL = ttl
+	}
+}
+
+// idempotencyBehavior executa uma única vez cada comando com chave de idempotência. A chave é do usuário
+// que envia o comando; o mesmo conteúdo repete a resposta guardada, outro conteúdo é rejeitado com
+// core.ErrIdempotencyKeyReused e uma execução em andamento com core.ErrIdempotencyInProgress.
+// Comandos que falham liberam a chave para que possam ser repetidos.
+type idempotencyBehavior struct {
+	store        idempotency.Store
+	ttl          time.Duration
+	responseType func(requestType reflect.Type) reflect.Type
+}
+
+func (b *idempotencyBehavior) Handle(ctx context.Context, request Request, next Next) (Response, error) {
+	key := idempotency.KeyFromContext(ctx)
+	if key == "" || !isCommand(request) {
+		return next(ctx)
+	}
+	requestType := reflect.TypeOf(request)
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+	fingerprint := idempotency.Fingerprint([]byte(jobTypeName(requestType)), payload)
+	scopedKey := idempotency.ScopedKey(idempotencyScope, security.PrincipalFromContext(ctx).Subject, key)
+
+	existing, reserved, err := b.store.Reserve(idempotency.NewRecord(scopedKey, fingerprint, idempotency.DefaultLockTimeout))
+	if err != nil {
+		return nil, err
+	}
+	if !reserved {
+		if err = existing.Check(fingerprint); err != nil {
+			return nil, err
+		}
+		return b.decode(requestType, existing.Body)
+	}
+
+	completed := false
+	defer func() {
+		if !completed {
+			if releaseErr := b.store.Release(scopedKey); releaseErr != nil {
+				log.Printf("idempotency: failed to release key %s: %v", scopedKey, releaseErr)
+			}
+		}
+	}()
+
+	// Os comandos enviados pelo handler não herdam a chave
+	response, err := next(idempotency.WithKey(ctx, ""))
+	if err != nil {
+		return nil, err
+	}
+	body, err := json.Marshal(response)
+	if err == nil {
+		err = b.store.Complete(scopedKey, idempotency.Response{Body: body}, time.Now().Add(b.ttl))
+	}
+	if err != nil {
+		log.Printf("idempotency: failed to store response for key %s: %v", scopedKey, err)
+		return response, nil
+	}
+	completed = true
+	return response, nil
+}
+
+// decode reconstrói a resposta guardada no tipo de resposta do handler
+func (b *idempotencyBehavior) decode(requestType reflect.Type, body []byte) (Response, error) {
+	if len(body) == 0 || string(body) == "null" {
+		return nil, nil
+	}
+	responseType := b.responseType(requestType)
+	if responseType == nil {
+		responseType = reflect.TypeFor[interface{}]()
+	}
+	response := reflect.New(responseType)
+	if err := json.Unmarshal(body, response.Interface()); err != nil {
+		return nil, err
+	}
+	return response.Elem().Interface(), nil
+}
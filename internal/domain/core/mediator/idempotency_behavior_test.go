@@ -0,0 +1,207 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockIdempotencyStore guarda os registros de idempotência em memória
+type MockIdempotencyStore struct {
+	Records map[string]idempotency.Record
+	mu      sync.Mutex
+}
+
+func NewMockIdempotencyStore() *MockIdempotencyStore {
+	return &MockIdempotencyStore{Records: make(map[string]idempotency.Record)}
+}
+
+func (s *MockIdempotencyStore) Reserve(record idempotency.Record) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if existing, ok := s.Records[record.Key]; ok && !existing.Expired(time.Now()) {
+		return &existing, false, nil
+	}
+	s.Records[record.Key] = record
+	return nil, true, nil
+}
+
+func (s *MockIdempotencyStore) Complete(key string, response idempotency.Response, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record := s.Records[key]
+	record.Status = idempotency.StatusCompleted
+	record.Body = response.Body
+	record.ExpiresAt = expiresAt
+	s.Records[key] = record
+	return nil
+}
+
+func (s *MockIdempotencyStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.Records, key)
+	return nil
+}
+
+// MockIdempotentCommand é um comando usado nos testes de idempotência
+type MockIdempotentCommand struct {
+	Name string
+}
+
+func (c MockIdempotentCommand) Transactional() bool {
+	return true
+}
+
+// MockIdempotentResult é a resposta de MockIdempotentCommand
+type MockIdempotentResult struct {
+	Name      string
+	Execution int
+}
+
+// MockIdempotentHandler conta as execuções e falha enquanto Fail for verdadeiro
+type MockIdempotentHandler struct {
+	Executions int
+	Fail       bool
+	Keys       []string
+}
+
+func (h *MockIdempotentHandler) Handle(ctx context.Context, command MockIdempotentCommand) (*MockIdempotentResult, error) {
+	h.Keys = append(h.Keys, idempotency.KeyFromContext(ctx))
+	if h.Fail {
+		return nil, errors.New("handler failed")
+	}
+	h.Executions++
+	return &MockIdempotentResult{Name: command.Name, Execution: h.Executions}, nil
+}
+
+func newIdempotentMediator(store idempotency.Store, handler *MockIdempotentHandler) Mediator {
+	mediator := NewMediatR(WithIdempotency(store, time.Hour))
+	Register[MockIdempotentCommand, *MockIdempotentResult](mediator, handler)
+	return mediator
+}
+
+func TestIdempotencyBehavior_Replay(t *testing.T) {
+	// Configuração
+	handler := &MockIdempotentHandler{}
+	mediator := newIdempotentMediator(NewMockIdempotencyStore(), handler)
+	ctx := idempotency.WithKey(context.Background(), "key-1")
+
+	// Execução
+	first, firstErr := Send[*MockIdempotentResult](ctx, mediator, MockIdempotentCommand{Name: "test"})
+	second, secondErr := Send[*MockIdempotentResult](ctx, mediator, MockIdempotentCommand{Name: "test"})
+
+	// Verificações
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.Equal(t, 1, handler.Executions, "O comando deve ser executado uma única vez")
+	assert.Equal(t, first, second, "A repetição deve receber a resposta guardada, no tipo do handler")
+	assert.Equal(t, []string{""}, handler.Keys, "O handler não deve herdar a chave, evitando conflito com comandos aninhados")
+}
+
+func TestIdempotencyBehavior_KeyReused(t *testing.T) {
+	// Configuração
+	handler := &MockIdempotentHandler{}
+	mediator := newIdempotentMediator(NewMockIdempotencyStore(), handler)
+	ctx := idempotency.WithKey(context.Background(), "key-1")
+	_, _ = mediator.Send(ctx, MockIdempotentCommand{Name: "first"})
+
+	// Execução
+	_, err := mediator.Send(ctx, MockIdempotentCommand{Name: "second"})
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "A reutilização da chave deve retornar DomainError")
+	assert.Equal(t, 13, domainError.Code, "Outro conteúdo com a mesma chave deve ser rejeitado")
+	assert.Equal(t, 1, handler.Executions)
+}
+
+func TestIdempotencyBehavior_InProgress(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR(WithIdempotency(NewMockIdempotencyStore(), time.Hour))
+	command := MockIdempotentCommand{Name: "test"}
+	reserveCtx := idempotency.WithKey(context.Background(), "key-1")
+
+	// A primeira execução reserva a chave e, enquanto ela roda, a mesma requisição chega de novo
+	var err error
+	inner := MockIdempotentHandler{}
+	Register[MockIdempotentCommand, *MockIdempotentResult](mediator, RequestHandlerFunc[MockIdempotentCommand, *MockIdempotentResult](
+		func(ctx context.Context, request MockIdempotentCommand) (*MockIdempotentResult, error) {
+			_, err = mediator.Send(reserveCtx, command)
+			return inner.Handle(ctx, request)
+		}))
+
+	// Execução
+	_, firstErr := mediator.Send(reserveCtx, command)
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.NoError(t, firstErr)
+	assert.True(t, errors.As(err, &domainError), "A execução em andamento deve retornar DomainError")
+	assert.Equal(t, 12, domainError.Code, "A mesma requisição em andamento deve ser informada")
+	assert.Equal(t, 1, inner.Executions, "A requisição duplicada não deve ser executada")
+}
+
+func TestIdempotencyBehavior_FailureReleasesKey(t *testing.T) {
+	// Configuração
+	store := NewMockIdempotencyStore()
+	handler := &MockIdempotentHandler{Fail: true}
+	mediator := newIdempotentMediator(store, handler)
+	ctx := idempotency.WithKey(context.Background(), "key-1")
+
+	// Execução
+	_, failedErr := mediator.Send(ctx, MockIdempotentCommand{Name: "test"})
+	handler.Fail = false
+	response, err := Send[*MockIdempotentResult](ctx, mediator, MockIdempotentCommand{Name: "test"})
+
+	// Verificações
+	assert.Error(t, failedErr)
+	assert.NoError(t, err, "Após uma falha, a requisição deve poder ser repetida")
+	assert.Equal(t, 1, response.Execution)
+}
+
+func TestIdempotencyBehavior_Scope(t *testing.T) {
+	// Configuração
+	store := NewMockIdempotencyStore()
+	handler := &MockIdempotentHandler{}
+	mediator := newIdempotentMediator(store, handler)
+	user1 := idempotency.WithKey(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"}), "key-1")
+	user2 := idempotency.WithKey(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-2"}), "key-1")
+
+	// Execução
+	_, _ = mediator.Send(user1, MockIdempotentCommand{Name: "test"})
+	_, _ = mediator.Send(user2, MockIdempotentCommand{Name: "test"})
+	_, _ = mediator.Send(context.Background(), MockIdempotentCommand{Name: "test"})
+	_, _ = mediator.Send(context.Background(), MockIdempotentCommand{Name: "test"})
+
+	// Verificações
+	assert.Equal(t, 4, handler.Executions, "As chaves são por usuário e comandos sem chave sempre executam")
+	assert.Len(t, store.Records, 2)
+}
+
+func TestIdempotencyBehavior_RunJob(t *testing.T) {
+	// Configuração
+	queue := &MockJobQueue{}
+	handler := &MockIdempotentHandler{}
+	mediator := NewMediatR(WithJobQueue(queue), WithIdempotency(NewMockIdempotencyStore(), 0))
+	Register[MockIdempotentCommand, *MockIdempotentResult](mediator, handler)
+	_, err := mediator.Enqueue(context.Background(), MockIdempotentCommand{Name: "test"})
+	assert.NoError(t, err)
+
+	// Execução
+	first, _ := mediator.RunJob(context.Background(), queue.Jobs[0])
+	second, _ := mediator.RunJob(context.Background(), queue.Jobs[0])
+	_, _ = mediator.RunJob(context.Background(), jobs.Job{RequestType: queue.Jobs[0].RequestType, Payload: queue.Jobs[0].Payload})
+
+	// Verificações
+	assert.Equal(t, first, second, "Uma nova tentativa do mesmo job deve repetir a resposta")
+	assert.Equal(t, 2, handler.Executions, "Jobs diferentes devem ser executados")
+}
@@ -0,0 +1,84 @@
+package mediator
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
+	"fmt"
+	"reflect"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrJobQueueNotConfigured é retornado por Enqueue quando o mediator não tem fila de jobs
+	ErrJobQueueNotConfigured = errors.New("no job queue configured")
+	// ErrInvalidJobPayload indica que o payload do job não corresponde ao tipo da requisição
+	ErrInvalidJobPayload = errors.New("invalid job payload")
+)
+
+// WithJobQueue permite enfileirar requisições com Enqueue para execução em segundo plano
+func WithJobQueue(queue jobs.Queue) Option {
+	return func(m *MediatR) {
+		m.jobQueue = queue
+	}
+}
+
+// Enqueue serializa a requisição em um job para ser executado por um worker e retorna o ID do job.
+// Dentro de uma transação, o job só é gravado se a transação for confirmada.
+func (m *MediatR) Enqueue(ctx context.Context, request Request) (uuid.UUID, error) {
+	if m.jobQueue == nil {
+		return uuid.Nil, ErrJobQueueNotConfigured
+	}
+	requestType := reflect.TypeOf(request)
+	if IsReadOnly(ctx) {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrSideEffectInQuery, requestType)
+	}
+	if _, ok := m.handlers[requestType]; !ok {
+		return uuid.Nil, ErrHandlerNotFound
+	}
+	job, err := jobs.NewJob(jobTypeName(requestType), request, security.PrincipalFromContext(ctx), core.CorrelationIDFromContext(ctx))
+	if err != nil {
+		return uuid.Nil, err
+	}
+	queue, err := uow.ResolveRepository(ctx, m.jobQueue)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err = queue.Enqueue(job); err != nil {
+		return uuid.Nil, err
+	}
+	return job.ID, nil
+}
+
+// RunJob executa o job pelo pipeline normal do mediator, em nome do usuário e com o ID de correlação de quem o enfileirou.
+// O ID do job é a chave de idempotência, evitando que um comando já concluído seja executado de novo em uma nova tentativa.
+func (m *MediatR) RunJob(ctx context.Context, job jobs.Job) (Response, error) {
+	requestType, ok := m.jobTypes[job.RequestType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrHandlerNotFound, job.RequestType)
+	}
+	request := reflect.New(requestType)
+	if err := json.Unmarshal(job.Payload, request.Interface()); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidJobPayload, err)
+	}
+	ctx = security.WithPrincipal(ctx, job.Principal)
+	if job.CorrelationID != "" {
+		ctx = core.WithCorrelationID(ctx, job.CorrelationID)
+	}
+	ctx = idempotency.WithKey(ctx, "job:"+job.ID.String())
+	return m.Send(ctx, request.Elem().Interface())
+}
+
+// jobTypeName identifica o tipo da requisição no job, incluindo o pacote para evitar colisões
+func jobTypeName(requestType reflect.Type) string {
+	if requestType.Name() == "" {
+		return requestType.String()
+	}
+	return requestType.PkgPath() + "." + requestType.Name()
+}
@@ -0,0 +1,153 @@
+package mediator
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockJobQueue guarda os jobs enfileirados
+type MockJobQueue struct {
+	Jobs []jobs.Job
+}
+
+func (q *MockJobQueue) Enqueue(job *jobs.Job) error {
+	q.Jobs = append(q.Jobs, *job)
+	return nil
+}
+
+func (q *MockJobQueue) Claim(now time.Time, lease time.Duration, limit int) ([]jobs.Job, error) {
+	return nil, nil
+}
+
+func (q *MockJobQueue) Complete(id uuid.UUID, result json.RawMessage, completedAt time.Time) error {
+	return nil
+}
+
+func (q *MockJobQueue) Retry(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return nil
+}
+
+func (q *MockJobQueue) DeadLetter(id uuid.UUID, lastError string, failedAt time.Time) error {
+	return nil
+}
+
+func (q *MockJobQueue) Get(id uuid.UUID) (*jobs.Job, error) {
+	return nil, nil
+}
+
+func (q *MockJobQueue) DeadLetters(limit int) ([]jobs.Job, error) {
+	return nil, nil
+}
+
+// mockEchoHandler devolve os dados da requisição e o usuário que a executou
+type mockEchoHandler struct{}
+
+func (h *mockEchoHandler) Handle(ctx context.Context, request MockRequest) (MockResponse, error) {
+	return MockResponse{Result: request.Data + " by " + security.PrincipalFromContext(ctx).Subject + " (" + core.CorrelationIDFromContext(ctx) + ")"}, nil
+}
+
+func TestEnqueue(t *testing.T) {
+	// Configuração
+	queue := &MockJobQueue{}
+	mediator := NewMediatR(WithJobQueue(queue))
+	Register[MockRequest, MockResponse](mediator, &mockEchoHandler{})
+	ctx := core.WithCorrelationID(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"}), "corr-1")
+
+	// Execução
+	id, err := mediator.Enqueue(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, queue.Jobs, 1, "A requisição deve ser enfileirada")
+	job := queue.Jobs[0]
+	assert.Equal(t, id, job.ID, "Enqueue deve retornar o ID do job")
+	assert.Equal(t, "flickly/internal/domain/core/mediator.MockRequest", job.RequestType, "O tipo deve incluir o pacote")
+	assert.JSONEq(t, `{"Request":null,"Data":"test"}`, string(job.Payload))
+	assert.Equal(t, "user-1", job.Principal.Subject, "O usuário que enfileirou deve ser guardado")
+	assert.Equal(t, "corr-1", job.CorrelationID)
+}
+
+func TestEnqueue_Errors(t *testing.T) {
+	// Configuração
+	withoutQueue := NewMediatR()
+	Register[MockRequest, MockResponse](withoutQueue, &mockEchoHandler{})
+	withoutHandler := NewMediatR(WithJobQueue(&MockJobQueue{}))
+
+	// Execução
+	_, queueErr := withoutQueue.Enqueue(context.Background(), MockRequest{Data: "test"})
+	_, handlerErr := withoutHandler.Enqueue(context.Background(), MockRequest{Data: "test"})
+
+	// Verificações
+	assert.ErrorIs(t, queueErr, ErrJobQueueNotConfigured)
+	assert.ErrorIs(t, handlerErr, ErrHandlerNotFound, "Requisições sem handler não devem ser enfileiradas")
+}
+
+// MockEnqueueHandler enfileira uma requisição de dentro de um comando
+type MockEnqueueHandler struct {
+	Mediator Mediator
+}
+
+func (h *MockEnqueueHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	_, err := h.Mediator.Enqueue(ctx, MockRequest{Data: "from command"})
+	return nil, err
+}
+
+func TestEnqueue_JoinsTransaction(t *testing.T) {
+	// Configuração
+	queue := &MockJobQueue{}
+	transactionQueue := &MockJobQueue{}
+	factory := uow.NewFactory(&MockTransactionProvider{})
+	uow.AddRepository[jobs.Queue](factory, func(tx uow.Transaction) jobs.Queue { return transactionQueue })
+	mediator := NewMediatR(WithUnitOfWork(factory), WithJobQueue(queue))
+	Register[MockRequest, MockResponse](mediator, &mockEchoHandler{})
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockEnqueueHandler{Mediator: mediator})
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, queue.Jobs, "Dentro de uma transação, o job não deve ser gravado fora dela")
+	assert.Len(t, transactionQueue.Jobs, 1, "O job deve ser gravado na fila vinculada à transação")
+}
+
+func TestRunJob(t *testing.T) {
+	// Configuração
+	queue := &MockJobQueue{}
+	mediator := NewMediatR(WithJobQueue(queue))
+	Register[MockRequest, MockResponse](mediator, &mockEchoHandler{})
+	ctx := core.WithCorrelationID(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"}), "corr-1")
+	_, err := mediator.Enqueue(ctx, MockRequest{Data: "test"})
+	assert.NoError(t, err)
+
+	// Execução
+	response, err := mediator.RunJob(context.Background(), queue.Jobs[0])
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, MockResponse{Result: "test by user-1 (corr-1)"}, response, "O job deve ser executado em nome de quem o enfileirou")
+}
+
+func TestRunJob_Errors(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	Register[MockRequest, MockResponse](mediator, &mockEchoHandler{})
+
+	// Execução
+	_, unknownErr := mediator.RunJob(context.Background(), jobs.Job{RequestType: "unknown.Request", Payload: []byte(`{}`)})
+	_, payloadErr := mediator.RunJob(context.Background(), jobs.Job{RequestType: "flickly/internal/domain/core/mediator.MockRequest", Payload: []byte(`{"Data":1}`)})
+
+	// Verificações
+	assert.ErrorIs(t, unknownErr, ErrHandlerNotFound, "Tipos desconhecidos devem ser reportados")
+	assert.ErrorIs(t, payloadErr, ErrInvalidJobPayload, "Payloads inválidos devem ser reportados")
+}
@@ -1,51 +1,362 @@
 package mediator
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
-	"flickly/internal/infra/crosscutting/utilities"
-	"github.com/gin-gonic/gin"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/uow"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrHandlerNotFound é retornado quando não há handler registrado para o tipo da requisição
+	ErrHandlerNotFound = errors.New("no handler registered for request type")
+	// ErrDuplicateHandler indica que um tipo de requisição recebeu mais de um handler
+	ErrDuplicateHandler = errors.New("more than one handler registered for request type")
+	// ErrInvalidEventPayload indica que o payload da mensagem do outbox não corresponde ao tipo do evento
+	ErrInvalidEventPayload = errors.New("invalid event payload")
 )
 
+// ErrEventsOutsideTransaction é retornado quando uma consulta registra eventos de domínio
+var ErrEventsOutsideTransaction = errors.New("domain events cannot be added by queries")
+
+// eventsKey é a chave usada para guardar os eventos de domínio registrados pelo handler
+type eventsKey struct{}
+
+// eventBuffer acumula os eventos registrados durante a execução de uma requisição
+type eventBuffer struct {
+	events []core.DomainEvent
+}
+
 // Request interface para requisições
 type Request interface {
 }
 
+// TransactionalRequest interface para requisições que devem ser executadas em uma única transação
+type TransactionalRequest interface {
+	Request
+	Transactional() bool
+}
+
 // Response interface para respostas
 type Response interface{}
 
 // Handler interface para manipuladores de requisições
 type Handler interface {
-	Handle(c *gin.Context, request Request) (Response, error)
+	Handle(ctx context.Context, request Request) (Response, error)
+}
+
+// Sender envia requisições aos seus handlers
+type Sender interface {
+	Send(ctx context.Context, request Request) (Response, error)
+}
+
+// Publisher publica notificações aos handlers de notificação
+type Publisher interface {
+	Publish(ctx context.Context, notification Notification) error
+}
+
+// JobEnqueuer enfileira requisições para serem executadas por um worker
+type JobEnqueuer interface {
+	Enqueue(ctx context.Context, request Request) (uuid.UUID, error)
+}
+
+// JobRunner executa os jobs enfileirados com JobEnqueuer
+type JobRunner interface {
+	RunJob(ctx context.Context, job jobs.Job) (Response, error)
+}
+
+// MessagePublisher publica aos handlers de notificação os eventos gravados no outbox
+type MessagePublisher interface {
+	PublishMessage(ctx context.Context, message outbox.Message) error
+}
+
+// Registry registra os handlers de requisições e de notificações na inicialização
+type Registry interface {
+	Register(requestType reflect.Type, handler Handler)
+	Validate(requestTypes ...reflect.Type) error
+	Subscribe(notificationType reflect.Type, handler NotificationHandler)
 }
 
-// Mediator interface para o mediador
+// Mediator interface para o mediador. Os consumidores devem depender apenas da interface menor que usam.
 type Mediator interface {
-	Register(requestName string, handler Handler)
-	Send(c *gin.Context, request Request) (Response, error)
+	Sender
+	Publisher
+	JobEnqueuer
+	JobRunner
+	MessagePublisher
+	Registry
 }
 
+// Option configura comportamentos opcionais do MediatR
+type Option func(m *MediatR)
+
 type MediatR struct {
-	handlers map[string]Handler
+	handlers          map[reflect.Type]Handler
+	registrations     map[reflect.Type]int
+	jobTypes          map[string]reflect.Type
+	behaviors         []Behavior
+	requestBehaviors  map[reflect.Type][]Behavior
+	subscribers       map[reflect.Type][]NotificationHandler
+	eventTypes        map[string]reflect.Type
+	publishStrategy   PublishStrategy
+	unitOfWorkFactory uow.Factory
+	auditStore        audit.Store
+	outboxStore       outbox.Store
+	jobQueue          jobs.Queue
+	idempotencyStore  idempotency.Store
+	idempotencyTTL    time.Duration
 }
 
 // NewMediator cria uma nova instância do MediatR
-func NewMediatR() Mediator {
-	return &MediatR{
-		handlers: make(map[string]Handler),
+func NewMediatR(options ...Option) Mediator {
+	m := &MediatR{
+		handlers:         make(map[reflect.Type]Handler),
+		registrations:    make(map[reflect.Type]int),
+		jobTypes:         make(map[string]reflect.Type),
+		requestBehaviors: make(map[reflect.Type][]Behavior),
+		subscribers:      make(map[reflect.Type][]NotificationHandler),
+		eventTypes:       make(map[string]reflect.Type),
+		publishStrategy:  SequentialStopOnError,
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// WithUnitOfWork executa as requisições transacionais dentro de uma unidade de trabalho
+func WithUnitOfWork(factory uow.Factory) Option {
+	return func(m *MediatR) {
+		m.unitOfWorkFactory = factory
+	}
+}
+
+// WithAuditTrail registra na trilha de auditoria cada comando que altera estado (requisições transacionais)
+func WithAuditTrail(store audit.Store) Option {
+	return func(m *MediatR) {
+		m.auditStore = store
+	}
+}
+
+// WithOutbox configura o outbox onde as mensagens publicadas aos handlers após o commit são marcadas como tratadas,
+// para que o relay não as entregue de novo aos handlers
+func WithOutbox(store outbox.Store) Option {
+	return func(m *MediatR) {
+		m.outboxStore = store
+	}
+}
+
+// WithBehavior adiciona um behavior executado em todas as requisições, na ordem de registro
+func WithBehavior(behavior Behavior) Option {
+	return func(m *MediatR) {
+		m.behaviors = append(m.behaviors, behavior)
 	}
 }
 
-// Register registra um manipulador para um tipo de requisição
-func (m *MediatR) Register(requestName string, handler Handler) {
-	m.handlers[requestName] = handler
+// WithRequestBehavior adiciona um behavior executado apenas nas requisições do tipo TReq,
+// depois dos behaviors globais
+func WithRequestBehavior[TReq Request](behavior Behavior) Option {
+	requestType := reflect.TypeFor[TReq]()
+	return func(m *MediatR) {
+		m.requestBehaviors[requestType] = append(m.requestBehaviors[requestType], behavior)
+	}
 }
 
-// Send envia a requisição para o manipulador apropriado
-func (m *MediatR) Send(c *gin.Context, request Request) (Response, error) {
-	structName := utilities.GetStructName(request)
-	handler, ok := m.handlers[structName]
+// WithPublishStrategy define como as notificações são entregues aos handlers (padrão: SequentialStopOnError)
+func WithPublishStrategy(strategy PublishStrategy) Option {
+	return func(m *MediatR) {
+		m.publishStrategy = strategy
+	}
+}
+
+// Register registra um manipulador para um tipo de requisição. Prefira a função genérica Register,
+// que verifica os tipos em tempo de compilação.
+func (m *MediatR) Register(requestType reflect.Type, handler Handler) {
+	m.handlers[requestType] = handler
+	m.registrations[requestType]++
+	m.jobTypes[jobTypeName(requestType)] = requestType
+}
+
+// Validate verifica, na inicialização, que cada tipo de requisição informado tem um handler,
+// que nenhum tipo registrado recebeu mais de um handler e que nenhum é comando e consulta ao mesmo tempo
+func (m *MediatR) Validate(requestTypes ...reflect.Type) error {
+	var errs []error
+	for _, requestType := range requestTypes {
+		if _, ok := m.handlers[requestType]; !ok {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrHandlerNotFound, requestType))
+		}
+	}
+	for requestType, count := range m.registrations {
+		if count > 1 {
+			errs = append(errs, fmt.Errorf("%w: %s (%d handlers)", ErrDuplicateHandler, requestType, count))
+		}
+		if isAmbiguous(requestType) {
+			errs = append(errs, fmt.Errorf("%w: %s", ErrAmbiguousRequest, requestType))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Send envia a requisição para o manipulador apropriado, passando pelos behaviors registrados.
+// A ordem é: isolamento das consultas, behaviors globais, behaviors do tipo da requisição, idempotência,
+// auditoria, unidade de trabalho e handler.
+// Os eventos registrados pelo agregado retornado pelo handler (core.EventSource) são tratados como os de AddEvent.
+// Fora de uma transação, como quando nenhuma unidade de trabalho está configurada, não há outbox onde gravá-los:
+// os eventos são publicados diretamente aos handlers de notificação assim que o handler termina.
+func (m *MediatR) Send(ctx context.Context, request Request) (Response, error) {
+	requestType := reflect.TypeOf(request)
+	handler, ok := m.handlers[requestType]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrHandlerNotFound, requestType)
+	}
+	return invoke(ctx, request, m.pipeline(requestType), func(ctx context.Context) (Response, error) {
+		parent := ctx
+		inTransaction := uow.FromContext(ctx) != nil
+		if !inTransaction {
+			ctx = NewEventContext(ctx)
+		}
+		response, err := handler.Handle(ctx, request)
+		if err != nil {
+			return response, err
+		}
+		if source, ok := response.(core.EventSource); ok {
+			for _, event := range source.PullEvents() {
+				AddEvent(ctx, event)
+			}
+		}
+		if inTransaction {
+			return response, nil
+		}
+		events := PendingEvents(ctx)
+		if len(events) > 0 && IsReadOnly(ctx) {
+			return nil, ErrEventsOutsideTransaction
+		}
+		for _, event := range events {
+			if err = m.Publish(parent, event); err != nil {
+				return nil, err
+			}
+		}
+		return response, nil
+	})
+}
+
+// Subscribe registra um handler para um tipo de notificação. Prefira a função genérica Subscribe.
+// Eventos de domínio também ficam conhecidos pelo nome, para que PublishMessage possa decodificá-los.
+func (m *MediatR) Subscribe(notificationType reflect.Type, handler NotificationHandler) {
+	m.subscribers[notificationType] = append(m.subscribers[notificationType], handler)
+	if event, ok := reflect.Zero(notificationType).Interface().(core.DomainEvent); ok {
+		m.eventTypes[event.EventName()] = notificationType
+	}
+}
+
+// Publish entrega a notificação a todos os handlers do seu tipo, conforme a estratégia configurada.
+// Publicar uma notificação sem handlers não é um erro.
+func (m *MediatR) Publish(ctx context.Context, notification Notification) error {
+	handlers := m.subscribers[reflect.TypeOf(notification)]
+	if len(handlers) == 0 {
+		return nil
+	}
+	return m.publishStrategy(ctx, notification, handlers)
+}
+
+// PublishMessage decodifica o evento gravado no outbox e o publica aos handlers do seu tipo.
+// É usado pelo relay para reentregar os eventos já confirmados; mensagens de eventos sem handlers são ignoradas.
+func (m *MediatR) PublishMessage(ctx context.Context, message outbox.Message) error {
+	eventType, ok := m.eventTypes[message.EventName]
 	if !ok {
-		return nil, errors.New("no handler registered for request type")
+		return nil
+	}
+	event := reflect.New(eventType)
+	if err := json.Unmarshal(message.Payload, event.Interface()); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidEventPayload, err)
+	}
+	return m.Publish(ctx, event.Elem().Interface())
+}
+
+// pipeline monta a lista de behaviors aplicados ao tipo de requisição
+func (m *MediatR) pipeline(requestType reflect.Type) []Behavior {
+	behaviors := make([]Behavior, 0, len(m.behaviors)+len(m.requestBehaviors[requestType])+4)
+	behaviors = append(behaviors, readOnlyBehavior{})
+	behaviors = append(behaviors, m.behaviors...)
+	behaviors = append(behaviors, m.requestBehaviors[requestType]...)
+	if m.idempotencyStore != nil {
+		behaviors = append(behaviors, &idempotencyBehavior{store: m.idempotencyStore, ttl: m.idempotencyTTL, responseType: m.responseType})
+	}
+	if m.auditStore != nil {
+		behaviors = append(behaviors, &auditBehavior{store: m.auditStore})
+	}
+	if m.unitOfWorkFactory != nil {
+		behaviors = append(behaviors, &unitOfWorkBehavior{factory: m.unitOfWorkFactory, outboxStore: m.outboxStore, publish: m.Publish})
+	}
+	return behaviors
+}
+
+// NewEventContext retorna uma cópia do contexto que acumula os eventos registrados com AddEvent.
+// O mediator cria esse contexto a cada requisição; fora dele, é útil para executar handlers diretamente.
+func NewEventContext(ctx context.Context) context.Context {
+	return context.WithValue(ctx, eventsKey{}, &eventBuffer{})
+}
+
+// AddEvent registra um evento de domínio para ser gravado no outbox junto com a transação corrente.
+// Sem um contexto de eventos (veja NewEventContext), o evento é descartado.
+func AddEvent(ctx context.Context, event core.DomainEvent) {
+	if buffer, ok := ctx.Value(eventsKey{}).(*eventBuffer); ok {
+		buffer.events = append(buffer.events, event)
+	}
+}
+
+// PendingEvents retorna os eventos registrados e ainda não gravados no outbox
+func PendingEvents(ctx context.Context) []core.DomainEvent {
+	if buffer, ok := ctx.Value(eventsKey{}).(*eventBuffer); ok {
+		return buffer.events
+	}
+	return nil
+}
+
+// writeEvents grava os eventos registrados no outbox vinculado à unidade de trabalho e retorna as mensagens gravadas,
+// na mesma ordem dos eventos
+func writeEvents(unitOfWork uow.UnitOfWork, events []core.DomainEvent) ([]*outbox.Message, error) {
+	if len(events) == 0 {
+		return nil, nil
+	}
+	store, err := uow.GetRepository[outbox.Store](unitOfWork)
+	if err != nil {
+		return nil, err
+	}
+	messages := make([]*outbox.Message, 0, len(events))
+	for _, event := range events {
+		message, err := outbox.NewMessage(event)
+		if err != nil {
+			return nil, err
+		}
+		if err = store.Add(message); err != nil {
+			return nil, err
+		}
+		messages = append(messages, message)
+	}
+	return messages, nil
+}
+
+// isCommand indica se a requisição altera estado, ou seja, se é uma requisição transacional
+func isCommand(request Request) bool {
+	transactionalRequest, ok := request.(TransactionalRequest)
+	return ok && transactionalRequest.Transactional()
+}
+
+// responseType retorna o tipo da resposta do handler registrado com a função Register, ou nil se não for conhecido
+func (m *MediatR) responseType(requestType reflect.Type) reflect.Type {
+	if typed, ok := m.handlers[requestType].(interface{ responseType() reflect.Type }); ok {
+		return typed.responseType()
 	}
-	return handler.Handle(c, request)
+	return nil
 }
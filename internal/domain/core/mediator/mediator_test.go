@@ -1,10 +1,19 @@
 package mediator
 
 import (
+	"context"
 	"errors"
-	"github.com/gin-gonic/gin"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
+	"time"
+
+	"github.com/google/uuid"
 )
 
 // MockRequest implementa a interface Request para testes
@@ -24,7 +33,7 @@ type MockHandler struct {
 	ReturnError    error
 }
 
-func (h *MockHandler) Handle(c *gin.Context, request Request) (Response, error) {
+func (h *MockHandler) Handle(ctx context.Context, request Request) (Response, error) {
 	return h.ReturnResponse, h.ReturnError
 }
 
@@ -43,11 +52,11 @@ func TestRegisterAndSend(t *testing.T) {
 	mockRequest := MockRequest{Data: "test"}
 
 	// Testar registro
-	mediator.Register("MockRequest", mockHandler)
+	mediator.Register(reflect.TypeFor[MockRequest](), mockHandler)
 
 	// Testar envio de solicitação
-	ginContext, _ := gin.CreateTestContext(nil)
-	response, err := mediator.Send(ginContext, mockRequest)
+	ctx := context.Background()
+	response, err := mediator.Send(ctx, mockRequest)
 
 	// Verificações
 	assert.NoError(t, err, "Send não deve retornar erro quando o handler registrado retorna sucesso")
@@ -68,11 +77,11 @@ func TestSendWithHandlerError(t *testing.T) {
 	mockRequest := MockRequest{Data: "test"}
 
 	// Testar registro
-	mediator.Register("MockRequest", mockHandler)
+	mediator.Register(reflect.TypeFor[MockRequest](), mockHandler)
 
 	// Testar envio de solicitação
-	ginContext, _ := gin.CreateTestContext(nil)
-	response, err := mediator.Send(ginContext, mockRequest)
+	ctx := context.Background()
+	response, err := mediator.Send(ctx, mockRequest)
 
 	// Verificações
 	assert.Equal(t, expectedError, err, "Send deve retornar o erro do handler")
@@ -85,11 +94,407 @@ func TestSendWithoutRegisteredHandler(t *testing.T) {
 	mockRequest := MockRequest{Data: "test"}
 
 	// Testar envio de solicitação sem handler registrado
-	ginContext, _ := gin.CreateTestContext(nil)
-	response, err := mediator.Send(ginContext, mockRequest)
+	ctx := context.Background()
+	response, err := mediator.Send(ctx, mockRequest)
 
 	// Verificações
 	assert.Error(t, err, "Send deve retornar erro quando não há handler registrado")
 	assert.Nil(t, response, "Response deve ser nil quando não há handler registrado")
-	assert.Equal(t, "no handler registered for request type", err.Error(), "Mensagem de erro incorreta")
-} 
\ No newline at end of file
+	assert.ErrorIs(t, err, ErrHandlerNotFound, "O erro deve ser ErrHandlerNotFound")
+	assert.Equal(t, "no handler registered for request type: mediator.MockRequest", err.Error(), "A mensagem deve informar o tipo da requisição")
+}
+
+// MockTransactionalRequest implementa a interface TransactionalRequest para testes
+type MockTransactionalRequest struct {
+	Data string
+}
+
+func (r MockTransactionalRequest) Transactional() bool {
+	return true
+}
+
+// MockTransactionalHandler registra a unidade de trabalho ativa durante a execução
+type MockTransactionalHandler struct {
+	UnitOfWork  uow.UnitOfWork
+	ReturnError error
+	Panic       bool
+	Events      []core.DomainEvent
+}
+
+func (h *MockTransactionalHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	h.UnitOfWork = uow.FromContext(ctx)
+	for _, event := range h.Events {
+		AddEvent(ctx, event)
+	}
+	if h.Panic {
+		panic("handler panic")
+	}
+	return MockResponse{Result: "success"}, h.ReturnError
+}
+
+// MockTransaction registra as chamadas de commit e rollback
+type MockTransaction struct {
+	Committed   bool
+	RolledBack  bool
+	CommitError error
+}
+
+func (t *MockTransaction) Commit() error {
+	if t.CommitError != nil {
+		return t.CommitError
+	}
+	t.Committed = true
+	return nil
+}
+
+func (t *MockTransaction) Rollback() error {
+	t.RolledBack = true
+	return nil
+}
+
+// MockTransactionProvider registra as transações abertas
+type MockTransactionProvider struct {
+	Transactions []*MockTransaction
+	CommitError  error
+}
+
+func (p *MockTransactionProvider) Begin(ctx context.Context) (uow.Transaction, error) {
+	transaction := &MockTransaction{CommitError: p.CommitError}
+	p.Transactions = append(p.Transactions, transaction)
+	return transaction, nil
+}
+
+func TestSendTransactional_Commit(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	handler := &MockTransactionalHandler{}
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), handler)
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err, "Send não deve retornar erro quando o handler retorna sucesso")
+	assert.Equal(t, MockResponse{Result: "success"}, response)
+	assert.NotNil(t, handler.UnitOfWork, "O handler deve ser executado dentro de uma unidade de trabalho")
+	assert.Len(t, provider.Transactions, 1, "Uma transação deve ser aberta")
+	assert.True(t, provider.Transactions[0].Committed, "A transação deve ser confirmada")
+	assert.False(t, provider.Transactions[0].RolledBack, "A transação não deve ser desfeita")
+	assert.Nil(t, uow.FromContext(ctx), "A unidade de trabalho deve ser removida do contexto ao final")
+}
+
+func TestSendTransactional_RollbackOnError(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	expectedError := errors.New("handler error")
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{ReturnError: expectedError})
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Equal(t, expectedError, err, "Send deve retornar o erro do handler")
+	assert.Nil(t, response, "Response deve ser nil em caso de erro")
+	assert.False(t, provider.Transactions[0].Committed, "A transação não deve ser confirmada")
+	assert.True(t, provider.Transactions[0].RolledBack, "A transação deve ser desfeita")
+}
+
+func TestSendTransactional_RollbackWhenCommitFails(t *testing.T) {
+	// Configuração
+	expectedError := errors.New("commit error")
+	provider := &MockTransactionProvider{CommitError: expectedError}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{})
+
+	// Execução
+	response, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Equal(t, expectedError, err, "Send deve retornar o erro do commit")
+	assert.Nil(t, response, "Response deve ser nil em caso de erro")
+	assert.True(t, provider.Transactions[0].RolledBack, "A transação deve ser desfeita quando o commit falha")
+}
+
+func TestSendTransactional_RollbackOnPanic(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{Panic: true})
+	ctx := context.Background()
+
+	// Execução e verificações
+	assert.Panics(t, func() {
+		_, _ = mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+	}, "O panic do handler deve ser propagado")
+	assert.True(t, provider.Transactions[0].RolledBack, "A transação deve ser desfeita em caso de panic")
+	assert.Nil(t, uow.FromContext(ctx), "A unidade de trabalho deve ser removida do contexto")
+}
+
+func TestSendTransactional_JoinsAmbientTransaction(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	factory := uow.NewFactory(provider)
+	mediator := NewMediatR(WithUnitOfWork(factory))
+	handler := &MockTransactionalHandler{}
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), handler)
+	ctx := context.Background()
+	ambient := factory.New()
+	assert.NoError(t, ambient.Begin(ctx))
+	ctx = uow.NewContext(ctx, ambient)
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Same(t, ambient, handler.UnitOfWork, "O handler deve participar da transação já aberta")
+	assert.Len(t, provider.Transactions, 1, "Nenhuma nova transação deve ser aberta")
+	assert.False(t, provider.Transactions[0].Committed, "A transação externa não deve ser confirmada pelo Send interno")
+}
+
+func TestSendTransactional_WithoutUnitOfWork(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	handler := &MockTransactionalHandler{}
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), handler)
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Nil(t, handler.UnitOfWork, "Sem fábrica configurada a requisição não deve abrir transação")
+}
+
+// MockEvent implementa a interface DomainEvent para testes
+type MockEvent struct {
+	Data string `json:"data"`
+}
+
+func (e MockEvent) EventName() string {
+	return "mock.happened"
+}
+
+// MockOutboxStore registra as mensagens gravadas no outbox e as marcadas como tratadas
+type MockOutboxStore struct {
+	Messages []*outbox.Message
+	Handled  []uuid.UUID
+}
+
+func (s *MockOutboxStore) Add(message *outbox.Message) error {
+	s.Messages = append(s.Messages, message)
+	return nil
+}
+
+func (s *MockOutboxStore) Claim(now time.Time, lease time.Duration, limit int) ([]outbox.Message, error) {
+	return nil, nil
+}
+
+func (s *MockOutboxStore) MarkHandled(id uuid.UUID, handledAt time.Time) error {
+	s.Handled = append(s.Handled, id)
+	return nil
+}
+
+func (s *MockOutboxStore) MarkDelivered(id uuid.UUID, deliveredAt time.Time) error {
+	return nil
+}
+
+func (s *MockOutboxStore) MarkFailed(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return nil
+}
+
+func newOutboxMediator(provider *MockTransactionProvider, store *MockOutboxStore) Mediator {
+	factory := uow.NewFactory(provider)
+	uow.AddRepository[outbox.Store](factory, func(tx uow.Transaction) outbox.Store { return store })
+	return NewMediatR(WithUnitOfWork(factory), WithOutbox(store))
+}
+
+func TestSendTransactional_WritesEventsToOutbox(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockOutboxStore{}
+	mediator := newOutboxMediator(provider, store)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{Events: []core.DomainEvent{MockEvent{Data: "a"}, MockEvent{Data: "b"}}})
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, store.Messages, 2, "Os eventos devem ser gravados no outbox")
+	assert.Equal(t, "mock.happened", store.Messages[0].EventName)
+	assert.JSONEq(t, `{"data":"a"}`, string(store.Messages[0].Payload), "O payload deve conter o evento serializado")
+	assert.True(t, provider.Transactions[0].Committed, "A transação deve ser confirmada após gravar os eventos")
+	assert.Empty(t, PendingEvents(ctx), "Os eventos devem ser removidos do contexto")
+}
+
+func TestSendTransactional_DiscardsEventsOnError(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockOutboxStore{}
+	mediator := newOutboxMediator(provider, store)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{
+		Events:      []core.DomainEvent{MockEvent{Data: "a"}},
+		ReturnError: errors.New("handler error"),
+	})
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Error(t, err)
+	assert.Empty(t, store.Messages, "Eventos de um handler com erro não devem ser gravados")
+	assert.True(t, provider.Transactions[0].RolledBack)
+	assert.Empty(t, PendingEvents(ctx), "Os eventos devem ser descartados")
+}
+
+func TestSendTransactional_RollbackWhenOutboxIsMissing(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{Events: []core.DomainEvent{MockEvent{Data: "a"}}})
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Error(t, err, "Send deve falhar quando não há outbox registrado na unidade de trabalho")
+	assert.True(t, provider.Transactions[0].RolledBack, "A transação deve ser desfeita")
+}
+
+// MockEventHandler registra um evento sem ser transacional
+type MockEventHandler struct{}
+
+func (h *MockEventHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	AddEvent(ctx, MockEvent{Data: "a"})
+	return MockResponse{Result: "success"}, nil
+}
+
+func TestSend_EventsOutsideTransaction(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockEventHandler{})
+	var received []MockEvent
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		received = append(received, notification)
+		return nil
+	}))
+	ctx := context.Background()
+
+	// Execução
+	response, err := mediator.Send(ctx, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err, "Eventos registrados fora de uma transação não devem falhar a requisição já executada")
+	assert.Equal(t, MockResponse{Result: "success"}, response)
+	assert.Equal(t, []MockEvent{{Data: "a"}}, received, "Sem transação os eventos devem ser publicados diretamente")
+}
+
+func TestSend_EventsOutsideTransaction_PublishError(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockEventHandler{})
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		return errors.New("subscriber error")
+	}))
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockRequest{Data: "test"})
+
+	// Verificações
+	assert.EqualError(t, err, "subscriber error", "Sem outbox, as falhas na publicação devem ser retornadas ao chamador")
+}
+
+// MockAuditStore registra as entradas de auditoria gravadas
+type MockAuditStore struct {
+	Entries []audit.Entry
+}
+
+func (s *MockAuditStore) Append(entry *audit.Entry) error {
+	s.Entries = append(s.Entries, *entry)
+	return nil
+}
+
+func (s *MockAuditStore) Find(filter audit.Filter) ([]audit.Entry, error) {
+	return s.Entries, nil
+}
+
+func TestSend_AuditsCommands(t *testing.T) {
+	// Configuração
+	store := &MockAuditStore{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(&MockTransactionProvider{})), WithAuditTrail(store))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{})
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockHandler{})
+	ctx := context.Background()
+	ctx = security.WithPrincipal(ctx, security.Principal{Subject: "user-1"})
+	ctx = core.WithCorrelationID(ctx, "corr-1")
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+	_, _ = mediator.Send(ctx, MockRequest{Data: "query"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, store.Entries, 1, "Apenas comandos que alteram estado devem ser auditados")
+	entry := store.Entries[0]
+	assert.Equal(t, "MockTransactionalRequest", entry.RequestType, "O tipo deve ser o nome da requisição")
+	assert.Equal(t, "user-1", entry.Actor, "O ator deve ser o usuário autenticado")
+	assert.Equal(t, "corr-1", entry.CorrelationID, "O ID de correlação deve ser registrado")
+	assert.Equal(t, audit.ResultSuccess, entry.Result)
+	assert.JSONEq(t, `{"Data":"test"}`, string(entry.Payload))
+}
+
+func TestSend_AuditsFailures(t *testing.T) {
+	// Configuração
+	store := &MockAuditStore{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(&MockTransactionProvider{})), WithAuditTrail(store))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{ReturnError: errors.New("handler error")})
+	ctx := context.Background()
+
+	// Execução
+	_, err := mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Error(t, err)
+	assert.Len(t, store.Entries, 1, "Comandos com erro também devem ser auditados")
+	assert.Equal(t, audit.ResultFailure, store.Entries[0].Result)
+	assert.Equal(t, "handler error", store.Entries[0].Error)
+	assert.Equal(t, audit.Anonymous, store.Entries[0].Actor, "Comandos sem usuário autenticado devem ser atribuídos ao anônimo")
+}
+
+func TestSend_AuditsPanics(t *testing.T) {
+	// Configuração
+	store := &MockAuditStore{}
+	mediator := NewMediatR(WithAuditTrail(store))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{Panic: true})
+	ctx := context.Background()
+
+	// Execução e verificações
+	assert.Panics(t, func() {
+		_, _ = mediator.Send(ctx, MockTransactionalRequest{Data: "test"})
+	}, "O panic do handler deve ser propagado")
+	assert.Len(t, store.Entries, 1, "Comandos que entram em panic devem ser auditados")
+	assert.Equal(t, audit.ResultFailure, store.Entries[0].Result)
+}
+
+func TestAddEvent_RequiresEventContext(t *testing.T) {
+	// Configuração
+	ctx := context.Background()
+	eventContext := NewEventContext(ctx)
+
+	// Execução
+	AddEvent(ctx, MockEvent{Data: "ignored"})
+	AddEvent(eventContext, MockEvent{Data: "a"})
+
+	// Verificações
+	assert.Empty(t, PendingEvents(ctx), "Sem contexto de eventos o evento deve ser descartado")
+	assert.Equal(t, []core.DomainEvent{MockEvent{Data: "a"}}, PendingEvents(eventContext), "O contexto de eventos deve acumular os eventos registrados")
+}
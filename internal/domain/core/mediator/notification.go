@@ -0,0 +1,92 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// Notification é uma mensagem publicada para zero ou mais handlers, como um evento de domínio
+type Notification interface{}
+
+// NotificationHandler interface para manipuladores de notificações
+type NotificationHandler interface {
+	Handle(ctx context.Context, notification Notification) error
+}
+
+// Subscriber é o handler tipado das notificações do tipo TNotification, registrado com a função Subscribe
+type Subscriber[TNotification Notification] interface {
+	Handle(ctx context.Context, notification TNotification) error
+}
+
+// SubscriberFunc permite usar uma função como Subscriber
+type SubscriberFunc[TNotification Notification] func(ctx context.Context, notification TNotification) error
+
+func (f SubscriberFunc[TNotification]) Handle(ctx context.Context, notification TNotification) error {
+	return f(ctx, notification)
+}
+
+// typedSubscriber adapta um Subscriber à interface NotificationHandler
+type typedSubscriber[TNotification Notification] struct {
+	inner Subscriber[TNotification]
+}
+
+func (s typedSubscriber[TNotification]) Handle(ctx context.Context, notification Notification) error {
+	typed, ok := notification.(TNotification)
+	if !ok {
+		return fmt.Errorf("mediator: notification %T is not %s", notification, reflect.TypeFor[TNotification]())
+	}
+	return s.inner.Handle(ctx, typed)
+}
+
+// Subscribe registra um handler para as notificações do tipo TNotification
+func Subscribe[TNotification Notification](m Registry, subscriber Subscriber[TNotification]) {
+	m.Subscribe(reflect.TypeFor[TNotification](), typedSubscriber[TNotification]{inner: subscriber})
+}
+
+// PublishStrategy define como uma notificação é entregue aos seus handlers
+type PublishStrategy func(ctx context.Context, notification Notification, handlers []NotificationHandler) error
+
+// SequentialStopOnError entrega a notificação um handler por vez, na ordem de registro, parando no primeiro erro
+func SequentialStopOnError(ctx context.Context, notification Notification, handlers []NotificationHandler) error {
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, notification); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SequentialContinueOnError entrega a notificação a todos os handlers, na ordem de registro, e retorna os erros agregados
+func SequentialContinueOnError(ctx context.Context, notification Notification, handlers []NotificationHandler) error {
+	var errs []error
+	for _, handler := range handlers {
+		if err := handler.Handle(ctx, notification); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Parallel entrega a notificação a todos os handlers ao mesmo tempo, aguarda todos e retorna os erros agregados.
+// Um panic em um handler é convertido em erro, já que não pode ser recuperado por quem publicou.
+func Parallel(ctx context.Context, notification Notification, handlers []NotificationHandler) error {
+	errs := make([]error, len(handlers))
+	var wg sync.WaitGroup
+	for i, handler := range handlers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					errs[i] = fmt.Errorf("panic: %v", recovered)
+				}
+			}()
+			errs[i] = handler.Handle(ctx, notification)
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
@@ -0,0 +1,252 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/uow"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// callLog registra as chamadas feitas pelos handlers, inclusive em paralelo
+type callLog struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (l *callLog) add(call string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.calls = append(l.calls, call)
+}
+
+// recordingSubscriber registra as notificações recebidas e retorna o erro configurado
+type recordingSubscriber struct {
+	name        string
+	log         *callLog
+	ReturnError error
+}
+
+func (s *recordingSubscriber) Handle(ctx context.Context, notification MockEvent) error {
+	s.log.add(s.name + ":" + notification.Data)
+	return s.ReturnError
+}
+
+func TestPublish_WithoutHandlers(t *testing.T) {
+	// Execução
+	err := NewMediatR().Publish(context.Background(), MockEvent{Data: "a"})
+
+	// Verificações
+	assert.NoError(t, err, "Publicar sem handlers não deve ser um erro")
+}
+
+func TestPublish_SequentialStopOnError(t *testing.T) {
+	// Configuração
+	calls := &callLog{}
+	expectedError := errors.New("subscriber error")
+	mediator := NewMediatR()
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "first", log: calls})
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "second", log: calls, ReturnError: expectedError})
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "third", log: calls})
+
+	// Execução
+	err := mediator.Publish(context.Background(), MockEvent{Data: "a"})
+
+	// Verificações
+	assert.Equal(t, expectedError, err)
+	assert.Equal(t, []string{"first:a", "second:a"}, calls.calls, "A entrega deve parar no primeiro erro")
+}
+
+func TestPublish_SequentialContinueOnError(t *testing.T) {
+	// Configuração
+	calls := &callLog{}
+	firstError := errors.New("first error")
+	thirdError := errors.New("third error")
+	mediator := NewMediatR(WithPublishStrategy(SequentialContinueOnError))
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "first", log: calls, ReturnError: firstError})
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "second", log: calls})
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "third", log: calls, ReturnError: thirdError})
+
+	// Execução
+	err := mediator.Publish(context.Background(), MockEvent{Data: "a"})
+
+	// Verificações
+	assert.ErrorIs(t, err, firstError, "Os erros devem ser agregados")
+	assert.ErrorIs(t, err, thirdError, "Os erros devem ser agregados")
+	assert.Equal(t, []string{"first:a", "second:a", "third:a"}, calls.calls, "Todos os handlers devem ser executados")
+}
+
+func TestPublish_Parallel(t *testing.T) {
+	// Configuração
+	calls := &callLog{}
+	expectedError := errors.New("subscriber error")
+	mediator := NewMediatR(WithPublishStrategy(Parallel))
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "first", log: calls, ReturnError: expectedError})
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "second", log: calls})
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		panic("subscriber panic")
+	}))
+
+	// Execução
+	err := mediator.Publish(context.Background(), MockEvent{Data: "a"})
+
+	// Verificações
+	assert.ErrorIs(t, err, expectedError, "Os erros devem ser agregados")
+	assert.Contains(t, err.Error(), "panic: subscriber panic", "O panic de um handler deve ser convertido em erro")
+	assert.ElementsMatch(t, []string{"first:a", "second:a"}, calls.calls, "Todos os handlers devem ser executados")
+}
+
+func TestPublish_OnlyMatchingType(t *testing.T) {
+	// Configuração
+	calls := &callLog{}
+	mediator := NewMediatR()
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "first", log: calls})
+
+	// Execução
+	err := mediator.Publish(context.Background(), &MockEvent{Data: "a"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, calls.calls, "Handlers de outro tipo de notificação não devem ser executados")
+}
+
+// MockAggregate é um agregado que registra eventos de domínio
+type MockAggregate struct {
+	core.AggregateRoot
+}
+
+// MockAggregateHandler retorna um agregado com os eventos configurados
+type MockAggregateHandler struct {
+	Events      []core.DomainEvent
+	ReturnError error
+}
+
+func (h *MockAggregateHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	aggregate := &MockAggregate{}
+	for _, event := range h.Events {
+		aggregate.RecordEvent(event)
+	}
+	if h.ReturnError != nil {
+		return nil, h.ReturnError
+	}
+	return aggregate, nil
+}
+
+func TestPublishMessage(t *testing.T) {
+	// Configuração
+	calls := &callLog{}
+	mediator := NewMediatR()
+	Subscribe[MockEvent](mediator, &recordingSubscriber{name: "first", log: calls})
+	message, _ := outbox.NewMessage(MockEvent{Data: "a"})
+	unknown := outbox.Message{EventName: "unknown.happened", Payload: []byte(`{}`)}
+	invalid := outbox.Message{EventName: MockEvent{}.EventName(), Payload: []byte(`{"data":1}`)}
+
+	// Execução
+	err := mediator.PublishMessage(context.Background(), *message)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first:a"}, calls.calls, "O evento gravado no outbox deve ser decodificado e publicado")
+	assert.NoError(t, mediator.PublishMessage(context.Background(), unknown), "Eventos sem handlers devem ser ignorados")
+	assert.ErrorIs(t, mediator.PublishMessage(context.Background(), invalid), ErrInvalidEventPayload, "Payloads inválidos devem retornar erro")
+}
+
+func TestSend_DispatchesAggregateEventsAfterCommit(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockOutboxStore{}
+	mediator := newOutboxMediator(provider, store)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockAggregateHandler{Events: []core.DomainEvent{MockEvent{Data: "a"}}})
+	var received []MockEvent
+	var committedBeforePublish bool
+	var transactionOnPublish uow.UnitOfWork
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		received = append(received, notification)
+		committedBeforePublish = provider.Transactions[0].Committed
+		transactionOnPublish = uow.FromContext(ctx)
+		return errors.New("subscriber error")
+	}))
+
+	// Execução
+	response, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err, "Falhas dos handlers de notificação não devem desfazer o comando já confirmado")
+	assert.Empty(t, response.(*MockAggregate).Events(), "Os eventos devem ser retirados do agregado")
+	assert.Len(t, store.Messages, 1, "Os eventos do agregado devem ser gravados no outbox")
+	assert.Equal(t, []MockEvent{{Data: "a"}}, received, "Os eventos do agregado devem ser publicados")
+	assert.True(t, committedBeforePublish, "Os eventos devem ser publicados após o commit")
+	assert.Nil(t, transactionOnPublish, "Os handlers de notificação não devem participar da transação confirmada")
+	assert.Empty(t, store.Handled, "Eventos com falha na publicação devem ficar para o relay do outbox")
+}
+
+func TestSend_MarksPublishedEventsAsHandled(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockOutboxStore{}
+	mediator := newOutboxMediator(provider, store)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockAggregateHandler{Events: []core.DomainEvent{MockEvent{Data: "a"}, MockEvent{Data: "b"}}})
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		if notification.Data == "b" {
+			return errors.New("subscriber error")
+		}
+		return nil
+	}))
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, store.Messages, 2)
+	assert.Equal(t, []uuid.UUID{store.Messages[0].ID}, store.Handled, "Apenas os eventos entregues aos handlers devem ser marcados como tratados")
+}
+
+func TestSend_DoesNotDispatchEventsOnError(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	store := &MockOutboxStore{}
+	mediator := newOutboxMediator(provider, store)
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockAggregateHandler{
+		Events:      []core.DomainEvent{MockEvent{Data: "a"}},
+		ReturnError: errors.New("handler error"),
+	})
+	var received []MockEvent
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		received = append(received, notification)
+		return nil
+	}))
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.Error(t, err)
+	assert.Empty(t, store.Messages)
+	assert.Empty(t, received, "Eventos de um comando com erro não devem ser publicados")
+}
+
+func TestSend_AggregateEventsOutsideTransaction(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockAggregateHandler{Events: []core.DomainEvent{MockEvent{Data: "a"}}})
+	var received []MockEvent
+	Subscribe[MockEvent](mediator, SubscriberFunc[MockEvent](func(ctx context.Context, notification MockEvent) error {
+		received = append(received, notification)
+		return nil
+	}))
+
+	// Execução
+	response, err := mediator.Send(context.Background(), MockTransactionalRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, response.(*MockAggregate).Events(), "Os eventos devem ser retirados do agregado")
+	assert.Equal(t, []MockEvent{{Data: "a"}}, received, "Sem unidade de trabalho configurada os eventos do agregado devem ser publicados diretamente")
+}
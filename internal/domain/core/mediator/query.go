@@ -0,0 +1,60 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/uow"
+	"fmt"
+	"reflect"
+)
+
+var (
+	// ErrSideEffectInQuery é retornado quando uma consulta tenta enviar um comando ou enfileirar um job
+	ErrSideEffectInQuery = errors.New("queries cannot send commands or enqueue jobs")
+	// ErrAmbiguousRequest indica um tipo de requisição que é ao mesmo tempo comando e consulta
+	ErrAmbiguousRequest = errors.New("request cannot be both a command and a query")
+)
+
+// readOnlyKey marca o contexto das consultas
+type readOnlyKey struct{}
+
+// Query interface para requisições de leitura. Consultas são executadas sem transação
+// e não podem enviar comandos, enfileirar jobs nem registrar eventos de domínio.
+type Query interface {
+	Request
+	ReadOnly() bool
+}
+
+// IsReadOnly indica se o contexto pertence à execução de uma consulta
+func IsReadOnly(ctx context.Context) bool {
+	readOnly, _ := ctx.Value(readOnlyKey{}).(bool)
+	return readOnly
+}
+
+// readOnlyBehavior é o primeiro passo do pipeline: executa as consultas fora de qualquer transação
+// e rejeita comandos enviados de dentro de uma consulta
+type readOnlyBehavior struct{}
+
+func (b readOnlyBehavior) Handle(ctx context.Context, request Request, next Next) (Response, error) {
+	if isCommand(request) && IsReadOnly(ctx) {
+		return nil, fmt.Errorf("%w: %s", ErrSideEffectInQuery, reflect.TypeOf(request))
+	}
+	if !isQuery(request) {
+		return next(ctx)
+	}
+	// Uma consulta enviada de dentro de um comando não participa da transação do comando
+	ctx = uow.NewContext(ctx, nil)
+	return next(context.WithValue(ctx, readOnlyKey{}, true))
+}
+
+// isQuery indica se a requisição é uma consulta
+func isQuery(request Request) bool {
+	query, ok := request.(Query)
+	return ok && query.ReadOnly()
+}
+
+// isAmbiguous indica se o tipo de requisição se declara comando e consulta ao mesmo tempo
+func isAmbiguous(requestType reflect.Type) bool {
+	request := reflect.Zero(requestType).Interface()
+	return isQuery(request) && isCommand(request)
+}
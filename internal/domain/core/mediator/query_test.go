@@ -0,0 +1,140 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/uow"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockQuery implementa a interface Query para testes
+type MockQuery struct {
+	ID string
+}
+
+func (q MockQuery) ReadOnly() bool {
+	return true
+}
+
+// MockAmbiguousRequest se declara comando e consulta ao mesmo tempo
+type MockAmbiguousRequest struct{}
+
+func (r MockAmbiguousRequest) ReadOnly() bool {
+	return true
+}
+
+func (r MockAmbiguousRequest) Transactional() bool {
+	return true
+}
+
+// MockQueryHandler registra o contexto recebido e executa a ação informada
+type MockQueryHandler struct {
+	UnitOfWork uow.UnitOfWork
+	ReadOnly   bool
+	Action     func(ctx context.Context) error
+}
+
+func (h *MockQueryHandler) Handle(ctx context.Context, request Request) (Response, error) {
+	h.UnitOfWork = uow.FromContext(ctx)
+	h.ReadOnly = IsReadOnly(ctx)
+	if h.Action != nil {
+		if err := h.Action(ctx); err != nil {
+			return nil, err
+		}
+	}
+	return MockResponse{Result: "query"}, nil
+}
+
+func TestSendQuery_RunsWithoutTransaction(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	queryHandler := &MockQueryHandler{}
+	mediator.Register(reflect.TypeFor[MockQuery](), queryHandler)
+
+	// Execução
+	response, err := mediator.Send(context.Background(), MockQuery{ID: "1"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, MockResponse{Result: "query"}, response)
+	assert.Empty(t, provider.Transactions, "Consultas não devem abrir transações")
+	assert.True(t, queryHandler.ReadOnly, "O handler deve receber um contexto somente leitura")
+}
+
+func TestSendQuery_InsideCommand(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(provider)))
+	queryHandler := &MockQueryHandler{}
+	mediator.Register(reflect.TypeFor[MockQuery](), queryHandler)
+	commandHandler := &MockQueryHandler{Action: func(ctx context.Context) error {
+		_, err := mediator.Send(ctx, MockQuery{ID: "1"})
+		return err
+	}}
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), commandHandler)
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockTransactionalRequest{})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NotNil(t, commandHandler.UnitOfWork, "O comando deve ser executado em uma transação")
+	assert.False(t, commandHandler.ReadOnly, "O contexto do comando não é somente leitura")
+	assert.Nil(t, queryHandler.UnitOfWork, "A consulta não deve participar da transação do comando")
+	assert.True(t, queryHandler.ReadOnly)
+}
+
+func TestSendQuery_RejectsSideEffects(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR(WithUnitOfWork(uow.NewFactory(&MockTransactionProvider{})), WithJobQueue(&MockJobQueue{}))
+	mediator.Register(reflect.TypeFor[MockTransactionalRequest](), &MockTransactionalHandler{})
+	mediator.Register(reflect.TypeFor[MockRequest](), &MockHandler{})
+	actions := map[string]func(ctx context.Context) error{
+		"enviar um comando": func(ctx context.Context) error {
+			_, err := mediator.Send(ctx, MockTransactionalRequest{})
+			return err
+		},
+		"enfileirar um job": func(ctx context.Context) error {
+			_, err := mediator.Enqueue(ctx, MockRequest{})
+			return err
+		},
+	}
+
+	for name, action := range actions {
+		queryHandler := &MockQueryHandler{Action: action}
+		mediator.Register(reflect.TypeFor[MockQuery](), queryHandler)
+
+		// Execução
+		_, err := mediator.Send(context.Background(), MockQuery{})
+
+		// Verificações
+		assert.True(t, errors.Is(err, ErrSideEffectInQuery), "Uma consulta não deve %s", name)
+	}
+
+	// Eventos de domínio também não podem ser registrados por consultas
+	mediator.Register(reflect.TypeFor[MockQuery](), &MockQueryHandler{Action: func(ctx context.Context) error {
+		AddEvent(ctx, MockEvent{})
+		return nil
+	}})
+	_, err := mediator.Send(context.Background(), MockQuery{})
+	assert.Equal(t, ErrEventsOutsideTransaction, err, "Uma consulta não deve registrar eventos")
+}
+
+func TestValidate_AmbiguousRequest(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	mediator.Register(reflect.TypeFor[MockAmbiguousRequest](), &MockHandler{})
+	mediator.Register(reflect.TypeFor[MockQuery](), &MockHandler{})
+
+	// Execução
+	err := mediator.Validate()
+
+	// Verificações
+	assert.True(t, errors.Is(err, ErrAmbiguousRequest), "Requisições que são comando e consulta devem ser rejeitadas")
+	assert.Contains(t, err.Error(), "MockAmbiguousRequest")
+	assert.NotContains(t, err.Error(), "MockQuery", "Consultas válidas não devem ser reportadas")
+}
@@ -0,0 +1,63 @@
+package mediator
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+)
+
+// RequestHandler é o handler tipado de TReq, registrado com a função Register
+type RequestHandler[TReq Request, TResp Response] interface {
+	Handle(ctx context.Context, request TReq) (TResp, error)
+}
+
+// RequestHandlerFunc permite usar uma função como RequestHandler
+type RequestHandlerFunc[TReq Request, TResp Response] func(ctx context.Context, request TReq) (TResp, error)
+
+func (f RequestHandlerFunc[TReq, TResp]) Handle(ctx context.Context, request TReq) (TResp, error) {
+	return f(ctx, request)
+}
+
+// typedHandler adapta um RequestHandler à interface Handler usada pelo pipeline
+type typedHandler[TReq Request, TResp Response] struct {
+	inner RequestHandler[TReq, TResp]
+}
+
+func (h typedHandler[TReq, TResp]) Handle(ctx context.Context, request Request) (Response, error) {
+	typed, ok := request.(TReq)
+	if !ok {
+		return nil, fmt.Errorf("mediator: request %T is not %s", request, reflect.TypeFor[TReq]())
+	}
+	response, err := h.inner.Handle(ctx, typed)
+	if err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// responseType informa o tipo da resposta, usado para reconstruir respostas guardadas
+func (h typedHandler[TReq, TResp]) responseType() reflect.Type {
+	return reflect.TypeFor[TResp]()
+}
+
+// Register registra o handler das requisições do tipo TReq, usando o próprio tipo como chave
+func Register[TReq Request, TResp Response](m Registry, handler RequestHandler[TReq, TResp]) {
+	m.Register(reflect.TypeFor[TReq](), typedHandler[TReq, TResp]{inner: handler})
+}
+
+// Send envia a requisição pelo mediator e retorna a resposta já convertida para TResp
+func Send[TResp Response](ctx context.Context, m Sender, request Request) (TResp, error) {
+	var zero TResp
+	response, err := m.Send(ctx, request)
+	if err != nil {
+		return zero, err
+	}
+	if response == nil {
+		return zero, nil
+	}
+	typed, ok := response.(TResp)
+	if !ok {
+		return zero, fmt.Errorf("mediator: response %T is not %s", response, reflect.TypeFor[TResp]())
+	}
+	return typed, nil
+}
@@ -0,0 +1,100 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// mockTypedHandler responde à MockRequest com uma MockResponse tipada
+type mockTypedHandler struct{}
+
+func (h *mockTypedHandler) Handle(ctx context.Context, request MockRequest) (MockResponse, error) {
+	return MockResponse{Result: "typed " + request.Data}, nil
+}
+
+func TestRegisterAndSend_Typed(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	Register[MockRequest, MockResponse](mediator, &mockTypedHandler{})
+
+	// Execução
+	response, err := Send[MockResponse](context.Background(), mediator, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, "typed test", response.Result, "O handler tipado deve receber a requisição já convertida")
+	assert.NoError(t, mediator.Validate(reflect.TypeFor[MockRequest]()))
+}
+
+func TestSend_TypedUnexpectedResponse(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	Register[MockRequest, MockResponse](mediator, &mockTypedHandler{})
+
+	// Execução
+	response, err := Send[*MockResponse](context.Background(), mediator, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.Error(t, err, "Uma resposta de outro tipo deve resultar em erro")
+	assert.Nil(t, response)
+}
+
+func TestSend_TypedHandlerError(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	expectedError := errors.New("handler error")
+	Register[MockRequest, *MockResponse](mediator, RequestHandlerFunc[MockRequest, *MockResponse](func(ctx context.Context, request MockRequest) (*MockResponse, error) {
+		return nil, expectedError
+	}))
+
+	// Execução
+	response, err := Send[*MockResponse](context.Background(), mediator, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, response)
+}
+
+func TestSend_SameNameInDifferentScopes(t *testing.T) {
+	// Configuração
+	type MockRequest struct {
+		Data string
+	}
+	mediator := NewMediatR()
+	Register[MockRequest, MockResponse](mediator, RequestHandlerFunc[MockRequest, MockResponse](func(ctx context.Context, request MockRequest) (MockResponse, error) {
+		return MockResponse{Result: "local"}, nil
+	}))
+
+	// Execução
+	_, err := mediator.Send(context.Background(), MockRequestForNameTest())
+	response, localErr := Send[MockResponse](context.Background(), mediator, MockRequest{Data: "test"})
+
+	// Verificações
+	assert.ErrorIs(t, err, ErrHandlerNotFound, "Tipos com o mesmo nome não devem compartilhar o handler")
+	assert.NoError(t, localErr)
+	assert.Equal(t, "local", response.Result)
+}
+
+// MockRequestForNameTest retorna a MockRequest declarada no pacote, homônima da declarada no teste
+func MockRequestForNameTest() Request {
+	return MockRequest{Data: "test"}
+}
+
+func TestValidate(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR()
+	Register[MockRequest, MockResponse](mediator, &mockTypedHandler{})
+	Register[MockRequest, MockResponse](mediator, &mockTypedHandler{})
+
+	// Execução
+	err := mediator.Validate(reflect.TypeFor[MockRequest](), reflect.TypeFor[MockTransactionalRequest]())
+
+	// Verificações
+	assert.ErrorIs(t, err, ErrHandlerNotFound, "Tipos sem handler devem ser reportados")
+	assert.ErrorIs(t, err, ErrDuplicateHandler, "Tipos com mais de um handler devem ser reportados")
+	assert.Contains(t, err.Error(), "MockTransactionalRequest")
+}
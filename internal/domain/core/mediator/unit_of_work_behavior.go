@@ -0,0 +1,68 @@
+package mediator
+
+import (
+	"context"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/uow"
+	"log"
+	"time"
+)
+
+// unitOfWorkBehavior executa as requisições transacionais em uma unidade de trabalho, desfazendo-a em caso de erro ou panic.
+// Os eventos registrados com AddEvent são gravados no outbox antes do commit.
+// Após o commit, os eventos também são publicados aos handlers de notificação e, quando o outbox foi configurado
+// com WithOutbox, as mensagens entregues são marcadas como tratadas. A transação já foi confirmada, então uma
+// falha nessa etapa é registrada no log e as mensagens não marcadas são entregues de novo aos handlers pelo relay
+// do outbox (veja PublishMessage); por isso os handlers devem ser idempotentes.
+// Requisições enviadas de dentro de uma transação participam da transação já aberta.
+type unitOfWorkBehavior struct {
+	factory     uow.Factory
+	outboxStore outbox.Store
+	publish     func(ctx context.Context, notification Notification) error
+}
+
+func (b *unitOfWorkBehavior) Handle(ctx context.Context, request Request, next Next) (response Response, err error) {
+	if !isCommand(request) || uow.FromContext(ctx) != nil {
+		return next(ctx)
+	}
+
+	unitOfWork := b.factory.New()
+	if err = unitOfWork.Begin(ctx); err != nil {
+		return nil, err
+	}
+	parent := ctx
+	ctx = NewEventContext(uow.NewContext(ctx, unitOfWork))
+
+	committed := false
+	defer func() {
+		if !committed {
+			_ = unitOfWork.Rollback()
+		}
+	}()
+
+	response, err = next(ctx)
+	if err != nil {
+		return nil, err
+	}
+	events := PendingEvents(ctx)
+	messages, err := writeEvents(unitOfWork, events)
+	if err != nil {
+		return nil, err
+	}
+	if err = unitOfWork.Commit(); err != nil {
+		return nil, err
+	}
+	committed = true
+	for i, event := range events {
+		if publishErr := b.publish(parent, event); publishErr != nil {
+			log.Printf("mediator: failed to publish %s after commit, the outbox relay will retry: %v", event.EventName(), publishErr)
+			continue
+		}
+		if b.outboxStore != nil {
+			if markErr := b.outboxStore.MarkHandled(messages[i].ID, time.Now()); markErr != nil {
+				log.Printf("mediator: failed to mark %s as handled, the outbox relay will publish it again: %v", event.EventName(), markErr)
+			}
+		}
+	}
+	return response, nil
+}
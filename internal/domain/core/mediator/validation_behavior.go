@@ -0,0 +1,131 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Validatable é implementado pelas requisições com regras que não podem ser expressas nas tags `validate`.
+// Validate pode retornar core.Violations para apontar os campos inválidos.
+type Validatable interface {
+	Validate() error
+}
+
+// ValidationBehavior valida a requisição antes do handler, usando as tags `validate` (go-playground/validator)
+// e o método Validate, quando existir. Requisições inválidas resultam em core.ErrValidation com as violações.
+func ValidationBehavior() Behavior {
+	validate := validator.New(validator.WithRequiredStructEnabled())
+	validate.RegisterTagNameFunc(jsonFieldName)
+	return BehaviorFunc(func(ctx context.Context, request Request, next Next) (Response, error) {
+		violations, err := validateRequest(validate, request)
+		if err != nil {
+			return nil, err
+		}
+		if len(violations) > 0 {
+			return nil, core.ErrValidation(violations)
+		}
+		return next(ctx)
+	})
+}
+
+// validateRequest reúne as violações das tags e do método Validate
+func validateRequest(validate *validator.Validate, request Request) (core.Violations, error) {
+	var violations core.Violations
+	if isStruct(request) {
+		if err := validate.Struct(request); err != nil {
+			var fieldErrors validator.ValidationErrors
+			if !errors.As(err, &fieldErrors) {
+				return nil, err
+			}
+			for _, fieldError := range fieldErrors {
+				violations = append(violations, core.Violation{
+					Field:   fieldPath(fieldError),
+					Rule:    fieldError.Tag(),
+					Message: violationMessage(fieldError),
+				})
+			}
+		}
+	}
+	if validatable, ok := request.(Validatable); ok {
+		if err := validatable.Validate(); err != nil {
+			var requestViolations core.Violations
+			if errors.As(err, &requestViolations) {
+				violations = append(violations, requestViolations...)
+			} else {
+				violations = append(violations, core.Violation{Rule: "validate", Message: err.Error()})
+			}
+		}
+	}
+	return violations, nil
+}
+
+func isStruct(request Request) bool {
+	t := reflect.TypeOf(request)
+	if t != nil && t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t != nil && t.Kind() == reflect.Struct
+}
+
+// jsonFieldName usa o nome do campo no JSON para que as violações correspondam ao corpo da requisição
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	switch name {
+	case "-":
+		return ""
+	case "":
+		return field.Name
+	}
+	return name
+}
+
+// fieldPath remove o nome do tipo da requisição do caminho do campo (ex.: CreateUserCommand.address.street)
+func fieldPath(fieldError validator.FieldError) string {
+	namespace := fieldError.Namespace()
+	if index := strings.Index(namespace, "."); index >= 0 {
+		return namespace[index+1:]
+	}
+	return namespace
+}
+
+// violationMessage descreve a regra violada
+func violationMessage(fieldError validator.FieldError) string {
+	param := fieldError.Param()
+	isText := fieldError.Kind() == reflect.String
+	switch fieldError.Tag() {
+	case "required":
+		return "é obrigatório"
+	case "email":
+		return "deve ser um email válido"
+	case "uuid", "uuid4":
+		return "deve ser um UUID válido"
+	case "oneof":
+		return "deve ser um dos valores: " + strings.Join(strings.Fields(param), ", ")
+	case "min", "gte":
+		if isText {
+			return fmt.Sprintf("deve ter no mínimo %s caracteres", param)
+		}
+		return "deve ser no mínimo " + param
+	case "max", "lte":
+		if isText {
+			return fmt.Sprintf("deve ter no máximo %s caracteres", param)
+		}
+		return "deve ser no máximo " + param
+	case "len":
+		if isText {
+			return fmt.Sprintf("deve ter %s caracteres", param)
+		}
+		return "deve ter tamanho " + param
+	case "gt":
+		return "deve ser maior que " + param
+	case "lt":
+		return "deve ser menor que " + param
+	}
+	return "não atende à regra " + fieldError.Tag()
+}
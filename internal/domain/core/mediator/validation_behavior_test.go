@@ -0,0 +1,96 @@
+package mediator
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// ValidatedAddress é um campo aninhado validado por tags
+type ValidatedAddress struct {
+	Street string `json:"street" validate:"required"`
+}
+
+// ValidatedRequest combina regras em tags com regras no método Validate
+type ValidatedRequest struct {
+	Name     string           `json:"name" validate:"required,max=5"`
+	Email    string           `json:"email" validate:"required,email"`
+	Role     string           `json:"role" validate:"omitempty,oneof=admin user"`
+	Address  ValidatedAddress `json:"address"`
+	Password string           `json:"password"`
+	Confirm  string           `json:"confirm"`
+}
+
+func (r ValidatedRequest) Validate() error {
+	if r.Password != r.Confirm {
+		return core.Violations{{Field: "confirm", Rule: "match", Message: "deve ser igual à senha"}}
+	}
+	return nil
+}
+
+// PlainValidatedRequest retorna um erro comum em Validate
+type PlainValidatedRequest struct{}
+
+func (r PlainValidatedRequest) Validate() error {
+	return errors.New("período inválido")
+}
+
+func TestValidationBehavior(t *testing.T) {
+	// Configuração
+	handler := &MockHandler{}
+	mediator := NewMediatR(WithBehavior(ValidationBehavior()))
+	mediator.Register(reflect.TypeFor[ValidatedRequest](), handler)
+
+	// Execução
+	_, err := mediator.Send(context.Background(), ValidatedRequest{Name: "Nome longo", Email: "invalido", Role: "root", Password: "a", Confirm: "b"})
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "A validação deve retornar um DomainError")
+	assert.Equal(t, http.StatusUnprocessableEntity, domainError.StatusCode, "O código de status deve ser 422")
+	assert.Equal(t, core.Violations{
+		{Field: "name", Rule: "max", Message: "deve ter no máximo 5 caracteres"},
+		{Field: "email", Rule: "email", Message: "deve ser um email válido"},
+		{Field: "role", Rule: "oneof", Message: "deve ser um dos valores: admin, user"},
+		{Field: "address.street", Rule: "required", Message: "é obrigatório"},
+		{Field: "confirm", Rule: "match", Message: "deve ser igual à senha"},
+	}, domainError.Violations, "As violações das tags e do método Validate devem ser retornadas com o nome do campo no JSON")
+}
+
+func TestValidationBehavior_ValidRequest(t *testing.T) {
+	// Configuração
+	handler := &MockHandler{ReturnResponse: MockResponse{Result: "ok"}}
+	mediator := NewMediatR(WithBehavior(ValidationBehavior()))
+	mediator.Register(reflect.TypeFor[ValidatedRequest](), handler)
+	mediator.Register(reflect.TypeFor[MockRequest](), handler)
+	valid := ValidatedRequest{Name: "Nome", Email: "nome@example.com", Address: ValidatedAddress{Street: "Rua A"}}
+
+	// Execução
+	response, err := mediator.Send(context.Background(), valid)
+	_, untaggedErr := mediator.Send(context.Background(), MockRequest{})
+
+	// Verificações
+	assert.NoError(t, err, "Requisições válidas devem chegar ao handler")
+	assert.Equal(t, MockResponse{Result: "ok"}, response)
+	assert.NoError(t, untaggedErr, "Requisições sem regras não devem ser rejeitadas")
+}
+
+func TestValidationBehavior_PlainValidateError(t *testing.T) {
+	// Configuração
+	mediator := NewMediatR(WithBehavior(ValidationBehavior()))
+	mediator.Register(reflect.TypeFor[PlainValidatedRequest](), &MockHandler{})
+
+	// Execução
+	_, err := mediator.Send(context.Background(), PlainValidatedRequest{})
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, core.Violations{{Rule: "validate", Message: "período inválido"}}, domainError.Violations,
+		"Erros comuns de Validate devem virar uma violação sem campo")
+}
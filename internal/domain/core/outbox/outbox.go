@@ -0,0 +1,50 @@
+package outbox
+
+import (
+	"encoding/json"
+	"flickly/internal/domain/core"
+	"github.com/google/uuid"
+	"time"
+)
+
+// Message é um evento de domínio aguardando publicação
+type Message struct {
+	ID            uuid.UUID       `json:"id"`
+	EventName     string          `json:"eventName"`
+	Payload       json.RawMessage `json:"payload"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Attempts      int             `json:"attempts"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt"`
+	DeliveredAt   *time.Time      `json:"deliveredAt,omitempty"`
+	LastError     string          `json:"lastError,omitempty"`
+	// HandledAt é quando os handlers de notificação do processo receberam o evento
+	HandledAt *time.Time `json:"handledAt,omitempty"`
+}
+
+// NewMessage serializa o evento de domínio em uma mensagem pronta para publicação
+func NewMessage(event core.DomainEvent) (*Message, error) {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	return &Message{
+		ID:            uuid.New(),
+		EventName:     event.EventName(),
+		Payload:       payload,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}, nil
+}
+
+// Store guarda as mensagens do outbox. Add deve ser chamado na mesma transação da alteração do agregado.
+type Store interface {
+	Add(message *Message) error
+	// Claim reserva e retorna, em ordem de criação, até limit mensagens não entregues cuja próxima tentativa já
+	// venceu, adiando a próxima tentativa para now+lease para que outras instâncias não as entreguem ao mesmo tempo
+	Claim(now time.Time, lease time.Duration, limit int) ([]Message, error)
+	// MarkHandled registra que os handlers de notificação do processo já receberam o evento
+	MarkHandled(id uuid.UUID, handledAt time.Time) error
+	MarkDelivered(id uuid.UUID, deliveredAt time.Time) error
+	MarkFailed(id uuid.UUID, lastError string, nextAttemptAt time.Time) error
+}
@@ -0,0 +1,47 @@
+package outbox
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type sampleEvent struct {
+	Value string `json:"value"`
+}
+
+func (e sampleEvent) EventName() string {
+	return "sample.happened"
+}
+
+func TestNewMessage(t *testing.T) {
+	// Execução
+	message, err := NewMessage(sampleEvent{Value: "test"})
+
+	// Verificações
+	assert.NoError(t, err, "NewMessage não deve retornar erro")
+	assert.NotEqual(t, uuid.Nil, message.ID, "A mensagem deve ter um ID")
+	assert.Equal(t, "sample.happened", message.EventName, "O nome do evento deve ser guardado")
+	assert.JSONEq(t, `{"value":"test"}`, string(message.Payload), "O evento deve ser serializado em JSON")
+	assert.Equal(t, message.CreatedAt, message.NextAttemptAt, "A primeira tentativa deve ser imediata")
+	assert.Nil(t, message.DeliveredAt, "Uma nova mensagem não deve estar entregue")
+}
+
+func TestNewMessage_Error(t *testing.T) {
+	// Execução
+	_, err := NewMessage(invalidEvent{Channel: make(chan int)})
+
+	// Verificações
+	var unsupported *json.UnsupportedTypeError
+	assert.ErrorAs(t, err, &unsupported, "Eventos não serializáveis devem retornar erro")
+}
+
+type invalidEvent struct {
+	Channel chan int
+}
+
+func (e invalidEvent) EventName() string {
+	return "invalid"
+}
@@ -0,0 +1,80 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/mediator"
+	"reflect"
+	"time"
+)
+
+// DefaultTimeout é o prazo usado pelas definições que não informam Timeout
+const DefaultTimeout = time.Hour
+
+// StepFunc executa um passo ou sua compensação. Os dados da saga podem ser alterados e são gravados
+// depois que a função retorna sem erro. Como a saga pode ser retomada após uma falha, as funções
+// devem ser idempotentes.
+type StepFunc[TData any] func(ctx context.Context, m mediator.Sender, data *TData) error
+
+// Step é um passo da saga com a ação que o desfaz; Compensate nil indica que não há o que desfazer
+type Step[TData any] struct {
+	Name       string
+	Execute    StepFunc[TData]
+	Compensate StepFunc[TData]
+}
+
+// Definition descreve uma saga: os passos executados em ordem e o prazo para concluí-los.
+// Se um passo falhar ou o prazo vencer, os passos já concluídos são compensados em ordem inversa.
+type Definition[TData any] struct {
+	Name    string
+	Steps   []Step[TData]
+	Timeout time.Duration
+}
+
+// definition é a visão sem tipo de Definition usada pelo Manager
+type definition interface {
+	dataType() reflect.Type
+	timeout() time.Duration
+	stepCount() int
+	stepName(index int) string
+	run(ctx context.Context, m mediator.Sender, index int, action string, data json.RawMessage) (json.RawMessage, error)
+}
+
+func (d Definition[TData]) dataType() reflect.Type {
+	return reflect.TypeFor[TData]()
+}
+
+func (d Definition[TData]) timeout() time.Duration {
+	if d.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return d.Timeout
+}
+
+func (d Definition[TData]) stepCount() int {
+	return len(d.Steps)
+}
+
+func (d Definition[TData]) stepName(index int) string {
+	return d.Steps[index].Name
+}
+
+// run executa a ação do passo sobre os dados desserializados e retorna os dados atualizados
+func (d Definition[TData]) run(ctx context.Context, m mediator.Sender, index int, action string, data json.RawMessage) (json.RawMessage, error) {
+	step := d.Steps[index]
+	function := step.Execute
+	if action == ActionCompensate {
+		function = step.Compensate
+	}
+	if function == nil {
+		return data, nil
+	}
+	var typed TData
+	if err := json.Unmarshal(data, &typed); err != nil {
+		return nil, err
+	}
+	if err := function(ctx, m, &typed); err != nil {
+		return nil, err
+	}
+	return json.Marshal(typed)
+}
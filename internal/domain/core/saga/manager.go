@@ -0,0 +1,191 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Valores padrão usados pelo Manager
+const (
+	DefaultMaxCompensationAttempts = 5
+	DefaultBaseBackoff             = time.Second
+	DefaultMaxBackoff              = 5 * time.Minute
+)
+
+// Manager inicia as sagas registradas e as conduz passo a passo, gravando o estado a cada passo.
+// Os passos usam o mediator para enviar comandos, em nome do usuário que iniciou a saga.
+type Manager struct {
+	store       Store
+	mediator    mediator.Sender
+	definitions map[string]definition
+	// MaxCompensationAttempts é o número de tentativas de uma compensação antes de a saga falhar
+	MaxCompensationAttempts int
+	BaseBackoff             time.Duration
+	MaxBackoff              time.Duration
+	Now                     func() time.Time
+}
+
+// NewManager cria um gerenciador de sagas com as configurações padrão
+func NewManager(store Store, mediator mediator.Sender) *Manager {
+	return &Manager{
+		store:                   store,
+		mediator:                mediator,
+		definitions:             make(map[string]definition),
+		MaxCompensationAttempts: DefaultMaxCompensationAttempts,
+		BaseBackoff:             DefaultBaseBackoff,
+		MaxBackoff:              DefaultMaxBackoff,
+		Now:                     time.Now,
+	}
+}
+
+// Register registra a definição da saga pelo seu nome
+func Register[TData any](manager *Manager, definition Definition[TData]) {
+	manager.definitions[definition.Name] = definition
+}
+
+// Start grava uma nova saga com os dados informados e retorna o seu ID; os passos são executados
+// em segundo plano pelo executor de sagas. Dentro de uma transação, a saga só é gravada se ela for confirmada.
+func (m *Manager) Start(ctx context.Context, name string, data interface{}) (uuid.UUID, error) {
+	definition, ok := m.definitions[name]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("%w: %s", ErrUnknownSaga, name)
+	}
+	if reflect.TypeOf(data) != definition.dataType() {
+		return uuid.Nil, fmt.Errorf("%w: %s expects %s, got %T", ErrInvalidSagaData, name, definition.dataType(), data)
+	}
+	if mediator.IsReadOnly(ctx) {
+		return uuid.Nil, fmt.Errorf("%w: saga %s", mediator.ErrSideEffectInQuery, name)
+	}
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	state := NewState(name, payload, definition.timeout(), security.PrincipalFromContext(ctx), core.CorrelationIDFromContext(ctx))
+	store, err := uow.ResolveRepository(ctx, m.store)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	if err = store.Create(state); err != nil {
+		return uuid.Nil, err
+	}
+	return state.ID, nil
+}
+
+// Get retorna o estado da saga ou nil quando não existe
+func (m *Manager) Get(id uuid.UUID) (*State, error) {
+	return m.store.Get(id)
+}
+
+// Resume continua a saga de onde ela parou: executa os passos restantes ou, após uma falha ou o fim do prazo,
+// compensa os passos concluídos em ordem inversa. Uma compensação com falha é reagendada com backoff
+// exponencial e, ao esgotar as tentativas, a saga fica como falha para um operador.
+// Se ctx for cancelado durante um passo, a saga é mantida como está para ser retomada depois.
+func (m *Manager) Resume(ctx context.Context, state State) error {
+	definition, ok := m.definitions[state.Name]
+	if !ok {
+		state.Status = StatusFailed
+		state.LastError = fmt.Sprintf("%v: %s", ErrUnknownSaga, state.Name)
+		return m.save(&state)
+	}
+	ctx = security.WithPrincipal(ctx, state.Principal)
+	if state.CorrelationID != "" {
+		ctx = core.WithCorrelationID(ctx, state.CorrelationID)
+	}
+
+	for state.Status == StatusRunning {
+		if state.Step >= definition.stepCount() {
+			state.Status = StatusCompleted
+			break
+		}
+		if !m.Now().Before(state.Deadline) {
+			state.Status = StatusCompensating
+			state.LastError = ErrSagaTimedOut.Error()
+		} else {
+			stepCtx, cancel := context.WithDeadline(ctx, state.Deadline)
+			data, err := m.run(stepCtx, definition, &state, state.Step, ActionExecute)
+			cancel()
+			if err != nil && ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if err != nil {
+				state.Status = StatusCompensating
+				state.LastError = err.Error()
+			} else {
+				state.Data = data
+				state.Step++
+			}
+		}
+		if err := m.save(&state); err != nil {
+			return err
+		}
+	}
+
+	for state.Status == StatusCompensating {
+		if state.Step == 0 {
+			state.Status = StatusCompensated
+			break
+		}
+		data, err := m.run(ctx, definition, &state, state.Step-1, ActionCompensate)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if err != nil {
+			state.Attempts++
+			state.LastError = err.Error()
+			if state.Attempts >= m.MaxCompensationAttempts {
+				state.Status = StatusFailed
+				break
+			}
+			state.NextAttemptAt = m.Now().Add(m.backoff(state.Attempts - 1))
+			return m.save(&state)
+		}
+		state.Data = data
+		state.Step--
+		state.Attempts = 0
+		if err = m.save(&state); err != nil {
+			return err
+		}
+	}
+	return m.save(&state)
+}
+
+// run executa a ação do passo, registrando-a no histórico e convertendo um panic em erro
+func (m *Manager) run(ctx context.Context, definition definition, state *State, index int, action string) (data json.RawMessage, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			data, err = nil, fmt.Errorf("saga step panicked: %v", recovered)
+		}
+		transition := Transition{Step: definition.stepName(index), Action: action, At: m.Now()}
+		if err != nil {
+			transition.Error = err.Error()
+		}
+		state.History = append(state.History, transition)
+	}()
+	return definition.run(ctx, m.mediator, index, action, state.Data)
+}
+
+func (m *Manager) save(state *State) error {
+	state.UpdatedAt = m.Now()
+	return m.store.Save(state)
+}
+
+// backoff calcula o atraso da próxima tentativa a partir do número de tentativas já feitas
+func (m *Manager) backoff(attempts int) time.Duration {
+	delay := m.BaseBackoff
+	for i := 0; i < attempts && delay < m.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > m.MaxBackoff {
+		return m.MaxBackoff
+	}
+	return delay
+}
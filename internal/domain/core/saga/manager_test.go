@@ -0,0 +1,289 @@
+package saga
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockSagaStore guarda as sagas em memória e conta as gravações
+type MockSagaStore struct {
+	States map[uuid.UUID]State
+	Saves  int
+}
+
+func NewMockSagaStore() *MockSagaStore {
+	return &MockSagaStore{States: make(map[uuid.UUID]State)}
+}
+
+func (s *MockSagaStore) Create(state *State) error {
+	s.States[state.ID] = *state
+	return nil
+}
+
+func (s *MockSagaStore) Save(state *State) error {
+	s.Saves++
+	s.States[state.ID] = *state
+	return nil
+}
+
+func (s *MockSagaStore) Get(id uuid.UUID) (*State, error) {
+	if state, ok := s.States[id]; ok {
+		return &state, nil
+	}
+	return nil, nil
+}
+
+func (s *MockSagaStore) Claim(now time.Time, lease time.Duration, limit int) ([]State, error) {
+	return nil, nil
+}
+
+func (s *MockSagaStore) Find(filter Filter) ([]State, error) {
+	return nil, nil
+}
+
+// testData são os dados da saga usada nos testes
+type testData struct {
+	UserID string   `json:"userId"`
+	Done   []string `json:"done"`
+}
+
+// testSteps acompanha as execuções e falhas dos passos da saga de teste
+type testSteps struct {
+	calls      []string
+	failStep   string
+	failUndo   string
+	panicStep  string
+	subjects   []string
+	beforeStep func()
+}
+
+func (s *testSteps) step(name string) Step[testData] {
+	return Step[testData]{
+		Name: name,
+		Execute: func(ctx context.Context, m mediator.Sender, data *testData) error {
+			if s.beforeStep != nil {
+				s.beforeStep()
+			}
+			s.calls = append(s.calls, name)
+			s.subjects = append(s.subjects, security.PrincipalFromContext(ctx).Subject)
+			if name == s.panicStep {
+				panic("boom")
+			}
+			if name == s.failStep {
+				return errors.New(name + " failed")
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			data.Done = append(data.Done, name)
+			return nil
+		},
+		Compensate: func(ctx context.Context, m mediator.Sender, data *testData) error {
+			s.calls = append(s.calls, "undo "+name)
+			if name == s.failUndo {
+				return errors.New("undo " + name + " failed")
+			}
+			data.Done = data.Done[:len(data.Done)-1]
+			return nil
+		},
+	}
+}
+
+func setupManager(steps *testSteps) (*Manager, *MockSagaStore) {
+	store := NewMockSagaStore()
+	manager := NewManager(store, mediator.NewMediatR())
+	Register(manager, Definition[testData]{
+		Name:    "DeleteAccount",
+		Steps:   []Step[testData]{steps.step("sessions"), steps.step("content"), steps.step("media")},
+		Timeout: time.Minute,
+	})
+	return manager, store
+}
+
+func startAndResume(t *testing.T, manager *Manager, store *MockSagaStore) State {
+	ctx := security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"})
+	id, err := manager.Start(ctx, "DeleteAccount", testData{UserID: "1"})
+	assert.NoError(t, err)
+	_ = manager.Resume(context.Background(), store.States[id])
+	return store.States[id]
+}
+
+func TestManager_Start(t *testing.T) {
+	// Configuração
+	steps := &testSteps{}
+	manager, store := setupManager(steps)
+	ctx := security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"})
+
+	// Execução
+	id, err := manager.Start(ctx, "DeleteAccount", testData{UserID: "1"})
+	state, _ := manager.Get(id)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, StatusRunning, state.Status, "A saga deve ser gravada em execução")
+	assert.JSONEq(t, `{"userId":"1","done":null}`, string(state.Data), "Os dados devem ser serializados")
+	assert.Equal(t, "user-1", state.Principal.Subject)
+	assert.Empty(t, steps.calls, "Os passos devem ser executados em segundo plano")
+	assert.Len(t, store.States, 1)
+}
+
+func TestManager_StartErrors(t *testing.T) {
+	// Configuração
+	manager, store := setupManager(&testSteps{})
+
+	// Execução
+	_, unknownErr := manager.Start(context.Background(), "Unknown", testData{})
+	_, dataErr := manager.Start(context.Background(), "DeleteAccount", map[string]string{})
+
+	// Verificações
+	assert.ErrorIs(t, unknownErr, ErrUnknownSaga, "Sagas sem definição devem ser rejeitadas")
+	assert.ErrorIs(t, dataErr, ErrInvalidSagaData, "Dados de outro tipo devem ser rejeitados")
+	assert.Empty(t, store.States)
+}
+
+func TestManager_ResumeCompletes(t *testing.T) {
+	// Configuração
+	steps := &testSteps{}
+	manager, store := setupManager(steps)
+
+	// Execução
+	state := startAndResume(t, manager, store)
+
+	// Verificações
+	assert.Equal(t, StatusCompleted, state.Status, "A saga deve ser concluída")
+	assert.Equal(t, []string{"sessions", "content", "media"}, steps.calls, "Os passos devem ser executados em ordem")
+	assert.Equal(t, []string{"user-1", "user-1", "user-1"}, steps.subjects, "Os passos devem rodar em nome de quem iniciou a saga")
+	assert.JSONEq(t, `{"userId":"1","done":["sessions","content","media"]}`, string(state.Data), "As alterações dos dados devem ser gravadas")
+	assert.Len(t, state.History, 3, "Cada passo deve ser registrado no histórico")
+	assert.Equal(t, 4, store.Saves, "O estado deve ser gravado a cada passo")
+}
+
+func TestManager_ResumeCompensates(t *testing.T) {
+	// Configuração
+	steps := &testSteps{failStep: "media"}
+	manager, store := setupManager(steps)
+
+	// Execução
+	state := startAndResume(t, manager, store)
+
+	// Verificações
+	assert.Equal(t, StatusCompensated, state.Status, "A saga deve ser compensada")
+	assert.Equal(t, []string{"sessions", "content", "media", "undo content", "undo sessions"}, steps.calls,
+		"Apenas os passos concluídos devem ser desfeitos, em ordem inversa")
+	assert.Equal(t, "media failed", state.LastError, "O erro que causou a compensação deve ser guardado")
+	assert.JSONEq(t, `{"userId":"1","done":[]}`, string(state.Data))
+	assert.Equal(t, Transition{Step: "media", Action: ActionExecute, Error: "media failed", At: state.History[2].At}, state.History[2])
+	assert.Equal(t, ActionCompensate, state.History[4].Action)
+}
+
+func TestManager_ResumePanic(t *testing.T) {
+	// Configuração
+	steps := &testSteps{panicStep: "content"}
+	manager, store := setupManager(steps)
+
+	// Execução
+	state := startAndResume(t, manager, store)
+
+	// Verificações
+	assert.Equal(t, StatusCompensated, state.Status, "Um panic no passo deve ser tratado como falha")
+	assert.Contains(t, state.LastError, "boom")
+}
+
+func TestManager_CompensationRetries(t *testing.T) {
+	// Configuração
+	steps := &testSteps{failStep: "media", failUndo: "content"}
+	manager, store := setupManager(steps)
+	manager.MaxCompensationAttempts = 2
+	now := time.Now()
+	manager.Now = func() time.Time { return now }
+
+	// Execução
+	state := startAndResume(t, manager, store)
+
+	// Verificações
+	assert.Equal(t, StatusCompensating, state.Status, "Uma compensação com falha deve ser tentada de novo")
+	assert.Equal(t, 1, state.Attempts)
+	assert.Equal(t, 2, state.Step, "O passo não desfeito deve continuar pendente")
+	assert.Equal(t, now.Add(DefaultBaseBackoff), state.NextAttemptAt, "A nova tentativa deve usar backoff")
+
+	// Execução - última tentativa
+	_ = manager.Resume(context.Background(), state)
+	state = store.States[state.ID]
+
+	// Verificações
+	assert.Equal(t, StatusFailed, state.Status, "Ao esgotar as tentativas, a saga deve ficar para um operador")
+	assert.Equal(t, "undo content failed", state.LastError)
+	assert.NotContains(t, steps.calls, "undo sessions", "Os passos anteriores não devem ser desfeitos fora de ordem")
+}
+
+func TestManager_ResumeAfterRestart(t *testing.T) {
+	// Configuração
+	steps := &testSteps{}
+	manager, store := setupManager(steps)
+	id, _ := manager.Start(context.Background(), "DeleteAccount", testData{UserID: "1"})
+	state := store.States[id]
+	state.Step = 2
+	state.Data = []byte(`{"userId":"1","done":["sessions","content"]}`)
+
+	// Execução
+	err := manager.Resume(context.Background(), state)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"media"}, steps.calls, "A saga deve continuar do passo em que parou")
+	assert.Equal(t, StatusCompleted, store.States[id].Status)
+}
+
+func TestManager_Timeout(t *testing.T) {
+	// Configuração
+	steps := &testSteps{}
+	manager, store := setupManager(steps)
+	id, _ := manager.Start(context.Background(), "DeleteAccount", testData{UserID: "1"})
+	state := store.States[id]
+	state.Step = 1
+	state.Data = []byte(`{"userId":"1","done":["sessions"]}`)
+	manager.Now = func() time.Time { return state.Deadline }
+
+	// Execução
+	_ = manager.Resume(context.Background(), state)
+
+	// Verificações
+	assert.Equal(t, StatusCompensated, store.States[id].Status, "Sagas que passam do prazo devem ser compensadas")
+	assert.Equal(t, ErrSagaTimedOut.Error(), store.States[id].LastError)
+	assert.Equal(t, []string{"undo sessions"}, steps.calls, "Nenhum novo passo deve ser executado após o prazo")
+}
+
+func TestManager_Cancelled(t *testing.T) {
+	// Configuração
+	ctx, cancel := context.WithCancel(context.Background())
+	steps := &testSteps{beforeStep: cancel}
+	manager, store := setupManager(steps)
+	id, _ := manager.Start(context.Background(), "DeleteAccount", testData{UserID: "1"})
+
+	// Execução
+	err := manager.Resume(ctx, store.States[id])
+
+	// Verificações
+	assert.ErrorIs(t, err, context.Canceled, "O cancelamento deve interromper a saga")
+	assert.Equal(t, StatusRunning, store.States[id].Status, "A saga não deve ser compensada por um desligamento")
+	assert.Equal(t, 0, store.States[id].Step)
+}
+
+func TestManager_UnknownDefinition(t *testing.T) {
+	// Configuração
+	manager, store := setupManager(&testSteps{})
+	state := NewState("Removed", []byte(`{}`), time.Minute, security.Principal{}, "")
+
+	// Execução
+	_ = manager.Resume(context.Background(), *state)
+
+	// Verificações
+	assert.Equal(t, StatusFailed, store.States[state.ID].Status, "Sagas sem definição devem falhar")
+}
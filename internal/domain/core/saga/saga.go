@@ -0,0 +1,118 @@
+package saga
+
+import (
+	"encoding/json"
+	"errors"
+	"flickly/internal/domain/core/security"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Estados de uma saga
+const (
+	// StatusRunning indica que os passos estão sendo executados
+	StatusRunning = "running"
+	// StatusCompensating indica que um passo falhou e os passos concluídos estão sendo desfeitos
+	StatusCompensating = "compensating"
+	// StatusCompleted indica que todos os passos foram executados
+	StatusCompleted = "completed"
+	// StatusCompensated indica que a saga falhou e todos os passos concluídos foram desfeitos
+	StatusCompensated = "compensated"
+	// StatusFailed indica que uma compensação esgotou as tentativas; exige intervenção de um operador
+	StatusFailed = "failed"
+)
+
+// Ações registradas no histórico da saga
+const (
+	ActionExecute    = "execute"
+	ActionCompensate = "compensate"
+)
+
+var (
+	// ErrUnknownSaga é retornado quando não há definição registrada com o nome da saga
+	ErrUnknownSaga = errors.New("no saga definition registered")
+	// ErrInvalidSagaData indica que os dados informados não são do tipo esperado pela definição
+	ErrInvalidSagaData = errors.New("invalid saga data")
+	// ErrSagaTimedOut é o erro registrado quando a saga não termina dentro do prazo da definição
+	ErrSagaTimedOut = errors.New("saga timed out")
+)
+
+// Transition é a execução de um passo ou de sua compensação, guardada no histórico da saga
+type Transition struct {
+	Step   string    `json:"step"`
+	Action string    `json:"action"`
+	Error  string    `json:"error,omitempty"`
+	At     time.Time `json:"at"`
+}
+
+// State é o estado persistido de uma saga, suficiente para retomá-la após um reinício
+type State struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	Status string    `json:"status"`
+	// Step é o número de passos concluídos: o índice do próximo passo a executar ou, durante a
+	// compensação, a quantidade de passos que ainda devem ser desfeitos
+	Step int             `json:"step"`
+	Data json.RawMessage `json:"data"`
+	// Attempts conta as tentativas de compensação do passo corrente
+	Attempts  int          `json:"attempts"`
+	LastError string       `json:"lastError,omitempty"`
+	History   []Transition `json:"history"`
+	// Deadline é o prazo para a saga terminar; depois dele, os passos concluídos são desfeitos
+	Deadline time.Time `json:"deadline"`
+	// NextAttemptAt é quando a saga pode continuar; durante a execução, é o fim da reserva do executor
+	NextAttemptAt time.Time          `json:"nextAttemptAt"`
+	CreatedAt     time.Time          `json:"createdAt"`
+	UpdatedAt     time.Time          `json:"updatedAt"`
+	Principal     security.Principal `json:"principal"`
+	CorrelationID string             `json:"correlationId,omitempty"`
+}
+
+// Filter seleciona sagas na listagem; campos vazios não filtram
+type Filter struct {
+	Name   string
+	Status string
+	Limit  int
+}
+
+// Matches indica se a saga atende ao filtro
+func (f Filter) Matches(state State) bool {
+	return (f.Name == "" || state.Name == f.Name) && (f.Status == "" || state.Status == f.Status)
+}
+
+// Store persiste o estado das sagas
+type Store interface {
+	Create(state *State) error
+	// Save grava o estado completo da saga
+	Save(state *State) error
+	// Get retorna a saga ou nil quando não existe
+	Get(id uuid.UUID) (*State, error)
+	// Claim reserva até limit sagas em andamento prontas para continuar, adiando NextAttemptAt por lease
+	Claim(now time.Time, lease time.Duration, limit int) ([]State, error)
+	// Find retorna as sagas que atendem ao filtro, da atualização mais recente para a mais antiga
+	Find(filter Filter) ([]State, error)
+}
+
+// NewState cria o estado inicial de uma saga, pronta para executar o primeiro passo
+func NewState(name string, data json.RawMessage, timeout time.Duration, principal security.Principal, correlationID string) *State {
+	now := time.Now()
+	return &State{
+		ID:            uuid.New(),
+		Name:          name,
+		Status:        StatusRunning,
+		Data:          data,
+		History:       []Transition{},
+		Deadline:      now.Add(timeout),
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		Principal:     principal,
+		CorrelationID: correlationID,
+	}
+}
+
+// Finished indica se a saga não será mais executada
+func (s State) Finished() bool {
+	return s.Status == StatusCompleted || s.Status == StatusCompensated || s.Status == StatusFailed
+}
@@ -0,0 +1,40 @@
+package saga
+
+import (
+	"flickly/internal/domain/core/security"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewState(t *testing.T) {
+	// Execução
+	state := NewState("DeleteAccount", []byte(`{"userId":"1"}`), time.Minute, security.Principal{Subject: "user-1"}, "corr-1")
+
+	// Verificações
+	assert.NotEqual(t, uuid.Nil, state.ID, "A saga deve ter um ID")
+	assert.Equal(t, StatusRunning, state.Status, "Uma nova saga deve estar em execução")
+	assert.Equal(t, 0, state.Step, "Nenhum passo deve estar concluído")
+	assert.Equal(t, state.CreatedAt.Add(time.Minute), state.Deadline, "O prazo deve contar a partir da criação")
+	assert.Equal(t, state.CreatedAt, state.NextAttemptAt, "A saga deve poder ser executada imediatamente")
+	assert.Equal(t, "user-1", state.Principal.Subject, "O usuário que iniciou a saga deve ser guardado")
+	assert.Equal(t, "corr-1", state.CorrelationID)
+	assert.False(t, state.Finished())
+}
+
+func TestState_Finished(t *testing.T) {
+	assert.True(t, State{Status: StatusCompleted}.Finished())
+	assert.True(t, State{Status: StatusCompensated}.Finished())
+	assert.True(t, State{Status: StatusFailed}.Finished(), "Sagas com falha só continuam por intervenção de um operador")
+	assert.False(t, State{Status: StatusCompensating}.Finished())
+}
+
+func TestFilter_Matches(t *testing.T) {
+	state := State{Name: "DeleteAccount", Status: StatusFailed}
+	assert.True(t, Filter{}.Matches(state), "Um filtro vazio deve aceitar qualquer saga")
+	assert.True(t, Filter{Name: "DeleteAccount", Status: StatusFailed}.Matches(state))
+	assert.False(t, Filter{Status: StatusRunning}.Matches(state))
+	assert.False(t, Filter{Name: "Other"}.Matches(state))
+}
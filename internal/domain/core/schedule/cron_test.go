@@ -0,0 +1,59 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// at cria um horário em UTC para os testes
+func at(value string) time.Time {
+	t, _ := time.Parse("2006-01-02 15:04", value)
+	return t
+}
+
+func TestParse_Next(t *testing.T) {
+	cases := []struct {
+		spec     string
+		after    string
+		expected string
+	}{
+		{"*/15 * * * *", "2026-01-05 10:07", "2026-01-05 10:15"},
+		{"0 3 * * *", "2026-01-05 10:07", "2026-01-06 03:00"},
+		{"30 9 * * mon-fri", "2026-01-09 10:00", "2026-01-12 09:30"},
+		{"0 0 1 */3 *", "2026-02-10 00:00", "2026-04-01 00:00"},
+		{"0 12 13 * 5", "2026-01-01 00:00", "2026-01-02 12:00"},
+		{"0 0 * * 7", "2026-01-05 00:00", "2026-01-11 00:00"},
+		{"5,10 8-9 * jan *", "2026-01-31 09:10", "2027-01-01 08:05"},
+		{"@hourly", "2026-01-05 10:07", "2026-01-05 11:00"},
+		{"@daily", "2026-12-31 23:59", "2027-01-01 00:00"},
+		{"0 0 29 2 *", "2026-03-01 00:00", "2028-02-29 00:00"},
+	}
+	for _, c := range cases {
+		// Configuração
+		schedule, err := Parse(c.spec)
+
+		// Verificações
+		assert.NoError(t, err, "A expressão %q deve ser válida", c.spec)
+		assert.Equal(t, at(c.expected), schedule.Next(at(c.after)), "Próxima execução de %q após %s", c.spec, c.after)
+	}
+}
+
+func TestParse_Every(t *testing.T) {
+	// Execução
+	schedule, err := Parse("@every 90s")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, at("2026-01-05 10:08").Add(30*time.Second), schedule.Next(at("2026-01-05 10:07")),
+		"O intervalo deve contar a partir do horário informado")
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, spec := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "0 0 0 * *", "* * * 13 *", "* * * * 8",
+		"5-1 * * * *", "*/0 * * * *", "a * * * *", "0 0 31 2 *", "@every", "@every -1m", "@every soon"} {
+		_, err := Parse(spec)
+		assert.ErrorIs(t, err, ErrInvalidSpec, "A expressão %q deve ser rejeitada", spec)
+	}
+}
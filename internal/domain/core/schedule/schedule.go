@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Resultados de uma execução
+const (
+	RunSucceeded = "succeeded"
+	RunFailed    = "failed"
+)
+
+// Origens de uma execução
+const (
+	// TriggerSchedule indica uma execução no horário agendado
+	TriggerSchedule = "schedule"
+	// TriggerManual indica uma execução solicitada por um operador
+	TriggerManual = "manual"
+)
+
+// MaxRuns é a quantidade de execuções mantidas no histórico de cada tarefa
+const MaxRuns = 100
+
+// State é o estado persistido de uma tarefa agendada, compartilhado pelas instâncias da aplicação
+type State struct {
+	Name   string `json:"name"`
+	Spec   string `json:"spec"`
+	Paused bool   `json:"paused"`
+	// Triggered indica uma execução manual pendente, feita mesmo com a tarefa pausada
+	Triggered bool      `json:"triggered"`
+	NextRunAt time.Time `json:"nextRunAt"`
+	// LockedUntil é o fim da reserva da instância que executa a tarefa
+	LockedUntil time.Time `json:"lockedUntil"`
+	LastRunAt   time.Time `json:"lastRunAt"`
+	LastStatus  string    `json:"lastStatus,omitempty"`
+	LastError   string    `json:"lastError,omitempty"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// Run é o registro de uma execução da tarefa
+type Run struct {
+	ID          uuid.UUID `json:"id"`
+	Name        string    `json:"name"`
+	Trigger     string    `json:"trigger"`
+	Status      string    `json:"status"`
+	Error       string    `json:"error,omitempty"`
+	ScheduledAt time.Time `json:"scheduledAt"`
+	StartedAt   time.Time `json:"startedAt"`
+	FinishedAt  time.Time `json:"finishedAt"`
+}
+
+// Store persiste as tarefas agendadas e o histórico de execuções
+type Store interface {
+	// Sync cria a tarefa se ela não existir; se a expressão mudou, atualiza a expressão e a próxima execução
+	Sync(state *State) error
+	// Claim reserva até limit tarefas entre as informadas que estão prontas para executar e sem reserva vigente,
+	// mantendo-as reservadas até now+lease para que apenas uma instância as execute
+	Claim(names []string, now time.Time, lease time.Duration, limit int) ([]State, error)
+	// Finish grava a execução, libera a reserva e agenda a próxima execução. lockedUntil é a reserva retornada
+	// por Claim; se ela venceu e a tarefa foi reservada de novo, nada é gravado e ErrLeaseLost é retornado.
+	Finish(run *Run, lockedUntil, nextRunAt time.Time) error
+	// Get retorna a tarefa ou nil quando não existe
+	Get(name string) (*State, error)
+	// List retorna as tarefas em ordem de nome
+	List() ([]State, error)
+	// Runs retorna as últimas execuções da tarefa, das mais recentes para as mais antigas
+	Runs(name string, limit int) ([]Run, error)
+	// Pause, Resume e Trigger retornam false quando a tarefa não existe
+	Pause(name string) (bool, error)
+	Resume(name string, nextRunAt time.Time) (bool, error)
+	Trigger(name string) (bool, error)
+}
+
+// Due indica se a tarefa deve ser executada em now, desconsiderando a reserva
+func (s State) Due(now time.Time) bool {
+	return s.Triggered || (!s.Paused && !s.NextRunAt.After(now))
+}
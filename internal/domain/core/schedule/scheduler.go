@@ -0,0 +1,233 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"fmt"
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrDuplicateTask é retornado ao registrar duas tarefas com o mesmo nome
+var ErrDuplicateTask = errors.New("scheduled task already registered")
+
+// ErrLeaseLost indica que a reserva da tarefa venceu e foi obtida por outra instância antes de Finish
+var ErrLeaseLost = errors.New("scheduled task lease lost")
+
+// SystemPrincipal é o usuário em nome de quem as tarefas agendadas são executadas
+var SystemPrincipal = security.Principal{Subject: "scheduler", Name: "Scheduler"}
+
+// task é uma requisição registrada para ser enviada periodicamente
+type task struct {
+	spec     string
+	schedule Schedule
+	request  mediator.Request
+}
+
+// Scheduler envia as requisições registradas pelo mediator nos horários agendados. O estado das tarefas fica
+// no Store, que garante, com uma reserva, que cada execução aconteça em uma única instância.
+// As expressões cron são avaliadas em UTC.
+type Scheduler struct {
+	store    Store
+	mediator mediator.Sender
+	tasks    map[string]task
+	Now      func() time.Time
+}
+
+// NewScheduler cria um agendador sem tarefas
+func NewScheduler(store Store, mediator mediator.Sender) *Scheduler {
+	return &Scheduler{
+		store:    store,
+		mediator: mediator,
+		tasks:    make(map[string]task),
+		Now:      time.Now,
+	}
+}
+
+// Register agenda o envio da requisição conforme spec (veja Parse). A requisição é enviada como foi registrada
+// a cada execução, por isso não deve ser alterada pelo handler.
+func (s *Scheduler) Register(name string, spec string, request mediator.Request) error {
+	if _, ok := s.tasks[name]; ok {
+		return fmt.Errorf("%w: %s", ErrDuplicateTask, name)
+	}
+	schedule, err := Parse(spec)
+	if err != nil {
+		return err
+	}
+	s.tasks[name] = task{spec: spec, schedule: schedule, request: request}
+	return nil
+}
+
+// Names retorna os nomes das tarefas registradas em ordem alfabética
+func (s *Scheduler) Names() []string {
+	names := make([]string, 0, len(s.tasks))
+	for name := range s.tasks {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// RequestTypes lista os tipos das requisições agendadas, para validar na inicialização que todas têm handler
+func (s *Scheduler) RequestTypes() []reflect.Type {
+	types := make([]reflect.Type, 0, len(s.tasks))
+	for _, name := range s.Names() {
+		types = append(types, reflect.TypeOf(s.tasks[name].request))
+	}
+	return types
+}
+
+// Sync grava as tarefas registradas no Store, calculando a primeira execução das tarefas novas
+func (s *Scheduler) Sync() error {
+	now := s.Now()
+	for _, name := range s.Names() {
+		task := s.tasks[name]
+		state := &State{Name: name, Spec: task.spec, NextRunAt: task.schedule.Next(now.UTC()), UpdatedAt: now}
+		if err := s.store.Sync(state); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Claim reserva as tarefas registradas que estão prontas para executar
+func (s *Scheduler) Claim(lease time.Duration, limit int) ([]State, error) {
+	if len(s.tasks) == 0 {
+		return nil, nil
+	}
+	return s.store.Claim(s.Names(), s.Now(), lease, limit)
+}
+
+// Execute envia a requisição da tarefa reservada, grava a execução no histórico e agenda a próxima.
+// A execução no horário agendado usa uma chave de idempotência própria do horário, para que um comando não
+// seja repetido se a reserva vencer durante a execução. Se ctx for cancelado, a tarefa fica reservada e é
+// executada de novo quando a reserva vencer.
+func (s *Scheduler) Execute(ctx context.Context, state State) error {
+	task, ok := s.tasks[state.Name]
+	if !ok {
+		return errTaskNotFound(state.Name)
+	}
+	run := &Run{ID: uuid.New(), Name: state.Name, Trigger: TriggerSchedule, ScheduledAt: state.NextRunAt, StartedAt: s.Now()}
+	key := fmt.Sprintf("schedule:%s:%d", state.Name, state.NextRunAt.Unix())
+	if state.Triggered {
+		run.Trigger = TriggerManual
+		run.ScheduledAt = run.StartedAt
+		key = "schedule:" + state.Name + ":" + run.ID.String()
+	}
+
+	runCtx := security.WithPrincipal(ctx, SystemPrincipal)
+	runCtx = core.WithCorrelationID(runCtx, run.ID.String())
+	runCtx = idempotency.WithKey(runCtx, key)
+	err := s.send(runCtx, task.request)
+	if err != nil && ctx.Err() != nil {
+		return ctx.Err()
+	}
+	run.FinishedAt = s.Now()
+	run.Status = RunSucceeded
+	if err != nil {
+		run.Status = RunFailed
+		run.Error = err.Error()
+	}
+
+	// Execuções perdidas enquanto a tarefa executava ou estava pausada não são repetidas
+	nextRunAt := state.NextRunAt
+	if !nextRunAt.After(run.StartedAt) {
+		nextRunAt = task.schedule.Next(run.FinishedAt.UTC())
+	}
+	return s.store.Finish(run, state.LockedUntil, nextRunAt)
+}
+
+// List retorna o estado das tarefas registradas
+func (s *Scheduler) List() ([]State, error) {
+	states, err := s.store.List()
+	if err != nil {
+		return nil, err
+	}
+	registered := make([]State, 0, len(states))
+	for _, state := range states {
+		if _, ok := s.tasks[state.Name]; ok {
+			registered = append(registered, state)
+		}
+	}
+	return registered, nil
+}
+
+// Get retorna o estado da tarefa
+func (s *Scheduler) Get(name string) (*State, error) {
+	if _, ok := s.tasks[name]; !ok {
+		return nil, errTaskNotFound(name)
+	}
+	state, err := s.store.Get(name)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil {
+		return nil, errTaskNotFound(name)
+	}
+	return state, nil
+}
+
+// Runs retorna as últimas execuções da tarefa
+func (s *Scheduler) Runs(name string, limit int) ([]Run, error) {
+	if _, ok := s.tasks[name]; !ok {
+		return nil, errTaskNotFound(name)
+	}
+	return s.store.Runs(name, limit)
+}
+
+// Pause suspende as execuções agendadas da tarefa; execuções manuais continuam permitidas
+func (s *Scheduler) Pause(name string) error {
+	return s.update(name, func() (bool, error) {
+		return s.store.Pause(name)
+	})
+}
+
+// Resume retoma as execuções agendadas a partir do próximo horário, sem repetir as perdidas durante a pausa
+func (s *Scheduler) Resume(name string) error {
+	return s.update(name, func() (bool, error) {
+		return s.store.Resume(name, s.tasks[name].schedule.Next(s.Now().UTC()))
+	})
+}
+
+// Trigger solicita uma execução imediata da tarefa, feita pela próxima instância que a reservar
+func (s *Scheduler) Trigger(name string) error {
+	return s.update(name, func() (bool, error) {
+		return s.store.Trigger(name)
+	})
+}
+
+func (s *Scheduler) update(name string, update func() (bool, error)) error {
+	if _, ok := s.tasks[name]; !ok {
+		return errTaskNotFound(name)
+	}
+	found, err := update()
+	if err != nil {
+		return err
+	}
+	if !found {
+		return errTaskNotFound(name)
+	}
+	return nil
+}
+
+// send envia a requisição convertendo um panic do handler em erro
+func (s *Scheduler) send(ctx context.Context, request mediator.Request) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("scheduled task panicked: %v", recovered)
+		}
+	}()
+	_, err = s.mediator.Send(ctx, request)
+	return err
+}
+
+func errTaskNotFound(name string) error {
+	return core.ErrScheduledTaskNotFound(fmt.Errorf("scheduled task %s not found", name))
+}
@@ -0,0 +1,297 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockScheduleStore guarda as tarefas em memória, sem controle de reserva
+type MockScheduleStore struct {
+	States   map[string]State
+	Finished []Run
+	Leases   []time.Time
+	Err      error
+}
+
+func NewMockScheduleStore() *MockScheduleStore {
+	return &MockScheduleStore{States: make(map[string]State)}
+}
+
+func (s *MockScheduleStore) Sync(state *State) error {
+	if existing, ok := s.States[state.Name]; ok && existing.Spec == state.Spec {
+		return s.Err
+	}
+	s.States[state.Name] = *state
+	return s.Err
+}
+
+func (s *MockScheduleStore) Claim(names []string, now time.Time, lease time.Duration, limit int) ([]State, error) {
+	var claimed []State
+	for _, name := range names {
+		if state, ok := s.States[name]; ok && state.Due(now) {
+			state.LockedUntil = now.Add(lease)
+			claimed = append(claimed, state)
+		}
+	}
+	return claimed, s.Err
+}
+
+func (s *MockScheduleStore) Finish(run *Run, lockedUntil, nextRunAt time.Time) error {
+	s.Finished = append(s.Finished, *run)
+	s.Leases = append(s.Leases, lockedUntil)
+	state := s.States[run.Name]
+	state.NextRunAt = nextRunAt
+	state.Triggered = false
+	state.LastRunAt = run.StartedAt
+	state.LastStatus = run.Status
+	state.LastError = run.Error
+	s.States[run.Name] = state
+	return s.Err
+}
+
+func (s *MockScheduleStore) Get(name string) (*State, error) {
+	if state, ok := s.States[name]; ok {
+		return &state, nil
+	}
+	return nil, s.Err
+}
+
+func (s *MockScheduleStore) List() ([]State, error) {
+	var states []State
+	for _, state := range s.States {
+		states = append(states, state)
+	}
+	return states, s.Err
+}
+
+func (s *MockScheduleStore) Runs(name string, limit int) ([]Run, error) {
+	return s.Finished, s.Err
+}
+
+func (s *MockScheduleStore) Pause(name string) (bool, error) {
+	return s.update(name, func(state *State) { state.Paused = true })
+}
+
+func (s *MockScheduleStore) Resume(name string, nextRunAt time.Time) (bool, error) {
+	return s.update(name, func(state *State) {
+		state.Paused = false
+		state.NextRunAt = nextRunAt
+	})
+}
+
+func (s *MockScheduleStore) Trigger(name string) (bool, error) {
+	return s.update(name, func(state *State) { state.Triggered = true })
+}
+
+func (s *MockScheduleStore) update(name string, update func(state *State)) (bool, error) {
+	state, ok := s.States[name]
+	if !ok {
+		return false, s.Err
+	}
+	update(&state)
+	s.States[name] = state
+	return true, s.Err
+}
+
+// purgeCommand é a requisição agendada nos testes
+type purgeCommand struct {
+	Target string
+}
+
+// received guarda o contexto recebido pelo handler de purgeCommand
+type received struct {
+	subject string
+	key     string
+	calls   int
+}
+
+func setupScheduler(handler func(ctx context.Context, request purgeCommand) (int, error)) (*Scheduler, *MockScheduleStore) {
+	mediatR := mediator.NewMediatR()
+	mediator.Register[purgeCommand, int](mediatR, mediator.RequestHandlerFunc[purgeCommand, int](handler))
+	store := NewMockScheduleStore()
+	return NewScheduler(store, mediatR), store
+}
+
+func TestScheduler_Register(t *testing.T) {
+	// Configuração
+	scheduler, _ := setupScheduler(nil)
+
+	// Execução
+	err := scheduler.Register("purge", "@hourly", purgeCommand{})
+	duplicateErr := scheduler.Register("purge", "@daily", purgeCommand{})
+	invalidErr := scheduler.Register("other", "every hour", purgeCommand{})
+
+	// Verificações
+	assert.NoError(t, err, "Register não deve retornar erro")
+	assert.ErrorIs(t, duplicateErr, ErrDuplicateTask, "Nomes repetidos devem ser rejeitados")
+	assert.ErrorIs(t, invalidErr, ErrInvalidSpec, "Expressões inválidas devem ser rejeitadas")
+	assert.Equal(t, []string{"purge"}, scheduler.Names())
+	assert.Error(t, mediator.NewMediatR().Validate(scheduler.RequestTypes()...), "Requisições agendadas sem handler devem ser reportadas")
+}
+
+func TestScheduler_Sync(t *testing.T) {
+	// Configuração
+	scheduler, store := setupScheduler(nil)
+	now := at("2026-01-05 10:07")
+	scheduler.Now = func() time.Time { return now }
+	_ = scheduler.Register("purge", "@hourly", purgeCommand{})
+	_ = scheduler.Register("digest", "0 8 * * *", purgeCommand{})
+
+	// Execução
+	err := scheduler.Sync()
+
+	// Verificações
+	assert.NoError(t, err, "Sync não deve retornar erro")
+	assert.Equal(t, at("2026-01-05 11:00"), store.States["purge"].NextRunAt, "A primeira execução deve seguir a expressão")
+	assert.Equal(t, at("2026-01-06 08:00"), store.States["digest"].NextRunAt)
+	assert.Equal(t, "@hourly", store.States["purge"].Spec)
+}
+
+func TestScheduler_Execute(t *testing.T) {
+	// Configuração
+	var got received
+	scheduler, store := setupScheduler(func(ctx context.Context, request purgeCommand) (int, error) {
+		got.calls++
+		got.subject = security.PrincipalFromContext(ctx).Subject
+		got.key = idempotency.KeyFromContext(ctx)
+		return 3, nil
+	})
+	now := at("2026-01-05 11:00")
+	scheduler.Now = func() time.Time { return now }
+	_ = scheduler.Register("purge", "@hourly", purgeCommand{Target: "idempotency"})
+	store.States["purge"] = State{Name: "purge", Spec: "@hourly", NextRunAt: now}
+
+	// Execução
+	claimed, _ := scheduler.Claim(time.Minute, 10)
+	err := scheduler.Execute(context.Background(), claimed[0])
+
+	// Verificações
+	assert.NoError(t, err, "Execute não deve retornar erro")
+	assert.Equal(t, 1, got.calls, "A requisição deve ser enviada pelo mediator")
+	assert.Equal(t, SystemPrincipal.Subject, got.subject, "A tarefa deve ser executada em nome do agendador")
+	assert.Equal(t, "schedule:purge:1767610800", got.key, "A chave de idempotência deve identificar o horário agendado")
+	assert.Len(t, store.Finished, 1, "A execução deve ser registrada no histórico")
+	assert.Equal(t, now.Add(time.Minute), store.Leases[0], "A execução deve ser finalizada com a reserva obtida em Claim")
+	assert.Equal(t, RunSucceeded, store.Finished[0].Status)
+	assert.Equal(t, TriggerSchedule, store.Finished[0].Trigger)
+	assert.Equal(t, now, store.Finished[0].ScheduledAt)
+	assert.Equal(t, at("2026-01-05 12:00"), store.States["purge"].NextRunAt, "A próxima execução deve ser agendada")
+}
+
+func TestScheduler_ExecuteFailure(t *testing.T) {
+	// Configuração
+	scheduler, store := setupScheduler(func(ctx context.Context, request purgeCommand) (int, error) {
+		if request.Target == "panic" {
+			panic("boom")
+		}
+		return 0, errors.New("database unavailable")
+	})
+	now := at("2026-01-05 11:00")
+	scheduler.Now = func() time.Time { return now }
+	_ = scheduler.Register("purge", "@every 10m", purgeCommand{})
+	_ = scheduler.Register("panic", "@every 10m", purgeCommand{Target: "panic"})
+	store.States["purge"] = State{Name: "purge", NextRunAt: now}
+	store.States["panic"] = State{Name: "panic", NextRunAt: now}
+
+	// Execução
+	err := scheduler.Execute(context.Background(), store.States["purge"])
+	panicErr := scheduler.Execute(context.Background(), store.States["panic"])
+
+	// Verificações
+	assert.NoError(t, err, "A falha da tarefa deve ser registrada, não retornada")
+	assert.NoError(t, panicErr, "Um panic no handler deve ser registrado como falha")
+	assert.Equal(t, RunFailed, store.States["purge"].LastStatus)
+	assert.Equal(t, "database unavailable", store.States["purge"].LastError)
+	assert.Contains(t, store.States["panic"].LastError, "boom")
+	assert.Equal(t, now.Add(10*time.Minute), store.States["purge"].NextRunAt, "Uma falha não deve impedir as próximas execuções")
+}
+
+func TestScheduler_ExecuteCancelled(t *testing.T) {
+	// Configuração
+	ctx, cancel := context.WithCancel(context.Background())
+	scheduler, store := setupScheduler(func(ctx context.Context, request purgeCommand) (int, error) {
+		cancel()
+		return 0, ctx.Err()
+	})
+	_ = scheduler.Register("purge", "@hourly", purgeCommand{})
+	store.States["purge"] = State{Name: "purge", NextRunAt: time.Now()}
+
+	// Execução
+	err := scheduler.Execute(ctx, store.States["purge"])
+
+	// Verificações
+	assert.ErrorIs(t, err, context.Canceled, "O cancelamento deve interromper a execução")
+	assert.Empty(t, store.Finished, "A execução interrompida deve ser repetida quando a reserva vencer")
+}
+
+func TestScheduler_PauseResumeTrigger(t *testing.T) {
+	// Configuração
+	var got received
+	scheduler, store := setupScheduler(func(ctx context.Context, request purgeCommand) (int, error) {
+		got.calls++
+		got.key = idempotency.KeyFromContext(ctx)
+		return 0, nil
+	})
+	now := at("2026-01-05 11:00")
+	scheduler.Now = func() time.Time { return now }
+	_ = scheduler.Register("purge", "@hourly", purgeCommand{})
+	_ = scheduler.Sync()
+
+	// Execução - pausa
+	assert.NoError(t, scheduler.Pause("purge"))
+	now = at("2026-01-05 15:30")
+	claimed, _ := scheduler.Claim(time.Minute, 10)
+
+	// Verificações
+	assert.Empty(t, claimed, "Tarefas pausadas não devem ser executadas no horário agendado")
+
+	// Execução - execução manual com a tarefa pausada
+	assert.NoError(t, scheduler.Trigger("purge"))
+	claimed, _ = scheduler.Claim(time.Minute, 10)
+	_ = scheduler.Execute(context.Background(), claimed[0])
+
+	// Verificações
+	assert.Equal(t, 1, got.calls, "A execução manual deve ser feita mesmo com a tarefa pausada")
+	assert.Equal(t, TriggerManual, store.Finished[0].Trigger)
+	assert.Equal(t, "schedule:purge:"+store.Finished[0].ID.String(), got.key, "Cada execução manual deve ter a sua chave")
+	assert.False(t, store.States["purge"].Triggered, "A execução manual deve ser consumida")
+	assert.True(t, store.States["purge"].Paused, "A execução manual não deve retomar a tarefa")
+
+	// Execução - retomada
+	assert.NoError(t, scheduler.Resume("purge"))
+
+	// Verificações
+	assert.False(t, store.States["purge"].Paused)
+	assert.Equal(t, at("2026-01-05 16:00"), store.States["purge"].NextRunAt, "Execuções perdidas durante a pausa não devem ser repetidas")
+}
+
+func TestScheduler_NotFound(t *testing.T) {
+	// Configuração
+	scheduler, store := setupScheduler(nil)
+	_ = scheduler.Register("purge", "@hourly", purgeCommand{})
+	store.States["removed"] = State{Name: "removed"}
+
+	// Execução
+	_, getErr := scheduler.Get("removed")
+	_, runsErr := scheduler.Runs("unknown", 10)
+	pauseErr := scheduler.Pause("unknown")
+	triggerErr := scheduler.Trigger("purge")
+	states, _ := scheduler.List()
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.ErrorAs(t, getErr, &domainError, "Tarefas não registradas devem ser tratadas como inexistentes")
+	assert.Equal(t, 15, domainError.Code)
+	assert.ErrorAs(t, runsErr, &domainError)
+	assert.ErrorAs(t, pauseErr, &domainError)
+	assert.ErrorAs(t, triggerErr, &domainError, "Tarefas ainda não sincronizadas devem ser tratadas como inexistentes")
+	assert.Empty(t, states, "A listagem deve ignorar tarefas que não estão mais registradas")
+}
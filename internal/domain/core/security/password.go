@@ -0,0 +1,8 @@
+package security
+
+// PasswordHasher gera e verifica os hashes das senhas dos usuários
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify indica se a senha corresponde ao hash; hashes vazios ou inválidos nunca correspondem
+	Verify(hash string, password string) bool
+}
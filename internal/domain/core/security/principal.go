@@ -0,0 +1,63 @@
+package security
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// RoleAdmin é o papel exigido pelos endpoints administrativos
+const RoleAdmin = "admin"
+
+// principalKey é a chave usada para guardar o usuário autenticado no contexto
+type principalKey struct{}
+
+// ErrInvalidToken é retornado quando o token de acesso é inválido ou expirou
+var ErrInvalidToken = errors.New("invalid or expired access token")
+
+// Principal identifica quem executa a requisição
+type Principal struct {
+	Subject string   `json:"sub"`
+	Name    string   `json:"name,omitempty"`
+	Roles   []string `json:"roles,omitempty"`
+}
+
+// IsAuthenticated indica se a requisição foi feita por um usuário identificado
+func (p Principal) IsAuthenticated() bool {
+	return p.Subject != ""
+}
+
+// HasRole indica se o usuário possui o papel informado
+func (p Principal) HasRole(role string) bool {
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenService emite e valida os tokens de acesso
+type TokenService interface {
+	Issue(principal Principal) (token string, expiresIn time.Duration, err error)
+	Validate(token string) (Principal, error)
+}
+
+// RoleProvider define os papéis de um usuário no momento da autenticação
+type RoleProvider interface {
+	RolesFor(email string) []string
+}
+
+// WithPrincipal retorna uma cópia do contexto com o usuário autenticado
+func WithPrincipal(ctx context.Context, principal Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext retorna o usuário autenticado ou um Principal vazio (anônimo)
+func PrincipalFromContext(ctx context.Context) Principal {
+	if ctx == nil {
+		return Principal{}
+	}
+	principal, _ := ctx.Value(principalKey{}).(Principal)
+	return principal
+}
@@ -0,0 +1,31 @@
+package security
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipal_HasRole(t *testing.T) {
+	// Configuração
+	principal := Principal{Subject: "user-1", Roles: []string{RoleAdmin}}
+
+	// Verificações
+	assert.True(t, principal.IsAuthenticated(), "Principal com Subject deve estar autenticado")
+	assert.True(t, principal.HasRole(RoleAdmin), "O papel atribuído deve ser encontrado")
+	assert.False(t, principal.HasRole("auditor"), "Papéis não atribuídos não devem ser encontrados")
+	assert.False(t, Principal{}.IsAuthenticated(), "Principal vazio deve ser anônimo")
+}
+
+func TestPrincipalFromContext(t *testing.T) {
+	// Configuração
+	ctx := context.Background()
+
+	// Execução e verificações
+	assert.False(t, PrincipalFromContext(ctx).IsAuthenticated(), "Sem principal no contexto a requisição deve ser anônima")
+	assert.False(t, PrincipalFromContext(nil).IsAuthenticated())
+
+	ctx = WithPrincipal(ctx, Principal{Subject: "user-1"})
+	assert.Equal(t, "user-1", PrincipalFromContext(ctx).Subject, "O principal guardado deve ser retornado")
+}
@@ -0,0 +1,197 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+var (
+	ErrTransactionNotStarted     = errors.New("unit of work has no active transaction")
+	ErrTransactionAlreadyStarted = errors.New("unit of work already has an active transaction")
+	ErrTransactionFinished       = errors.New("transaction already finished")
+)
+
+// contextKey é a chave usada para guardar a unidade de trabalho corrente no contexto
+type contextKey struct{}
+
+// Transaction representa uma transação aberta em um armazenamento
+type Transaction interface {
+	Commit() error
+	Rollback() error
+}
+
+// TransactionProvider abre transações em um armazenamento (memória, SQL, ...)
+type TransactionProvider interface {
+	Begin(ctx context.Context) (Transaction, error)
+}
+
+// CommitListener pode ser implementado por repositórios que precisam agir após a confirmação da transação
+// (por exemplo, invalidar um cache)
+type CommitListener interface {
+	AfterCommit()
+}
+
+// RepositoryFactory cria um repositório vinculado à transação informada
+type RepositoryFactory func(tx Transaction) interface{}
+
+// UnitOfWork agrupa as operações de vários repositórios em uma única transação
+type UnitOfWork interface {
+	Begin(ctx context.Context) error
+	Commit() error
+	Rollback() error
+	Repository(repositoryType reflect.Type) (interface{}, error)
+}
+
+// Factory cria unidades de trabalho que compartilham as mesmas fábricas de repositórios
+type Factory interface {
+	New() UnitOfWork
+	AddRepositoryFactory(repositoryType reflect.Type, factory RepositoryFactory) Factory
+}
+
+type unitOfWorkFactory struct {
+	provider  TransactionProvider
+	factories map[reflect.Type]RepositoryFactory
+}
+
+// NewFactory cria uma nova fábrica de unidades de trabalho para o provedor informado
+func NewFactory(provider TransactionProvider) Factory {
+	return &unitOfWorkFactory{
+		provider:  provider,
+		factories: make(map[reflect.Type]RepositoryFactory),
+	}
+}
+
+// New cria uma nova unidade de trabalho, ainda sem transação aberta
+func (f *unitOfWorkFactory) New() UnitOfWork {
+	return &unitOfWork{
+		provider:     f.provider,
+		factories:    f.factories,
+		repositories: make(map[reflect.Type]interface{}),
+	}
+}
+
+// AddRepositoryFactory registra como criar um repositório vinculado à transação
+func (f *unitOfWorkFactory) AddRepositoryFactory(repositoryType reflect.Type, factory RepositoryFactory) Factory {
+	f.factories[repositoryType] = factory
+	return f
+}
+
+type unitOfWork struct {
+	provider     TransactionProvider
+	factories    map[reflect.Type]RepositoryFactory
+	transaction  Transaction
+	repositories map[reflect.Type]interface{}
+}
+
+// Begin abre a transação da unidade de trabalho
+func (u *unitOfWork) Begin(ctx context.Context) error {
+	if u.transaction != nil {
+		return ErrTransactionAlreadyStarted
+	}
+	transaction, err := u.provider.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	u.transaction = transaction
+	return nil
+}
+
+// Commit confirma a transação, notifica os repositórios que implementam CommitListener
+// e libera os repositórios vinculados a ela. Se o commit falhar, a transação continua aberta para o Rollback.
+func (u *unitOfWork) Commit() error {
+	if u.transaction == nil {
+		return ErrTransactionNotStarted
+	}
+	if err := u.transaction.Commit(); err != nil {
+		return err
+	}
+	repositories := u.repositories
+	u.reset()
+	for _, repository := range repositories {
+		if listener, ok := repository.(CommitListener); ok {
+			listener.AfterCommit()
+		}
+	}
+	return nil
+}
+
+// Rollback desfaz a transação e libera os repositórios vinculados a ela
+func (u *unitOfWork) Rollback() error {
+	if u.transaction == nil {
+		return ErrTransactionNotStarted
+	}
+	defer u.reset()
+	return u.transaction.Rollback()
+}
+
+// Repository retorna o repositório do tipo informado vinculado à transação corrente
+func (u *unitOfWork) Repository(repositoryType reflect.Type) (interface{}, error) {
+	if u.transaction == nil {
+		return nil, ErrTransactionNotStarted
+	}
+	if repository, ok := u.repositories[repositoryType]; ok {
+		return repository, nil
+	}
+	factory, ok := u.factories[repositoryType]
+	if !ok {
+		return nil, fmt.Errorf("no repository registered for type %s", repositoryType)
+	}
+	repository := factory(u.transaction)
+	u.repositories[repositoryType] = repository
+	return repository, nil
+}
+
+func (u *unitOfWork) reset() {
+	u.transaction = nil
+	u.repositories = make(map[reflect.Type]interface{})
+}
+
+// Funções auxiliares genéricas
+
+// AddRepository é uma função helper genérica para registrar fábricas de repositórios
+func AddRepository[T any](factory Factory, create func(tx Transaction) T) Factory {
+	repositoryType := reflect.TypeOf((*T)(nil)).Elem()
+	return factory.AddRepositoryFactory(repositoryType, func(tx Transaction) interface{} {
+		return create(tx)
+	})
+}
+
+// GetRepository é uma função helper genérica para obter repositórios da unidade de trabalho
+func GetRepository[T any](unitOfWork UnitOfWork) (T, error) {
+	var zero T
+	repositoryType := reflect.TypeOf((*T)(nil)).Elem()
+	repository, err := unitOfWork.Repository(repositoryType)
+	if err != nil {
+		return zero, err
+	}
+	typed, ok := repository.(T)
+	if !ok {
+		return zero, fmt.Errorf("repository registered for %s has unexpected type %T", repositoryType, repository)
+	}
+	return typed, nil
+}
+
+// NewContext retorna uma cópia do contexto com a unidade de trabalho informada
+func NewContext(ctx context.Context, unitOfWork UnitOfWork) context.Context {
+	return context.WithValue(ctx, contextKey{}, unitOfWork)
+}
+
+// FromContext retorna a unidade de trabalho corrente ou nil quando não há transação
+func FromContext(ctx context.Context) UnitOfWork {
+	if ctx == nil {
+		return nil
+	}
+	unitOfWork, _ := ctx.Value(contextKey{}).(UnitOfWork)
+	return unitOfWork
+}
+
+// ResolveRepository retorna o repositório da transação corrente ou fallback quando não há transação
+func ResolveRepository[T any](ctx context.Context, fallback T) (T, error) {
+	unitOfWork := FromContext(ctx)
+	if unitOfWork == nil {
+		return fallback, nil
+	}
+	return GetRepository[T](unitOfWork)
+}
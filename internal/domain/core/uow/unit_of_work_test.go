@@ -0,0 +1,201 @@
+package uow
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockTransaction é uma transação mock para os testes
+type MockTransaction struct {
+	CommitCalled   bool
+	RollbackCalled bool
+	ErrorToReturn  error
+}
+
+func (t *MockTransaction) Commit() error {
+	t.CommitCalled = true
+	return t.ErrorToReturn
+}
+
+func (t *MockTransaction) Rollback() error {
+	t.RollbackCalled = true
+	return t.ErrorToReturn
+}
+
+// MockTransactionProvider é um provedor de transações mock para os testes
+type MockTransactionProvider struct {
+	Transactions  []*MockTransaction
+	ErrorToReturn error
+}
+
+func (p *MockTransactionProvider) Begin(ctx context.Context) (Transaction, error) {
+	if p.ErrorToReturn != nil {
+		return nil, p.ErrorToReturn
+	}
+	transaction := &MockTransaction{}
+	p.Transactions = append(p.Transactions, transaction)
+	return transaction, nil
+}
+
+// MockRepository é um repositório de exemplo vinculado a uma transação
+type MockRepository interface {
+	Transaction() Transaction
+}
+
+type mockRepository struct {
+	transaction Transaction
+}
+
+func (r *mockRepository) Transaction() Transaction {
+	return r.transaction
+}
+
+// listenerRepository registra as notificações de commit
+type listenerRepository struct {
+	Committed int
+}
+
+func (r *listenerRepository) AfterCommit() {
+	r.Committed++
+}
+
+func newFactoryForTest(provider *MockTransactionProvider) Factory {
+	factory := NewFactory(provider)
+	AddRepository[MockRepository](factory, func(tx Transaction) MockRepository {
+		return &mockRepository{transaction: tx}
+	})
+	return factory
+}
+
+func TestUnitOfWork_BeginCommit(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	unitOfWork := newFactoryForTest(provider).New()
+
+	// Execução
+	err := unitOfWork.Begin(context.Background())
+	assert.NoError(t, err, "Begin não deve retornar erro")
+	repository, err := GetRepository[MockRepository](unitOfWork)
+	assert.NoError(t, err, "GetRepository não deve retornar erro com transação aberta")
+	err = unitOfWork.Commit()
+
+	// Verificações
+	assert.NoError(t, err, "Commit não deve retornar erro")
+	assert.Len(t, provider.Transactions, 1, "Uma transação deve ser aberta")
+	assert.Same(t, provider.Transactions[0], repository.Transaction(), "O repositório deve estar vinculado à transação aberta")
+	assert.True(t, provider.Transactions[0].CommitCalled, "Commit da transação deve ser chamado")
+	assert.False(t, provider.Transactions[0].RollbackCalled, "Rollback não deve ser chamado")
+}
+
+func TestUnitOfWork_Rollback(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	unitOfWork := newFactoryForTest(provider).New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+
+	// Execução
+	err := unitOfWork.Rollback()
+
+	// Verificações
+	assert.NoError(t, err, "Rollback não deve retornar erro")
+	assert.True(t, provider.Transactions[0].RollbackCalled, "Rollback da transação deve ser chamado")
+	assert.Equal(t, ErrTransactionNotStarted, unitOfWork.Commit(), "Após o rollback não deve haver transação ativa")
+}
+
+func TestUnitOfWork_RepositoryIsCachedPerTransaction(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	unitOfWork := newFactoryForTest(provider).New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+
+	// Execução
+	first, _ := GetRepository[MockRepository](unitOfWork)
+	second, _ := GetRepository[MockRepository](unitOfWork)
+	assert.NoError(t, unitOfWork.Commit())
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	third, _ := GetRepository[MockRepository](unitOfWork)
+
+	// Verificações
+	assert.Same(t, first, second, "O mesmo repositório deve ser reutilizado na mesma transação")
+	assert.NotSame(t, first, third, "Uma nova transação deve criar um novo repositório")
+}
+
+func TestUnitOfWork_NotifiesCommitListeners(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	factory := NewFactory(provider)
+	listener := &listenerRepository{}
+	AddRepository[*listenerRepository](factory, func(tx Transaction) *listenerRepository { return listener })
+	unitOfWork := factory.New()
+
+	// Execução - rollback não notifica
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	_, _ = GetRepository[*listenerRepository](unitOfWork)
+	assert.NoError(t, unitOfWork.Rollback())
+	assert.Equal(t, 0, listener.Committed, "Rollback não deve notificar os repositórios")
+
+	// Execução - commit notifica
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	_, _ = GetRepository[*listenerRepository](unitOfWork)
+	assert.NoError(t, unitOfWork.Commit())
+
+	// Verificações
+	assert.Equal(t, 1, listener.Committed, "Commit deve notificar os repositórios usados na transação")
+
+	// Falha no commit não notifica
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	_, _ = GetRepository[*listenerRepository](unitOfWork)
+	provider.Transactions[2].ErrorToReturn = errors.New("commit failed")
+	assert.Error(t, unitOfWork.Commit())
+	assert.Equal(t, 1, listener.Committed, "Uma falha no commit não deve notificar os repositórios")
+	_ = unitOfWork.Rollback()
+	assert.True(t, provider.Transactions[2].RollbackCalled, "A transação deve poder ser desfeita após uma falha no commit")
+}
+
+func TestUnitOfWork_Errors(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	unitOfWork := NewFactory(provider).New()
+
+	// Execução e verificações sem transação
+	_, err := GetRepository[MockRepository](unitOfWork)
+	assert.Equal(t, ErrTransactionNotStarted, err, "GetRepository deve falhar sem transação")
+	assert.Equal(t, ErrTransactionNotStarted, unitOfWork.Rollback(), "Rollback deve falhar sem transação")
+
+	// Execução e verificações com transação
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	assert.Equal(t, ErrTransactionAlreadyStarted, unitOfWork.Begin(context.Background()), "Begin não deve abrir transação aninhada")
+	_, err = GetRepository[MockRepository](unitOfWork)
+	assert.Error(t, err, "GetRepository deve falhar para repositórios não registrados")
+
+	// Erro do provedor
+	failingProvider := &MockTransactionProvider{ErrorToReturn: errors.New("connection refused")}
+	assert.Error(t, NewFactory(failingProvider).New().Begin(context.Background()), "Begin deve propagar o erro do provedor")
+}
+
+func TestFromContextAndResolveRepository(t *testing.T) {
+	// Configuração
+	provider := &MockTransactionProvider{}
+	unitOfWork := newFactoryForTest(provider).New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	fallback := &mockRepository{}
+	ctx := context.Background()
+
+	// Execução sem unidade de trabalho no contexto
+	repository, err := ResolveRepository[MockRepository](ctx, fallback)
+	assert.NoError(t, err)
+	assert.Same(t, fallback, repository, "Sem transação, o repositório padrão deve ser usado")
+	assert.Nil(t, FromContext(ctx), "FromContext deve retornar nil sem transação")
+
+	// Execução com unidade de trabalho no contexto
+	ctx = NewContext(ctx, unitOfWork)
+	repository, err = ResolveRepository[MockRepository](ctx, fallback)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Same(t, unitOfWork, FromContext(ctx), "FromContext deve retornar a unidade de trabalho corrente")
+	assert.Same(t, provider.Transactions[0], repository.Transaction(), "Com transação, o repositório vinculado deve ser usado")
+}
@@ -0,0 +1,25 @@
+package core
+
+import "strings"
+
+// Violation descreve um campo da requisição que não atende a uma regra de validação
+type Violation struct {
+	Field   string
+	Rule    string
+	Message string
+}
+
+// Violations agrupa as violações de uma requisição. Pode ser retornado pelo método Validate das requisições.
+type Violations []Violation
+
+func (v Violations) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, violation := range v {
+		if violation.Field == "" {
+			messages = append(messages, violation.Message)
+			continue
+		}
+		messages = append(messages, violation.Field+": "+violation.Message)
+	}
+	return "validation failed: " + strings.Join(messages, "; ")
+}
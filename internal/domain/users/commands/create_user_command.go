@@ -1,36 +1,50 @@
 package commands
 
 import (
+	"context"
 	"flickly/internal/domain/core"
-	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
 	"flickly/internal/domain/users/entities"
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
-	"github.com/gin-gonic/gin"
 )
 
 type CreateUserCommand struct {
-	Name     string `json:"name"`
-	Email    string `json:"email"`
-	Password string `json:"password"`
+	Name     string `json:"name" validate:"required,max=100"`
+	Email    string `json:"email" validate:"required,email,max=254"`
+	Password string `json:"password" validate:"required,min=8"`
+}
+
+// Transactional indica que o comando deve ser executado em uma única transação
+func (c CreateUserCommand) Transactional() bool {
+	return true
 }
 
 type CreateUserCommandHandler struct {
-	mediator       mediator.Mediator
 	userRepository repositories.IUserRepository
+	passwordHasher security.PasswordHasher
 }
 
 func NewCreateUserCommandHandler(serviceCollection utilities.IServiceCollection) *CreateUserCommandHandler {
 	return &CreateUserCommandHandler{
-		mediator:       utilities.GetService[mediator.Mediator](serviceCollection),
 		userRepository: utilities.GetService[repositories.IUserRepository](serviceCollection),
+		passwordHasher: utilities.GetService[security.PasswordHasher](serviceCollection),
 	}
 }
 
-func (h *CreateUserCommandHandler) Handle(c *gin.Context, request mediator.Request) (mediator.Response, error) {
-	command := request.(CreateUserCommand)
+func (h *CreateUserCommandHandler) Handle(ctx context.Context, command CreateUserCommand) (*entities.User, error) {
+	userRepository, err := uow.ResolveRepository(ctx, h.userRepository)
+	if err != nil {
+		return nil, err
+	}
+	passwordHash, err := h.passwordHasher.Hash(command.Password)
+	if err != nil {
+		return nil, err
+	}
 	user := entities.NewUser(command.Name, command.Email)
-	err := h.userRepository.CreateUser(user)
+	user.SetPasswordHash(passwordHash)
+	err = userRepository.CreateUser(user)
 	if err != nil {
 		return nil, core.ErrUserAlreadyExist(err)
 	}
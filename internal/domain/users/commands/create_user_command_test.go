@@ -1,22 +1,27 @@
 package commands
 
 import (
+	"context"
 	"errors"
 	"flickly/internal/domain/core"
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
 	"flickly/internal/domain/users/entities"
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
-	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
 
 // MockUserRepository é um mock do repositório de usuários para os testes
 type MockUserRepository struct {
-	CreateUserCalled bool
-	UserToReturn     *entities.User
-	ErrorToReturn    error
+	CreateUserCalled    bool
+	UpdateUserCalled    bool
+	UserToReturn        *entities.User
+	ErrorToReturn       error
+	UpdateErrorToReturn error
 }
 
 func (m *MockUserRepository) CreateUser(user *entities.User) error {
@@ -28,32 +33,33 @@ func (m *MockUserRepository) GetUserByEmail(email string) (*entities.User, error
 	return m.UserToReturn, m.ErrorToReturn
 }
 
-// MockMediator é um mock do mediator para os testes
-type MockMediator struct {
-	RegisterCalled   bool
-	SendCalled       bool
-	ResponseToReturn mediator.Response
-	ErrorToReturn    error
+func (m *MockUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return m.UserToReturn, m.ErrorToReturn
+}
+
+func (m *MockUserRepository) UpdateUser(user *entities.User) error {
+	m.UpdateUserCalled = true
+	return m.UpdateErrorToReturn
 }
 
-func (m *MockMediator) Register(requestName string, handler mediator.Handler) {
-	m.RegisterCalled = true
+// MockPasswordHasher gera hashes previsíveis para os testes
+type MockPasswordHasher struct{}
+
+func (h *MockPasswordHasher) Hash(password string) (string, error) {
+	return "hash:" + password, nil
 }
 
-func (m *MockMediator) Send(c *gin.Context, request mediator.Request) (mediator.Response, error) {
-	m.SendCalled = true
-	return m.ResponseToReturn, m.ErrorToReturn
+func (h *MockPasswordHasher) Verify(hash string, password string) bool {
+	return hash == "hash:"+password
 }
 
 // Criando um ServiceCollection com mocks para os testes
-func setupMockServices(mockRepo *MockUserRepository, mockMediator *MockMediator) utilities.IServiceCollection {
+func setupMockServices(mockRepo *MockUserRepository) utilities.IServiceCollection {
 	serviceCollection := utilities.NewServiceCollection()
 
 	// Registrar o mock do repositório
 	utilities.AddService[repositories.IUserRepository](serviceCollection, mockRepo)
-
-	// Registrar o mock do mediator
-	utilities.AddService[mediator.Mediator](serviceCollection, mockMediator)
+	utilities.AddService[security.PasswordHasher](serviceCollection, &MockPasswordHasher{})
 
 	return serviceCollection
 }
@@ -61,8 +67,7 @@ func setupMockServices(mockRepo *MockUserRepository, mockMediator *MockMediator)
 func TestNewCreateUserCommandHandler(t *testing.T) {
 	// Configuração
 	mockRepo := &MockUserRepository{}
-	mockMediator := &MockMediator{}
-	serviceCollection := setupMockServices(mockRepo, mockMediator)
+	serviceCollection := setupMockServices(mockRepo)
 
 	// Execução
 	handler := NewCreateUserCommandHandler(serviceCollection)
@@ -70,7 +75,6 @@ func TestNewCreateUserCommandHandler(t *testing.T) {
 	// Verificações
 	assert.NotNil(t, handler, "NewCreateUserCommandHandler deve retornar uma instância não nula")
 	assert.NotNil(t, handler.userRepository, "O repositório no handler deve ser inicializado")
-	assert.NotNil(t, handler.mediator, "O mediator no handler deve ser inicializado")
 }
 
 func TestHandle_Success(t *testing.T) {
@@ -78,29 +82,31 @@ func TestHandle_Success(t *testing.T) {
 	mockRepo := &MockUserRepository{
 		ErrorToReturn: nil,
 	}
-	mockMediator := &MockMediator{}
-	serviceCollection := setupMockServices(mockRepo, mockMediator)
+	serviceCollection := setupMockServices(mockRepo)
 
 	handler := NewCreateUserCommandHandler(serviceCollection)
 	command := CreateUserCommand{
-		Name:  "Test User",
-		Email: "test@example.com",
+		Name:     "Test User",
+		Email:    "test@example.com",
+		Password: "Senha@123",
 	}
 
 	// Execução
-	ginContext, _ := gin.CreateTestContext(nil)
-	response, err := handler.Handle(ginContext, command)
+	ctx := context.Background()
+	user, err := handler.Handle(ctx, command)
 
 	// Verificações
 	assert.NoError(t, err, "Handle não deve retornar erro quando o repositório retorna sucesso")
-	assert.NotNil(t, response, "Response não deve ser nil em caso de sucesso")
+	assert.NotNil(t, user, "Response não deve ser nil em caso de sucesso")
 
-	user, ok := response.(*entities.User)
-	assert.True(t, ok, "Response deve ser do tipo *entities.User")
 	assert.Equal(t, command.Name, user.Name, "O nome do usuário na resposta deve corresponder ao comando")
 	assert.Equal(t, command.Email, user.Email, "O email do usuário na resposta deve corresponder ao comando")
+	assert.Equal(t, "hash:Senha@123", user.PasswordHash, "Apenas o hash da senha deve ser guardado no usuário")
 
 	assert.True(t, mockRepo.CreateUserCalled, "O método CreateUser do repositório deve ser chamado")
+	events := user.Events()
+	assert.Len(t, events, 1, "O evento UserCreated deve ser registrado no agregado")
+	assert.Equal(t, entities.UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email}, events[0], "O evento deve conter os dados do usuário")
 }
 
 func TestHandle_Error(t *testing.T) {
@@ -109,8 +115,7 @@ func TestHandle_Error(t *testing.T) {
 	mockRepo := &MockUserRepository{
 		ErrorToReturn: mockError,
 	}
-	mockMediator := &MockMediator{}
-	serviceCollection := setupMockServices(mockRepo, mockMediator)
+	serviceCollection := setupMockServices(mockRepo)
 
 	handler := NewCreateUserCommandHandler(serviceCollection)
 	command := CreateUserCommand{
@@ -119,8 +124,8 @@ func TestHandle_Error(t *testing.T) {
 	}
 
 	// Execução
-	ginContext, _ := gin.CreateTestContext(nil)
-	response, err := handler.Handle(ginContext, command)
+	ctx := context.Background()
+	response, err := handler.Handle(ctx, command)
 
 	// Verificações
 	assert.Error(t, err, "Handle deve retornar erro quando o repositório retorna erro")
@@ -134,3 +139,62 @@ func TestHandle_Error(t *testing.T) {
 
 	assert.True(t, mockRepo.CreateUserCalled, "O método CreateUser do repositório deve ser chamado")
 }
+
+// MockTransaction é uma transação vazia para os testes
+type MockTransaction struct{}
+
+func (t *MockTransaction) Commit() error   { return nil }
+func (t *MockTransaction) Rollback() error { return nil }
+
+// MockTransactionProvider abre transações vazias para os testes
+type MockTransactionProvider struct{}
+
+func (p *MockTransactionProvider) Begin(ctx context.Context) (uow.Transaction, error) {
+	return &MockTransaction{}, nil
+}
+
+func TestCreateUserCommand_Transactional(t *testing.T) {
+	var request mediator.Request = CreateUserCommand{}
+	transactionalRequest, ok := request.(mediator.TransactionalRequest)
+	assert.True(t, ok, "CreateUserCommand deve implementar TransactionalRequest")
+	assert.True(t, transactionalRequest.Transactional(), "CreateUserCommand deve ser transacional")
+}
+
+func TestHandle_UsesTransactionRepository(t *testing.T) {
+	// Configuração
+	defaultRepo := &MockUserRepository{}
+	transactionRepo := &MockUserRepository{}
+	handler := NewCreateUserCommandHandler(setupMockServices(defaultRepo))
+
+	factory := uow.NewFactory(&MockTransactionProvider{})
+	uow.AddRepository[repositories.IUserRepository](factory, func(tx uow.Transaction) repositories.IUserRepository {
+		return transactionRepo
+	})
+	unitOfWork := factory.New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+
+	ctx := context.Background()
+	ctx = uow.NewContext(ctx, unitOfWork)
+
+	// Execução
+	_, err := handler.Handle(ctx, CreateUserCommand{Name: "Test User", Email: "test@example.com"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, transactionRepo.CreateUserCalled, "O repositório da transação deve ser usado")
+	assert.False(t, defaultRepo.CreateUserCalled, "O repositório padrão não deve ser usado dentro da transação")
+}
+
+func TestRequestTypes(t *testing.T) {
+	// Configuração
+	m := mediator.NewMediatR()
+	mediator.Register[CreateUserCommand, *entities.User](m, NewCreateUserCommandHandler(setupMockServices(&MockUserRepository{})))
+
+	// Execução
+	err := m.Validate(RequestTypes()...)
+
+	// Verificações
+	assert.ErrorIs(t, err, mediator.ErrHandlerNotFound, "UpdateUserCommand sem handler deve ser reportado")
+	assert.Contains(t, err.Error(), "UpdateUserCommand")
+	assert.NotContains(t, err.Error(), "CreateUserCommand", "CreateUserCommand possui handler")
+}
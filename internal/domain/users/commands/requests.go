@@ -0,0 +1,11 @@
+package commands
+
+import "reflect"
+
+// RequestTypes lista as requisições do módulo de usuários; cada uma deve ter exatamente um handler registrado
+func RequestTypes() []reflect.Type {
+	return []reflect.Type{
+		reflect.TypeFor[CreateUserCommand](),
+		reflect.TypeFor[UpdateUserCommand](),
+	}
+}
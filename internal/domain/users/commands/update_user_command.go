@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+type UpdateUserCommand struct {
+	ID    uuid.UUID `json:"id" validate:"required"`
+	Name  string    `json:"name" validate:"required,max=100"`
+	Email string    `json:"email" validate:"required,email,max=254"`
+	// ExpectedVersion é a versão lida pelo cliente (If-Match); zero aceita qualquer versão
+	ExpectedVersion int64 `json:"expectedVersion"`
+}
+
+// Transactional indica que o comando deve ser executado em uma única transação
+func (c UpdateUserCommand) Transactional() bool {
+	return true
+}
+
+type UpdateUserCommandHandler struct {
+	userRepository repositories.IUserRepository
+}
+
+func NewUpdateUserCommandHandler(serviceCollection utilities.IServiceCollection) *UpdateUserCommandHandler {
+	return &UpdateUserCommandHandler{
+		userRepository: utilities.GetService[repositories.IUserRepository](serviceCollection),
+	}
+}
+
+func (h *UpdateUserCommandHandler) Handle(ctx context.Context, command UpdateUserCommand) (*entities.User, error) {
+	userRepository, err := uow.ResolveRepository(ctx, h.userRepository)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := userRepository.GetUserByID(command.ID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, core.ErrUserNotFound(fmt.Errorf("user %s not found", command.ID))
+	}
+	if command.ExpectedVersion != 0 && command.ExpectedVersion != user.Version {
+		return nil, core.ErrPreconditionFailed(fmt.Errorf("user %s: expected version %d, found %d", command.ID, command.ExpectedVersion, user.Version))
+	}
+
+	renamed := user.Rename(command.Name)
+	emailChanged := user.ChangeEmail(command.Email)
+	// Sem alterações não há o que gravar, e a versão lida pelo cliente continua válida
+	if !renamed && !emailChanged {
+		return user, nil
+	}
+	user.Touch()
+	if err = userRepository.UpdateUser(user); err != nil {
+		var domainError *core.DomainError
+		if errors.As(err, &domainError) {
+			return nil, domainError
+		}
+		return nil, core.ErrUserAlreadyExist(err)
+	}
+	return user, nil
+}
@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/users/entities"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUpdateUserCommand_Transactional(t *testing.T) {
+	var request mediator.Request = UpdateUserCommand{}
+	transactionalRequest, ok := request.(mediator.TransactionalRequest)
+	assert.True(t, ok, "UpdateUserCommand deve implementar TransactionalRequest")
+	assert.True(t, transactionalRequest.Transactional(), "UpdateUserCommand deve ser transacional")
+}
+
+// loadedUser cria um usuário como se tivesse sido lido do repositório, sem eventos pendentes
+func loadedUser(name string, email string) *entities.User {
+	user := entities.NewUser(name, email)
+	user.PullEvents()
+	return user
+}
+
+func TestUpdateUserHandle_Success(t *testing.T) {
+	// Configuração
+	existing := loadedUser("Old Name", "old@example.com")
+	mockRepo := &MockUserRepository{UserToReturn: existing}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+	command := UpdateUserCommand{ID: existing.ID, Name: "New Name", Email: "new@example.com", ExpectedVersion: 1}
+
+	// Execução
+	ctx := context.Background()
+	user, err := handler.Handle(ctx, command)
+
+	// Verificações
+	assert.NoError(t, err, "Handle não deve retornar erro quando a versão corresponde")
+	assert.Equal(t, "New Name", user.Name, "O nome deve ser atualizado")
+	assert.Equal(t, "new@example.com", user.Email, "O email deve ser atualizado")
+	assert.NotNil(t, user.LastUpdateAt, "LastUpdateAt deve ser preenchido")
+	assert.True(t, mockRepo.UpdateUserCalled, "O método UpdateUser do repositório deve ser chamado")
+	assert.Equal(t, []core.DomainEvent{
+		entities.UserRenamed{UserID: existing.ID, OldName: "Old Name", NewName: "New Name"},
+		entities.UserEmailChanged{UserID: existing.ID, OldEmail: "old@example.com", NewEmail: "new@example.com"},
+	}, user.Events(), "As alterações de nome e email devem registrar os eventos UserRenamed e UserEmailChanged no agregado")
+}
+
+func TestUpdateUserHandle_SameEmail(t *testing.T) {
+	// Configuração
+	existing := loadedUser("Old Name", "same@example.com")
+	handler := NewUpdateUserCommandHandler(setupMockServices(&MockUserRepository{UserToReturn: existing}))
+
+	// Execução
+	ctx := context.Background()
+	_, err := handler.Handle(ctx, UpdateUserCommand{ID: existing.ID, Name: "New Name", Email: "same@example.com"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []core.DomainEvent{entities.UserRenamed{UserID: existing.ID, OldName: "Old Name", NewName: "New Name"}},
+		existing.Events(), "Nenhum evento de email deve ser registrado quando o email não muda")
+}
+
+func TestUpdateUserHandle_NoChanges(t *testing.T) {
+	// Configuração
+	existing := loadedUser("Same Name", "same@example.com")
+	mockRepo := &MockUserRepository{UserToReturn: existing}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+
+	// Execução
+	ctx := context.Background()
+	user, err := handler.Handle(ctx, UpdateUserCommand{ID: existing.ID, Name: "Same Name", Email: "same@example.com", ExpectedVersion: 1})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Same(t, existing, user, "O usuário atual deve ser retornado")
+	assert.False(t, mockRepo.UpdateUserCalled, "Uma atualização sem alterações não deve ser gravada")
+	assert.Nil(t, user.LastUpdateAt, "LastUpdateAt não deve mudar sem alterações")
+}
+
+func TestUpdateUserHandle_NoChangesWithPendingEvents(t *testing.T) {
+	// Configuração
+	existing := entities.NewUser("Same Name", "same@example.com")
+	mockRepo := &MockUserRepository{UserToReturn: existing}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+
+	// Execução
+	_, err := handler.Handle(context.Background(), UpdateUserCommand{ID: existing.ID, Name: "Same Name", Email: "same@example.com"})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.False(t, mockRepo.UpdateUserCalled, "Eventos anteriores do agregado não devem ser tratados como alterações")
+}
+
+func TestUpdateUserHandle_NotFound(t *testing.T) {
+	// Configuração
+	mockRepo := &MockUserRepository{}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+
+	// Execução
+	ctx := context.Background()
+	_, err := handler.Handle(ctx, UpdateUserCommand{Name: "Name"})
+
+	// Verificações
+	domainErr, ok := err.(*core.DomainError)
+	assert.True(t, ok, "Erro retornado deve ser do tipo *core.DomainError")
+	assert.Equal(t, 404, domainErr.StatusCode, "Usuário inexistente deve retornar 404")
+	assert.False(t, mockRepo.UpdateUserCalled, "UpdateUser não deve ser chamado")
+}
+
+func TestUpdateUserHandle_PreconditionFailed(t *testing.T) {
+	// Configuração
+	existing := loadedUser("Old Name", "old@example.com")
+	existing.Version = 3
+	mockRepo := &MockUserRepository{UserToReturn: existing}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+
+	// Execução
+	ctx := context.Background()
+	_, err := handler.Handle(ctx, UpdateUserCommand{ID: existing.ID, Name: "New", ExpectedVersion: 2})
+
+	// Verificações
+	domainErr, ok := err.(*core.DomainError)
+	assert.True(t, ok, "Erro retornado deve ser do tipo *core.DomainError")
+	assert.Equal(t, 412, domainErr.StatusCode, "Versão divergente deve retornar 412")
+	assert.False(t, mockRepo.UpdateUserCalled, "UpdateUser não deve ser chamado")
+}
+
+func TestUpdateUserHandle_RepositoryErrors(t *testing.T) {
+	// Configuração
+	existing := loadedUser("Old Name", "old@example.com")
+	conflict := core.ErrConcurrencyConflict(errors.New("version mismatch"))
+	mockRepo := &MockUserRepository{UserToReturn: existing, UpdateErrorToReturn: conflict}
+	handler := NewUpdateUserCommandHandler(setupMockServices(mockRepo))
+	ctx := context.Background()
+
+	// Execução e verificações - conflito de concorrência
+	_, err := handler.Handle(ctx, UpdateUserCommand{ID: existing.ID, Name: "New"})
+	assert.Same(t, conflict, err, "O conflito do repositório deve ser propagado")
+
+	// Execução e verificações - email duplicado
+	mockRepo.UpdateErrorToReturn = errors.New("user already exists")
+	_, err = handler.Handle(ctx, UpdateUserCommand{ID: existing.ID, Name: "Other"})
+	domainErr, ok := err.(*core.DomainError)
+	assert.True(t, ok, "Erro retornado deve ser do tipo *core.DomainError")
+	assert.Equal(t, 1, domainErr.Code, "Email duplicado deve retornar o código 1")
+}
+
+func TestUserCommands_Validation(t *testing.T) {
+	// Configuração
+	mediatR := mediator.NewMediatR(mediator.WithBehavior(mediator.ValidationBehavior()))
+	for _, requestType := range RequestTypes() {
+		mediatR.Register(requestType, validatedRequestHandler{})
+	}
+	valid := []mediator.Request{
+		CreateUserCommand{Name: "Nome", Email: "nome@example.com", Password: "Senha@123"},
+		UpdateUserCommand{ID: loadedUser("Nome", "nome@example.com").ID, Name: "Nome", Email: "nome@example.com"},
+	}
+	invalid := map[mediator.Request][]string{
+		CreateUserCommand{Email: "invalido", Password: "123"}: {"name", "email", "password"},
+		UpdateUserCommand{Name: "Nome", Email: "nome@"}:       {"id", "email"},
+	}
+
+	// Execução e verificações
+	for _, request := range valid {
+		_, err := mediatR.Send(context.Background(), request)
+		assert.NoError(t, err, "%s válido não deve ser rejeitado", reflect.TypeOf(request).Name())
+	}
+	for request, fields := range invalid {
+		_, err := mediatR.Send(context.Background(), request)
+		var domainErr *core.DomainError
+		assert.True(t, errors.As(err, &domainErr), "%s inválido deve ser rejeitado", reflect.TypeOf(request).Name())
+		var violatedFields []string
+		for _, violation := range domainErr.Violations {
+			violatedFields = append(violatedFields, violation.Field)
+		}
+		assert.Equal(t, fields, violatedFields, "Os campos inválidos de %s devem ser informados", reflect.TypeOf(request).Name())
+	}
+}
+
+// validatedRequestHandler aceita qualquer requisição que passe pela validação
+type validatedRequestHandler struct{}
+
+func (h validatedRequestHandler) Handle(ctx context.Context, request mediator.Request) (mediator.Response, error) {
+	return nil, nil
+}
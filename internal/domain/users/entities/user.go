@@ -6,14 +6,49 @@ import (
 
 type User struct {
 	core.Entity
+	core.AggregateRoot
 	Name  string `json:"name"`
 	Email string `json:"email"`
+	// PasswordHash é o hash da senha gerado por security.PasswordHasher
+	PasswordHash string `json:"-"`
 }
 
+// NewUser cria um novo usuário e registra o evento UserCreated
 func NewUser(name string, email string) *User {
-	return &User{
+	user := &User{
 		Entity: core.NewEntity(),
 		Name:   name,
 		Email:  email,
 	}
+	user.RecordEvent(UserCreated{UserID: user.ID, Name: user.Name, Email: user.Email})
+	return user
+}
+
+// ChangeEmail altera o email do usuário e registra o evento UserEmailChanged quando ele muda.
+// Retorna se o email foi alterado.
+func (u *User) ChangeEmail(email string) bool {
+	if email == u.Email {
+		return false
+	}
+	oldEmail := u.Email
+	u.Email = email
+	u.RecordEvent(UserEmailChanged{UserID: u.ID, OldEmail: oldEmail, NewEmail: email})
+	return true
+}
+
+// Rename altera o nome do usuário e registra o evento UserRenamed quando ele muda.
+// Retorna se o nome foi alterado.
+func (u *User) Rename(name string) bool {
+	if name == u.Name {
+		return false
+	}
+	oldName := u.Name
+	u.Name = name
+	u.RecordEvent(UserRenamed{UserID: u.ID, OldName: oldName, NewName: name})
+	return true
+}
+
+// SetPasswordHash define o hash da senha do usuário
+func (u *User) SetPasswordHash(passwordHash string) {
+	u.PasswordHash = passwordHash
 }
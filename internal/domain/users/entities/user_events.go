@@ -0,0 +1,36 @@
+package entities
+
+import "github.com/google/uuid"
+
+// UserCreated é publicado quando um novo usuário é cadastrado
+type UserCreated struct {
+	UserID uuid.UUID `json:"userId"`
+	Name   string    `json:"name"`
+	Email  string    `json:"email"`
+}
+
+func (e UserCreated) EventName() string {
+	return "user.created"
+}
+
+// UserEmailChanged é publicado quando o email de um usuário é alterado
+type UserEmailChanged struct {
+	UserID   uuid.UUID `json:"userId"`
+	OldEmail string    `json:"oldEmail"`
+	NewEmail string    `json:"newEmail"`
+}
+
+func (e UserEmailChanged) EventName() string {
+	return "user.email_changed"
+}
+
+// UserRenamed é publicado quando o nome de um usuário é alterado
+type UserRenamed struct {
+	UserID  uuid.UUID `json:"userId"`
+	OldName string    `json:"oldName"`
+	NewName string    `json:"newName"`
+}
+
+func (e UserRenamed) EventName() string {
+	return "user.renamed"
+}
@@ -0,0 +1,20 @@
+package entities
+
+import (
+	"flickly/internal/domain/core"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserEvents_EventName(t *testing.T) {
+	// Configuração
+	var created core.DomainEvent = UserCreated{}
+	var emailChanged core.DomainEvent = UserEmailChanged{}
+	var renamed core.DomainEvent = UserRenamed{}
+
+	// Verificações
+	assert.Equal(t, "user.created", created.EventName(), "Nome do evento de criação incorreto")
+	assert.Equal(t, "user.email_changed", emailChanged.EventName(), "Nome do evento de alteração de email incorreto")
+	assert.Equal(t, "user.renamed", renamed.EventName(), "Nome do evento de alteração de nome incorreto")
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"encoding/json"
+	"flickly/internal/domain/core"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
@@ -24,4 +26,59 @@ func TestNewUser(t *testing.T) {
 	assert.False(t, user.CreatedAt.IsZero(), "CreatedAt deve ser inicializado com a data atual")
 	assert.Nil(t, user.LastUpdateAt, "LastUpdateAt deve ser nulo para um novo usuário")
 	assert.Nil(t, user.DeletedAt, "DeletedAt deve ser nulo para um novo usuário")
-} 
\ No newline at end of file
+} 
+func TestNewUser_RecordsUserCreated(t *testing.T) {
+	// Execução
+	user := NewUser("Test User", "test@example.com")
+
+	// Verificações
+	assert.Equal(t, []core.DomainEvent{UserCreated{UserID: user.ID, Name: "Test User", Email: "test@example.com"}}, user.Events(),
+		"A criação do usuário deve registrar o evento UserCreated")
+}
+
+func TestUser_ChangeEmail(t *testing.T) {
+	// Configuração
+	user := NewUser("Test User", "old@example.com")
+	user.PullEvents()
+
+	// Execução
+	unchanged := user.ChangeEmail("old@example.com")
+	changed := user.ChangeEmail("new@example.com")
+
+	// Verificações
+	assert.False(t, unchanged, "O mesmo email não deve ser considerado uma alteração")
+	assert.True(t, changed, "Um novo email deve ser considerado uma alteração")
+	assert.Equal(t, "new@example.com", user.Email, "O email deve ser alterado")
+	assert.Equal(t, []core.DomainEvent{UserEmailChanged{UserID: user.ID, OldEmail: "old@example.com", NewEmail: "new@example.com"}}, user.Events(),
+		"Apenas a alteração efetiva do email deve registrar o evento UserEmailChanged")
+}
+
+func TestUser_Rename(t *testing.T) {
+	// Configuração
+	user := NewUser("Old Name", "test@example.com")
+	user.PullEvents()
+
+	// Execução
+	unchanged := user.Rename("Old Name")
+	changed := user.Rename("New Name")
+
+	// Verificações
+	assert.False(t, unchanged, "O mesmo nome não deve ser considerado uma alteração")
+	assert.True(t, changed, "Um novo nome deve ser considerado uma alteração")
+	assert.Equal(t, "New Name", user.Name, "O nome deve ser alterado")
+	assert.Equal(t, []core.DomainEvent{UserRenamed{UserID: user.ID, OldName: "Old Name", NewName: "New Name"}}, user.Events(),
+		"Apenas a alteração efetiva do nome deve registrar o evento UserRenamed")
+}
+
+func TestUser_DoesNotSerializePasswordHash(t *testing.T) {
+	// Configuração
+	user := NewUser("Test User", "test@example.com")
+	user.SetPasswordHash("hash")
+
+	// Execução
+	data, err := json.Marshal(user)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NotContains(t, string(data), "hash", "O hash da senha não deve ser serializado nas respostas, jobs e registros de idempotência")
+}
@@ -0,0 +1,40 @@
+package queries
+
+import (
+	"context"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/users/readmodels"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+type GetUserQuery struct {
+	ID uuid.UUID `json:"id" validate:"required"`
+}
+
+// ReadOnly indica que a consulta é executada sem transação e sem efeitos colaterais
+func (q GetUserQuery) ReadOnly() bool {
+	return true
+}
+
+type GetUserQueryHandler struct {
+	userViewRepository readmodels.IUserViewRepository
+}
+
+func NewGetUserQueryHandler(serviceCollection utilities.IServiceCollection) *GetUserQueryHandler {
+	return &GetUserQueryHandler{
+		userViewRepository: utilities.GetService[readmodels.IUserViewRepository](serviceCollection),
+	}
+}
+
+func (h *GetUserQueryHandler) Handle(ctx context.Context, query GetUserQuery) (*readmodels.UserView, error) {
+	view, err := h.userViewRepository.GetUserView(query.ID)
+	if err != nil {
+		return nil, err
+	}
+	if view == nil {
+		return nil, core.ErrUserNotFound(fmt.Errorf("user %s not found", query.ID))
+	}
+	return view, nil
+}
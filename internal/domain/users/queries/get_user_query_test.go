@@ -0,0 +1,93 @@
+package queries
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/readmodels"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/crosscutting/utilities"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockUserViewRepository guarda os modelos de leitura em memória
+type MockUserViewRepository struct {
+	Views         map[uuid.UUID]readmodels.UserView
+	ErrorToReturn error
+}
+
+func (m *MockUserViewRepository) GetUserView(id uuid.UUID) (*readmodels.UserView, error) {
+	view, ok := m.Views[id]
+	if !ok {
+		return nil, m.ErrorToReturn
+	}
+	return &view, m.ErrorToReturn
+}
+
+func (m *MockUserViewRepository) SaveUserView(view *readmodels.UserView) error {
+	if m.Views == nil {
+		m.Views = make(map[uuid.UUID]readmodels.UserView)
+	}
+	m.Views[view.ID] = *view
+	return m.ErrorToReturn
+}
+
+// MockUserRepository retorna sempre o mesmo usuário
+type MockUserRepository struct {
+	repositories.IUserRepository
+	UserToReturn *entities.User
+}
+
+func (m *MockUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return m.UserToReturn, nil
+}
+
+func setupMockServices(userRepository repositories.IUserRepository, userViewRepository readmodels.IUserViewRepository) utilities.IServiceCollection {
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[repositories.IUserRepository](serviceCollection, userRepository)
+	utilities.AddService[readmodels.IUserViewRepository](serviceCollection, userViewRepository)
+	return serviceCollection
+}
+
+func TestGetUserQuery_ReadOnly(t *testing.T) {
+	var request mediator.Request = GetUserQuery{}
+	query, ok := request.(mediator.Query)
+	assert.True(t, ok, "GetUserQuery deve implementar Query")
+	assert.True(t, query.ReadOnly(), "GetUserQuery deve ser somente leitura")
+}
+
+func TestGetUserQueryHandle(t *testing.T) {
+	// Configuração
+	view := readmodels.UserView{ID: uuid.New(), Name: "Nome", Email: "nome@example.com", Version: 2}
+	views := &MockUserViewRepository{Views: map[uuid.UUID]readmodels.UserView{view.ID: view}}
+	handler := NewGetUserQueryHandler(setupMockServices(&MockUserRepository{}, views))
+	ctx := context.Background()
+
+	// Execução
+	found, err := handler.Handle(ctx, GetUserQuery{ID: view.ID})
+	_, notFoundErr := handler.Handle(ctx, GetUserQuery{ID: uuid.New()})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, view, *found, "O modelo de leitura deve ser retornado")
+	var domainErr *core.DomainError
+	assert.True(t, errors.As(notFoundErr, &domainErr), "Usuário inexistente deve retornar um DomainError")
+	assert.Equal(t, 404, domainErr.StatusCode, "Usuário inexistente deve retornar 404")
+}
+
+func TestGetUserQueryHandle_RepositoryError(t *testing.T) {
+	// Configuração
+	expected := errors.New("connection refused")
+	handler := NewGetUserQueryHandler(setupMockServices(&MockUserRepository{}, &MockUserViewRepository{ErrorToReturn: expected}))
+
+	// Execução
+	_, err := handler.Handle(context.Background(), GetUserQuery{ID: uuid.New()})
+
+	// Verificações
+	assert.Equal(t, expected, err, "Erros do repositório devem ser propagados")
+}
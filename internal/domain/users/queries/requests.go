@@ -0,0 +1,10 @@
+package queries
+
+import "reflect"
+
+// RequestTypes lista as consultas do módulo de usuários; cada uma deve ter exatamente um handler registrado
+func RequestTypes() []reflect.Type {
+	return []reflect.Type{
+		reflect.TypeFor[GetUserQuery](),
+	}
+}
@@ -0,0 +1,56 @@
+package queries
+
+import (
+	"context"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/readmodels"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/crosscutting/utilities"
+	"github.com/google/uuid"
+)
+
+// UserProjection mantém o UserView atualizado a partir dos eventos de usuário.
+// Como os eventos são publicados após o commit, a projeção lê o estado confirmado do usuário,
+// o que também mantém a versão usada nas ETags. Ler o estado atual torna a projeção idempotente:
+// o relay do outbox entrega de novo cada evento, recuperando as publicações que falharam após o commit.
+type UserProjection struct {
+	userRepository     repositories.IUserRepository
+	userViewRepository readmodels.IUserViewRepository
+}
+
+func NewUserProjection(serviceCollection utilities.IServiceCollection) *UserProjection {
+	return &UserProjection{
+		userRepository:     utilities.GetService[repositories.IUserRepository](serviceCollection),
+		userViewRepository: utilities.GetService[readmodels.IUserViewRepository](serviceCollection),
+	}
+}
+
+// Subscribe registra a projeção nos eventos que alteram o usuário
+func (p *UserProjection) Subscribe(m mediator.Registry) {
+	mediator.Subscribe[entities.UserCreated](m, mediator.SubscriberFunc[entities.UserCreated](func(ctx context.Context, event entities.UserCreated) error {
+		return p.Project(event.UserID)
+	}))
+	mediator.Subscribe[entities.UserRenamed](m, mediator.SubscriberFunc[entities.UserRenamed](func(ctx context.Context, event entities.UserRenamed) error {
+		return p.Project(event.UserID)
+	}))
+	mediator.Subscribe[entities.UserEmailChanged](m, mediator.SubscriberFunc[entities.UserEmailChanged](func(ctx context.Context, event entities.UserEmailChanged) error {
+		return p.Project(event.UserID)
+	}))
+}
+
+// Project grava o modelo de leitura do usuário com o seu estado atual
+func (p *UserProjection) Project(id uuid.UUID) error {
+	user, err := p.userRepository.GetUserByID(id)
+	if err != nil || user == nil {
+		return err
+	}
+	return p.userViewRepository.SaveUserView(&readmodels.UserView{
+		ID:           user.ID,
+		Name:         user.Name,
+		Email:        user.Email,
+		CreatedAt:    user.CreatedAt,
+		LastUpdateAt: user.LastUpdateAt,
+		Version:      user.Version,
+	})
+}
@@ -0,0 +1,68 @@
+package queries
+
+import (
+	"context"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/users/entities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserProjection_Subscribe(t *testing.T) {
+	// Configuração
+	user := entities.NewUser("Nome", "nome@example.com")
+	users := &MockUserRepository{UserToReturn: user}
+	views := &MockUserViewRepository{}
+	mediatR := mediator.NewMediatR()
+	NewUserProjection(setupMockServices(users, views)).Subscribe(mediatR)
+	ctx := context.Background()
+
+	// Execução e verificações - criação
+	assert.NoError(t, mediatR.Publish(ctx, entities.UserCreated{UserID: user.ID}))
+	assert.Equal(t, "Nome", views.Views[user.ID].Name, "A criação deve gravar o modelo de leitura")
+	assert.Equal(t, int64(1), views.Views[user.ID].Version)
+
+	// Execução e verificações - alterações
+	user.Name = "Novo Nome"
+	user.Version = 2
+	assert.NoError(t, mediatR.Publish(ctx, entities.UserRenamed{UserID: user.ID}))
+	assert.Equal(t, "Novo Nome", views.Views[user.ID].Name, "A alteração de nome deve atualizar o modelo de leitura")
+	user.Email = "novo@example.com"
+	user.Version = 3
+	assert.NoError(t, mediatR.Publish(ctx, entities.UserEmailChanged{UserID: user.ID}))
+	assert.Equal(t, "novo@example.com", views.Views[user.ID].Email, "A alteração de email deve atualizar o modelo de leitura")
+	assert.Equal(t, int64(3), views.Views[user.ID].Version, "A versão do modelo de leitura deve acompanhar a do usuário")
+}
+
+func TestUserProjection_Project_MissingUser(t *testing.T) {
+	// Configuração
+	views := &MockUserViewRepository{}
+	projection := NewUserProjection(setupMockServices(&MockUserRepository{}, views))
+
+	// Execução
+	err := projection.Project(entities.NewUser("Nome", "nome@example.com").ID)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, views.Views, "Nada deve ser gravado para usuários inexistentes")
+}
+
+func TestUserProjection_RedeliveredFromOutbox(t *testing.T) {
+	// Configuração
+	user := entities.NewUser("Nome", "nome@example.com")
+	views := &MockUserViewRepository{}
+	mediatR := mediator.NewMediatR()
+	NewUserProjection(setupMockServices(&MockUserRepository{UserToReturn: user}, views)).Subscribe(mediatR)
+	message, _ := outbox.NewMessage(entities.UserCreated{UserID: user.ID})
+	ctx := context.Background()
+
+	// Execução
+	assert.NoError(t, mediatR.PublishMessage(ctx, *message))
+	assert.NoError(t, mediatR.PublishMessage(ctx, *message))
+
+	// Verificações
+	assert.Len(t, views.Views, 1, "Entregas repetidas pelo relay não devem duplicar o modelo de leitura")
+	assert.Equal(t, "Nome", views.Views[user.ID].Name, "O evento reentregue pelo relay deve gravar o modelo de leitura")
+}
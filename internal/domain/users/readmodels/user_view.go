@@ -0,0 +1,23 @@
+package readmodels
+
+import (
+	"github.com/google/uuid"
+	"time"
+)
+
+// UserView é o modelo de leitura de um usuário, mantido pela projeção a partir dos eventos de domínio
+type UserView struct {
+	ID           uuid.UUID  `json:"id"`
+	Name         string     `json:"name"`
+	Email        string     `json:"email"`
+	CreatedAt    time.Time  `json:"createdAt"`
+	LastUpdateAt *time.Time `json:"lastUpdateAt,omitempty"`
+	Version      int64      `json:"version"`
+}
+
+type IUserViewRepository interface {
+	// GetUserView retorna o modelo de leitura do usuário ou nil quando ele não existe
+	GetUserView(id uuid.UUID) (*UserView, error)
+	// SaveUserView insere ou substitui o modelo de leitura do usuário
+	SaveUserView(view *UserView) error
+}
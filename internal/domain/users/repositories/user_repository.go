@@ -2,9 +2,14 @@ package repositories
 
 import (
 	"flickly/internal/domain/users/entities"
+	"github.com/google/uuid"
 )
 
 type IUserRepository interface {
 	CreateUser(user *entities.User) error
 	GetUserByEmail(email string) (*entities.User, error)
+	GetUserByID(id uuid.UUID) (*entities.User, error)
+	// UpdateUser grava o usuário somente se sua versão ainda for a versão armazenada,
+	// retornando core.ErrConcurrencyConflict caso contrário. Em caso de sucesso a versão é incrementada.
+	UpdateUser(user *entities.User) error
 }
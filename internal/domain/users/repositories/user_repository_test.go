@@ -3,6 +3,7 @@ package repositories
 import (
 	"errors"
 	"flickly/internal/domain/users/entities"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -11,6 +12,8 @@ import (
 type MockUserRepository struct {
 	CreateUserCalled     bool
 	GetUserByEmailCalled bool
+	GetUserByIDCalled    bool
+	UpdateUserCalled     bool
 	UserToReturn         *entities.User
 	ErrorToReturn        error
 }
@@ -25,6 +28,16 @@ func (m *MockUserRepository) GetUserByEmail(email string) (*entities.User, error
 	return m.UserToReturn, m.ErrorToReturn
 }
 
+func (m *MockUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	m.GetUserByIDCalled = true
+	return m.UserToReturn, m.ErrorToReturn
+}
+
+func (m *MockUserRepository) UpdateUser(user *entities.User) error {
+	m.UpdateUserCalled = true
+	return m.ErrorToReturn
+}
+
 func TestIUserRepository_Interface(t *testing.T) {
 	// Teste para verificar se a implementação mock satisfaz a interface
 	var _ IUserRepository = (*MockUserRepository)(nil)
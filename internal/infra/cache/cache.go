@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Cache é o armazenamento chave/valor usado pelos decoradores de repositório
+type Cache interface {
+	// Get retorna o valor guardado e false quando a chave não existe ou expirou
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+	Delete(ctx context.Context, keys ...string) error
+}
+
+// Options define por quanto tempo os resultados ficam em cache
+type Options struct {
+	// TTL é a validade dos valores encontrados
+	TTL time.Duration
+	// NegativeTTL é a validade dos resultados vazios (cache negativo); zero desativa o cache negativo
+	NegativeTTL time.Duration
+}
+
+// DefaultOptions são as opções usadas quando nenhuma é informada
+var DefaultOptions = Options{
+	TTL:         5 * time.Minute,
+	NegativeTTL: 30 * time.Second,
+}
+
+// Load retorna o valor em cache para a chave ou o carrega com load, guardando o resultado.
+// Resultados nil também são guardados (cache negativo). Falhas do cache não impedem a leitura.
+func Load[T any](ctx context.Context, cache Cache, key string, options Options, load func() (*T, error)) (*T, error) {
+	if data, found, err := cache.Get(ctx, key); err == nil && found {
+		var value *T
+		if err = json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+	}
+
+	value, err := load()
+	if err != nil {
+		return nil, err
+	}
+	ttl := options.TTL
+	if value == nil {
+		ttl = options.NegativeTTL
+	}
+	if ttl > 0 {
+		if data, err := json.Marshal(value); err == nil {
+			_ = cache.Set(ctx, key, data, ttl)
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,91 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cachedValue struct {
+	Name string `json:"name"`
+}
+
+// failingCache simula um backend indisponível
+type failingCache struct{}
+
+func (failingCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	return nil, false, errors.New("cache unavailable")
+}
+
+func (failingCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	return errors.New("cache unavailable")
+}
+
+func (failingCache) Delete(ctx context.Context, keys ...string) error {
+	return errors.New("cache unavailable")
+}
+
+func TestLoad_CachesValues(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(10)
+	loads := 0
+	load := func() (*cachedValue, error) {
+		loads++
+		return &cachedValue{Name: "value"}, nil
+	}
+
+	// Execução
+	first, err := Load(context.Background(), cache, "key", DefaultOptions, load)
+	assert.NoError(t, err)
+	second, err := Load(context.Background(), cache, "key", DefaultOptions, load)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, 1, loads, "O valor deve ser carregado apenas uma vez")
+	assert.Equal(t, first, second, "O valor em cache deve ser igual ao carregado")
+}
+
+func TestLoad_NegativeCaching(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(10)
+	loads := 0
+	load := func() (*cachedValue, error) {
+		loads++
+		return nil, nil
+	}
+
+	// Execução
+	_, _ = Load(context.Background(), cache, "missing", DefaultOptions, load)
+	value, err := Load(context.Background(), cache, "missing", DefaultOptions, load)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Nil(t, value, "O resultado vazio deve ser retornado do cache")
+	assert.Equal(t, 1, loads, "Resultados vazios também devem ser guardados")
+
+	// Sem cache negativo o resultado vazio não é guardado
+	_, _ = Load(context.Background(), cache, "other", Options{TTL: time.Minute}, load)
+	_, _ = Load(context.Background(), cache, "other", Options{TTL: time.Minute}, load)
+	assert.Equal(t, 3, loads, "Com NegativeTTL zero os resultados vazios não devem ser guardados")
+}
+
+func TestLoad_Errors(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(10)
+	loadError := errors.New("database unavailable")
+
+	// Execução e verificações - erro da carga não é guardado
+	_, err := Load(context.Background(), cache, "key", DefaultOptions, func() (*cachedValue, error) { return nil, loadError })
+	assert.Equal(t, loadError, err, "O erro da carga deve ser propagado")
+	assert.Equal(t, 0, cache.Len(), "Erros não devem ser guardados")
+
+	// Execução e verificações - falha do cache não impede a leitura
+	value, err := Load(context.Background(), failingCache{}, "key", DefaultOptions, func() (*cachedValue, error) {
+		return &cachedValue{Name: "value"}, nil
+	})
+	assert.NoError(t, err, "Falhas do cache não devem impedir a leitura")
+	assert.Equal(t, "value", value.Name)
+}
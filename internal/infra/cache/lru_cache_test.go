@@ -0,0 +1,67 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLRUCache_SetGetDelete(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(10)
+	ctx := context.Background()
+
+	// Execução
+	assert.NoError(t, cache.Set(ctx, "a", []byte("1"), time.Minute))
+	value, found, err := cache.Get(ctx, "a")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, found, "A chave gravada deve ser encontrada")
+	assert.Equal(t, []byte("1"), value)
+
+	assert.NoError(t, cache.Delete(ctx, "a", "missing"))
+	_, found, _ = cache.Get(ctx, "a")
+	assert.False(t, found, "A chave removida não deve ser encontrada")
+}
+
+func TestLRUCache_Expiration(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(10)
+	now := time.Now()
+	cache.Now = func() time.Time { return now }
+	_ = cache.Set(context.Background(), "a", []byte("1"), time.Second)
+
+	// Execução
+	now = now.Add(time.Second)
+	_, found, _ := cache.Get(context.Background(), "a")
+
+	// Verificações
+	assert.False(t, found, "A chave deve expirar após o TTL")
+	assert.Equal(t, 0, cache.Len(), "A chave expirada deve ser descartada")
+}
+
+func TestLRUCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	// Configuração
+	cache := NewLRUCache(2)
+	ctx := context.Background()
+	_ = cache.Set(ctx, "a", []byte("1"), time.Minute)
+	_ = cache.Set(ctx, "b", []byte("2"), time.Minute)
+
+	// Execução
+	_, _, _ = cache.Get(ctx, "a")
+	_ = cache.Set(ctx, "c", []byte("3"), time.Minute)
+
+	// Verificações
+	assert.Equal(t, 2, cache.Len(), "O cache não deve exceder a capacidade")
+	_, found, _ := cache.Get(ctx, "b")
+	assert.False(t, found, "A chave usada há mais tempo deve ser descartada")
+	_, found, _ = cache.Get(ctx, "a")
+	assert.True(t, found, "A chave lida recentemente deve ser mantida")
+}
+
+func TestNewLRUCache_DefaultCapacity(t *testing.T) {
+	assert.Equal(t, DefaultLRUCapacity, NewLRUCache(0).capacity, "Capacidade não positiva deve usar o padrão")
+}
@@ -0,0 +1,186 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultRedisTimeout limita conexão e comandos quando o contexto não tem prazo
+const defaultRedisTimeout = 2 * time.Second
+
+// RedisCache é um Cache que usa um servidor compatível com o protocolo do Redis (RESP)
+type RedisCache struct {
+	address string
+	prefix  string
+	conn    net.Conn
+	reader  *bufio.Reader
+	mu      sync.Mutex
+}
+
+// NewRedisCache cria um cache para o servidor no endereço informado (host:porta ou redis://host:porta).
+// As chaves são gravadas com o prefixo informado.
+func NewRedisCache(address string, prefix string) *RedisCache {
+	return &RedisCache{
+		address: strings.TrimSuffix(strings.TrimPrefix(address, "redis://"), "/"),
+		prefix:  prefix,
+	}
+}
+
+func (c *RedisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	reply, err := c.do(ctx, "GET", c.prefix+key)
+	if err != nil {
+		return nil, false, err
+	}
+	if reply == nil {
+		return nil, false, nil
+	}
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected redis reply %v", reply)
+	}
+	return value, true, nil
+}
+
+func (c *RedisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	milliseconds := ttl.Milliseconds()
+	if milliseconds <= 0 {
+		milliseconds = 1
+	}
+	_, err := c.do(ctx, "SET", c.prefix+key, string(value), "PX", strconv.FormatInt(milliseconds, 10))
+	return err
+}
+
+func (c *RedisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	args := []string{"DEL"}
+	for _, key := range keys {
+		args = append(args, c.prefix+key)
+	}
+	_, err := c.do(ctx, args...)
+	return err
+}
+
+// Close encerra a conexão com o servidor
+func (c *RedisCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.closeConnection()
+	return nil
+}
+
+// do envia um comando e lê a resposta, reconectando na próxima chamada em caso de falha de rede
+func (c *RedisCache) do(ctx context.Context, args ...string) (interface{}, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if err := c.connect(ctx); err != nil {
+		return nil, err
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultRedisTimeout)
+	}
+	_ = c.conn.SetDeadline(deadline)
+
+	if _, err := c.conn.Write(encodeCommand(args)); err != nil {
+		c.closeConnection()
+		return nil, err
+	}
+	reply, err := readReply(c.reader)
+	var replyError redisError
+	if err != nil && !errors.As(err, &replyError) {
+		c.closeConnection()
+	}
+	return reply, err
+}
+
+func (c *RedisCache) connect(ctx context.Context) error {
+	if c.conn != nil {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: defaultRedisTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", c.address)
+	if err != nil {
+		return err
+	}
+	c.conn = conn
+	c.reader = bufio.NewReader(conn)
+	return nil
+}
+
+func (c *RedisCache) closeConnection() {
+	if c.conn != nil {
+		_ = c.conn.Close()
+	}
+	c.conn = nil
+	c.reader = nil
+}
+
+// redisError é um erro retornado pelo servidor; a conexão continua válida
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// encodeCommand codifica o comando como um array de bulk strings
+func encodeCommand(args []string) []byte {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "*%d\r\n", len(args))
+	for _, arg := range args {
+		fmt.Fprintf(&builder, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(builder.String())
+}
+
+// readReply lê uma resposta RESP: string simples, erro, inteiro, bulk string ou array
+func readReply(reader *bufio.Reader) (interface{}, error) {
+	line, err := reader.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, errors.New("empty redis reply")
+	}
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		size, err := strconv.Atoi(line[1:])
+		if err != nil || size < 0 {
+			return nil, err
+		}
+		data := make([]byte, size+2)
+		if _, err = io.ReadFull(reader, data); err != nil {
+			return nil, err
+		}
+		return data[:size], nil
+	case '*':
+		count, err := strconv.Atoi(line[1:])
+		if err != nil || count < 0 {
+			return nil, err
+		}
+		items := make([]interface{}, count)
+		for i := range items {
+			if items[i], err = readReply(reader); err != nil {
+				return nil, err
+			}
+		}
+		return items, nil
+	}
+	return nil, fmt.Errorf("unexpected redis reply %q", line)
+}
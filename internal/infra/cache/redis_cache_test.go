@@ -0,0 +1,147 @@
+package cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// redisStandIn é um servidor mínimo em memória compatível com o protocolo do Redis
+type redisStandIn struct {
+	listener net.Listener
+	mu       sync.Mutex
+	values   map[string][]byte
+	expires  map[string]time.Time
+}
+
+func newRedisStandIn(t *testing.T) *redisStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &redisStandIn{listener: listener, values: make(map[string][]byte), expires: make(map[string]time.Time)}
+	go server.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return server
+}
+
+func (s *redisStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *redisStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		items := reply.([]interface{})
+		args := make([]string, len(items))
+		for i, item := range items {
+			args[i] = string(item.([]byte))
+		}
+		fmt.Fprint(conn, s.execute(args))
+	}
+}
+
+func (s *redisStandIn) execute(args []string) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch strings.ToUpper(args[0]) {
+	case "GET":
+		value, ok := s.values[args[1]]
+		if !ok || !time.Now().Before(s.expires[args[1]]) {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		milliseconds, _ := strconv.Atoi(args[4])
+		s.values[args[1]] = []byte(args[2])
+		s.expires[args[1]] = time.Now().Add(time.Duration(milliseconds) * time.Millisecond)
+		return "+OK\r\n"
+	case "DEL":
+		deleted := 0
+		for _, key := range args[1:] {
+			if _, ok := s.values[key]; ok {
+				delete(s.values, key)
+				deleted++
+			}
+		}
+		return fmt.Sprintf(":%d\r\n", deleted)
+	}
+	return "-ERR unknown command '" + args[0] + "'\r\n"
+}
+
+func TestRedisCache_SetGetDelete(t *testing.T) {
+	// Configuração
+	server := newRedisStandIn(t)
+	cache := NewRedisCache("redis://"+server.listener.Addr().String(), "flickly:")
+	defer cache.Close()
+	ctx := context.Background()
+
+	// Execução
+	assert.NoError(t, cache.Set(ctx, "a", []byte("value\r\nwith newline"), time.Minute))
+	value, found, err := cache.Get(ctx, "a")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, found, "A chave gravada deve ser encontrada")
+	assert.Equal(t, []byte("value\r\nwith newline"), value, "O valor deve ser lido sem alterações")
+	server.mu.Lock()
+	assert.Contains(t, server.values, "flickly:a", "As chaves devem ser gravadas com o prefixo")
+	server.mu.Unlock()
+
+	assert.NoError(t, cache.Delete(ctx, "a", "missing"))
+	_, found, err = cache.Get(ctx, "a")
+	assert.NoError(t, err)
+	assert.False(t, found, "A chave removida não deve ser encontrada")
+}
+
+func TestRedisCache_Expiration(t *testing.T) {
+	// Configuração
+	server := newRedisStandIn(t)
+	cache := NewRedisCache(server.listener.Addr().String(), "")
+	defer cache.Close()
+	_ = cache.Set(context.Background(), "a", []byte("1"), time.Millisecond)
+
+	// Execução
+	time.Sleep(5 * time.Millisecond)
+	_, found, err := cache.Get(context.Background(), "a")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.False(t, found, "A chave deve expirar após o TTL")
+}
+
+func TestRedisCache_Errors(t *testing.T) {
+	// Configuração
+	server := newRedisStandIn(t)
+	cache := NewRedisCache(server.listener.Addr().String(), "")
+	defer cache.Close()
+
+	// Execução e verificações - erro do servidor mantém a conexão
+	_, err := cache.do(context.Background(), "FLUSHALL")
+	assert.Error(t, err, "Erros do servidor devem ser retornados")
+	assert.Contains(t, err.Error(), "unknown command")
+	assert.NotNil(t, cache.conn, "Erros do servidor não devem encerrar a conexão")
+
+	// Execução e verificações - servidor indisponível
+	_ = server.listener.Close()
+	unavailable := NewRedisCache(server.listener.Addr().String(), "")
+	_, _, err = unavailable.Get(context.Background(), "a")
+	assert.Error(t, err, "Get deve falhar quando o servidor está indisponível")
+}
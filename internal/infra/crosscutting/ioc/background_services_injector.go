@@ -0,0 +1,30 @@
+package ioc
+
+import (
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
+)
+
+// InjectBackgroundServices registra os executores em segundo plano, iniciados e parados com o contêiner:
+// o relay do outbox, o worker de jobs e os executores de sagas e de tarefas agendadas
+func InjectBackgroundServices(serviceCollection utilities.IServiceCollection) {
+	// O relay também entrega aos handlers de notificação, como as projeções, os eventos que a publicação logo
+	// após o commit não marcou como tratados, para que eles os recebam ao menos uma vez
+	utilities.AddConstructor[*messaging.Relay](serviceCollection, utilities.Singleton,
+		func(store outbox.Store, sink messaging.Sink, mediatR mediator.Mediator) *messaging.Relay {
+			relay := messaging.NewRelay(store, sink)
+			relay.Handlers = messaging.NewMediatorSink(mediatR)
+			return relay
+		})
+	utilities.AddConstructor[*messaging.Worker](serviceCollection, utilities.Singleton,
+		func(queue jobs.Queue, mediatR mediator.Mediator, collection utilities.IServiceCollection) *messaging.Worker {
+			worker := messaging.NewWorker(queue, mediatR)
+			worker.Services = collection
+			return worker
+		})
+	utilities.AddConstructor[*messaging.SagaRunner](serviceCollection, utilities.Singleton, messaging.NewSagaRunner)
+	utilities.AddConstructor[*messaging.ScheduleRunner](serviceCollection, utilities.Singleton, messaging.NewScheduleRunner)
+}
@@ -0,0 +1,35 @@
+package ioc
+
+import (
+	"context"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectBackgroundServices(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	InjectServices(serviceCollection)
+	utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
+
+	// Execução
+	InjectBackgroundServices(serviceCollection)
+
+	// Verificações
+	assert.NoError(t, serviceCollection.Build(), "As dependências dos executores devem estar registradas")
+	assert.NotNil(t, utilities.GetService[*messaging.Relay](serviceCollection), "O relay do outbox deve ser registrado")
+	assert.NotNil(t, utilities.GetService[*messaging.SagaRunner](serviceCollection), "O executor de sagas deve ser registrado")
+	assert.NotNil(t, utilities.GetService[*messaging.ScheduleRunner](serviceCollection), "O executor de tarefas agendadas deve ser registrado")
+	worker := utilities.GetService[*messaging.Worker](serviceCollection)
+	assert.Same(t, serviceCollection, worker.Services, "O worker deve criar os escopos dos jobs a partir do contêiner")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	assert.NoError(t, serviceCollection.Start(ctx), "Os executores devem ser iniciados")
+	assert.NoError(t, serviceCollection.Stop(ctx), "Os executores devem ser parados dentro do prazo")
+}
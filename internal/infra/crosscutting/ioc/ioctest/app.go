@@ -0,0 +1,96 @@
+// Package ioctest monta a aplicação com a configuração de produção para testes, permitindo substituir serviços
+// específicos sem alterar o contêiner original.
+package ioctest
+
+import (
+	"context"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/ioc"
+	"flickly/internal/infra/crosscutting/modules"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// overridesModuleName é o módulo que registra os substitutos, depois de todos os módulos da aplicação
+const overridesModuleName = "overrides"
+
+// tokenSecret assina os tokens de acesso emitidos nos testes, no lugar de TOKEN_SECRET
+const tokenSecret = "ioctest-token-secret"
+
+// passwordIterations reduz o custo do hash das senhas, que em produção tornaria os testes lentos
+const passwordIterations = 1000
+
+// App é a aplicação completa montada para testes
+type App struct {
+	Router   *gin.Engine
+	Services utilities.IServiceCollection
+}
+
+// NewApp monta a aplicação com todos os módulos, em memória, e os mesmos middlewares e rotas de produção, com um
+// segredo fixo para os tokens e um hash de senhas mais barato. Os overrides registram os substitutos depois dos
+// serviços dos módulos e antes da validação do contêiner e do registro dos handlers, que passam a usá-los. Os serviços criados são parados ao fim do teste; os executores em
+// segundo plano só rodam se o teste chamar Services.Start.
+func NewApp(t testing.TB, overrides ...func(serviceCollection utilities.IServiceCollection)) *App {
+	t.Helper()
+	applicationModules := ioc.Modules()
+	dependsOn := make([]string, 0, len(applicationModules))
+	for _, module := range applicationModules {
+		dependsOn = append(dependsOn, module.Name)
+	}
+	catalog := modules.NewCatalog(applicationModules...)
+	catalog.Register(modules.Module{
+		Name:      overridesModuleName,
+		DependsOn: dependsOn,
+		Services: func(serviceCollection utilities.IServiceCollection) {
+			utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret(tokenSecret))
+			utilities.AddService[security.PasswordHasher](serviceCollection, &infrasecurity.PBKDF2PasswordHasher{Iterations: passwordIterations})
+			for _, override := range overrides {
+				override(serviceCollection)
+			}
+		},
+	})
+	enabled, err := catalog.Resolve(nil)
+	if err != nil {
+		t.Fatalf("Erro ao resolver os módulos: %v", err)
+	}
+
+	serviceCollection := utilities.NewServiceCollection()
+	t.Cleanup(func() { stop(t, serviceCollection) })
+	if err := modules.Setup(serviceCollection, nil, enabled); err != nil {
+		t.Fatalf("Erro ao configurar os módulos: %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	ioc.InjectRouter(router, serviceCollection, enabled)
+	return &App{Router: router, Services: serviceCollection}
+}
+
+// Do executa a requisição na aplicação e retorna a resposta gravada
+func (a *App) Do(request *http.Request) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	a.Router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+// Override cria um contêiner filho de serviceCollection com os substitutos registrados por override. O contêiner
+// original não é alterado, e os serviços criados no filho são parados ao fim do teste.
+func Override(t testing.TB, serviceCollection utilities.IServiceCollection, override func(child utilities.IServiceCollection)) utilities.IServiceCollection {
+	t.Helper()
+	child := serviceCollection.CreateChild()
+	override(child)
+	t.Cleanup(func() { stop(t, child) })
+	return child
+}
+
+// stop para os serviços criados pelo contêiner
+func stop(t testing.TB, serviceCollection utilities.IServiceCollection) {
+	if err := serviceCollection.Stop(context.Background()); err != nil {
+		t.Errorf("Erro ao parar os serviços: %v", err)
+	}
+}
@@ -0,0 +1,59 @@
+package ioctest
+
+import (
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockTokenServiceForTest aceita o token "admin" como administrador
+type MockTokenServiceForTest struct{}
+
+func (s *MockTokenServiceForTest) Issue(principal security.Principal) (string, time.Duration, error) {
+	return "admin", time.Hour, nil
+}
+
+func (s *MockTokenServiceForTest) Validate(token string) (security.Principal, error) {
+	if token == "admin" {
+		return security.Principal{Subject: "admin", Roles: []string{security.RoleAdmin}}, nil
+	}
+	return security.Principal{}, security.ErrInvalidToken
+}
+
+func TestNewApp(t *testing.T) {
+	// Configuração
+	request := httptest.NewRequest(http.MethodGet, "/admin/services", nil)
+	request.Header.Set("Authorization", "Bearer admin")
+
+	// Execução
+	production := NewApp(t)
+	overridden := NewApp(t, func(serviceCollection utilities.IServiceCollection) {
+		utilities.AddService[security.TokenService](serviceCollection, &MockTokenServiceForTest{})
+	})
+
+	// Verificações
+	assert.Equal(t, http.StatusOK, production.Do(httptest.NewRequest(http.MethodGet, "/health", nil)).Code, "A aplicação deve registrar as rotas dos módulos")
+	assert.Equal(t, http.StatusUnauthorized, production.Do(request.Clone(request.Context())).Code, "Sem substitutos deve ser usado o serviço de tokens de produção")
+	assert.Equal(t, http.StatusOK, overridden.Do(request.Clone(request.Context())).Code, "Os middlewares devem usar o serviço substituto")
+	assert.NoError(t, overridden.Services.Build())
+}
+
+func TestOverride(t *testing.T) {
+	// Configuração
+	app := NewApp(t)
+	original := utilities.GetService[security.TokenService](app.Services)
+
+	// Execução
+	child := Override(t, app.Services, func(child utilities.IServiceCollection) {
+		utilities.AddService[security.TokenService](child, &MockTokenServiceForTest{})
+	})
+
+	// Verificações
+	assert.IsType(t, &MockTokenServiceForTest{}, utilities.GetService[security.TokenService](child), "O filho deve usar o substituto")
+	assert.Same(t, original, utilities.GetService[security.TokenService](app.Services), "O contêiner original não deve ser alterado")
+}
@@ -3,11 +3,22 @@ package ioc
 import (
 	"flickly/internal/domain/core/mediator"
 	"flickly/internal/domain/users/commands"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/queries"
+	"flickly/internal/domain/users/readmodels"
 	"flickly/internal/infra/crosscutting/utilities"
 )
 
-func InjectMediatorHandlers(serviceCollection utilities.IServiceCollection) {
-	mediatR := utilities.GetService[mediator.Mediator](serviceCollection)
+// InjectMediatorHandlers registra os handlers das requisições e as projeções dos modelos de leitura,
+// e valida que cada requisição conhecida tem exatamente um handler
+func InjectMediatorHandlers(serviceCollection utilities.IServiceCollection) error {
+	mediatR := utilities.GetService[mediator.Registry](serviceCollection)
 
-	mediatR.Register("CreateUserCommand", commands.NewCreateUserCommandHandler(serviceCollection))
+	mediator.Register[commands.CreateUserCommand, *entities.User](mediatR, commands.NewCreateUserCommandHandler(serviceCollection))
+	mediator.Register[commands.UpdateUserCommand, *entities.User](mediatR, commands.NewUpdateUserCommandHandler(serviceCollection))
+	mediator.Register[queries.GetUserQuery, *readmodels.UserView](mediatR, queries.NewGetUserQueryHandler(serviceCollection))
+
+	queries.NewUserProjection(serviceCollection).Subscribe(mediatR)
+
+	return mediatR.Validate(append(commands.RequestTypes(), queries.RequestTypes()...)...)
 }
@@ -2,31 +2,39 @@ package ioc
 
 import (
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/users/commands"
 	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/queries"
 	"flickly/internal/domain/users/repositories"
 	"flickly/internal/infra/crosscutting/utilities"
-	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"reflect"
 	"testing"
 )
 
-// MockMediatorForTest é um mock do mediator para testar o injetor de handlers
-type MockMediatorForTest struct {
-	RegisteredHandlers map[string]mediator.Handler
+// MockRegistryForTest é um mock do mediator.Registry para testar o injetor de handlers
+type MockRegistryForTest struct {
+	RegisteredHandlers      map[reflect.Type]mediator.Handler
+	SubscribedNotifications []reflect.Type
 }
 
-func NewMockMediatorForTest() *MockMediatorForTest {
-	return &MockMediatorForTest{
-		RegisteredHandlers: make(map[string]mediator.Handler),
+func NewMockRegistryForTest() *MockRegistryForTest {
+	return &MockRegistryForTest{
+		RegisteredHandlers: make(map[reflect.Type]mediator.Handler),
 	}
 }
 
-func (m *MockMediatorForTest) Register(requestName string, handler mediator.Handler) {
-	m.RegisteredHandlers[requestName] = handler
+func (m *MockRegistryForTest) Register(requestType reflect.Type, handler mediator.Handler) {
+	m.RegisteredHandlers[requestType] = handler
 }
 
-func (m *MockMediatorForTest) Send(c *gin.Context, request mediator.Request) (mediator.Response, error) {
-	return nil, nil
+func (m *MockRegistryForTest) Validate(requestTypes ...reflect.Type) error {
+	return nil
+}
+
+func (m *MockRegistryForTest) Subscribe(notificationType reflect.Type, handler mediator.NotificationHandler) {
+	m.SubscribedNotifications = append(m.SubscribedNotifications, notificationType)
 }
 
 // MockUserRepositoryForTest é um mock do repositório de usuários para testes
@@ -40,22 +48,56 @@ func (m *MockUserRepositoryForTest) GetUserByEmail(email string) (*entities.User
 	return nil, nil
 }
 
+func (m *MockUserRepositoryForTest) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return nil, nil
+}
+
+func (m *MockUserRepositoryForTest) UpdateUser(user *entities.User) error {
+	return nil
+}
+
 func TestInjectMediatorHandlers(t *testing.T) {
 	// Configuração
 	serviceCollection := utilities.NewServiceCollection()
-	mockMediator := NewMockMediatorForTest()
+	mockRegistry := NewMockRegistryForTest()
 	mockUserRepo := &MockUserRepositoryForTest{}
 
-	// Registrar o mediator mock e o repositório necessário para os handlers
-	utilities.AddService[mediator.Mediator](serviceCollection, mockMediator)
+	// Registrar o registro de handlers mock e o repositório necessário para os handlers
+	utilities.AddService[mediator.Registry](serviceCollection, mockRegistry)
 	utilities.AddService[repositories.IUserRepository](serviceCollection, mockUserRepo)
 
 	// Execução
-	InjectMediatorHandlers(serviceCollection)
+	err := InjectMediatorHandlers(serviceCollection)
 
 	// Verificações
+	assert.NoError(t, err)
 	// Verificar se o handler do CreateUserCommand foi registrado
-	handler, exists := mockMediator.RegisteredHandlers["CreateUserCommand"]
+	handler, exists := mockRegistry.RegisteredHandlers[reflect.TypeFor[commands.CreateUserCommand]()]
 	assert.True(t, exists, "O handler de CreateUserCommand deve ser registrado")
 	assert.NotNil(t, handler, "O handler registrado não deve ser nulo")
+
+	// Verificar se o handler do UpdateUserCommand foi registrado
+	handler, exists = mockRegistry.RegisteredHandlers[reflect.TypeFor[commands.UpdateUserCommand]()]
+	assert.True(t, exists, "O handler de UpdateUserCommand deve ser registrado")
+	assert.NotNil(t, handler, "O handler registrado não deve ser nulo")
+
+	// Verificar se o handler do GetUserQuery e a projeção de usuários foram registrados
+	_, exists = mockRegistry.RegisteredHandlers[reflect.TypeFor[queries.GetUserQuery]()]
+	assert.True(t, exists, "O handler de GetUserQuery deve ser registrado")
+	assert.Contains(t, mockRegistry.SubscribedNotifications, reflect.TypeFor[entities.UserCreated](), "A projeção deve receber UserCreated")
+	assert.Contains(t, mockRegistry.SubscribedNotifications, reflect.TypeFor[entities.UserRenamed](), "A projeção deve receber UserRenamed")
+	assert.Contains(t, mockRegistry.SubscribedNotifications, reflect.TypeFor[entities.UserEmailChanged](), "A projeção deve receber UserEmailChanged")
+}
+
+func TestInjectMediatorHandlers_ValidatesRegistrations(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	InjectServices(serviceCollection)
+
+	// Execução
+	err := InjectMediatorHandlers(serviceCollection)
+
+	// Verificações
+	assert.NoError(t, err, "Cada requisição conhecida deve ter exatamente um handler")
+	assert.ErrorIs(t, InjectMediatorHandlers(serviceCollection), mediator.ErrDuplicateHandler, "Registrar os handlers duas vezes deve ser reportado")
 }
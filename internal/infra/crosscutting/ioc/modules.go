@@ -0,0 +1,68 @@
+package ioc
+
+import (
+	"flickly/internal/api/admin"
+	"flickly/internal/api/commons/auto_mapper"
+	"flickly/internal/api/flickly"
+	jobsapi "flickly/internal/api/jobs"
+	"flickly/internal/api/users"
+	"flickly/internal/infra/crosscutting/modules"
+	"flickly/internal/infra/crosscutting/utilities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Nomes dos módulos da aplicação
+const (
+	CoreModuleName  = "core"
+	AdminModuleName = "admin"
+	UsersModuleName = "users"
+)
+
+// Modules retorna os módulos da aplicação, na ordem de registro
+func Modules() []modules.Module {
+	return []modules.Module{CoreModule(), AdminModule(), UsersModule()}
+}
+
+// CoreModule é a infraestrutura compartilhada: mapeador, mediator, outbox, jobs, sagas, agendamentos,
+// segurança e executores em segundo plano, além das rotas de sistema e de consulta de jobs
+func CoreModule() modules.Module {
+	return modules.Module{
+		Name: CoreModuleName,
+		Services: func(serviceCollection utilities.IServiceCollection) {
+			utilities.AddService[utilities.Mapper](serviceCollection, utilities.NewAutoMapper())
+			InjectCoreServices(serviceCollection)
+			InjectBackgroundServices(serviceCollection)
+		},
+		Migrations:  CoreSQLMigrations(),
+		SQLServices: InjectCoreSQLServices,
+		Mappings:    auto_mapper.ViewModelAutomapperConfig,
+		Handlers:    InjectScheduledTasks,
+		Routes: func(router *gin.Engine, serviceCollection utilities.IServiceCollection) {
+			flickly.Startup(router)
+			jobsapi.Startup(router, serviceCollection)
+		},
+	}
+}
+
+// AdminModule expõe as rotas administrativas de consulta da infraestrutura
+func AdminModule() modules.Module {
+	return modules.Module{
+		Name:      AdminModuleName,
+		DependsOn: []string{CoreModuleName},
+		Routes:    admin.Startup,
+	}
+}
+
+// UsersModule é o contexto de usuários: repositórios, modelo de leitura, handlers e rotas
+func UsersModule() modules.Module {
+	return modules.Module{
+		Name:        UsersModuleName,
+		DependsOn:   []string{CoreModuleName},
+		Services:    InjectUserServices,
+		Migrations:  UserSQLMigrations(),
+		SQLServices: InjectUserSQLServices,
+		Handlers:    InjectMediatorHandlers,
+		Routes:      users.Startup,
+	}
+}
@@ -0,0 +1,80 @@
+package ioc
+
+import (
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/users/commands"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/crosscutting/modules"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestModules(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
+	enabled, err := modules.NewCatalog(Modules()...).Resolve(nil)
+	assert.NoError(t, err)
+
+	// Execução
+	err = modules.Setup(serviceCollection, nil, enabled)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	modules.MapRoutes(router, serviceCollection, enabled)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	// Verificações
+	assert.NoError(t, err, "Os módulos da aplicação devem ser configurados sem erros")
+	assert.NotNil(t, utilities.GetService[repositories.IUserRepository](serviceCollection), "O módulo de usuários deve registrar o repositório")
+	assert.NoError(t, utilities.GetService[mediator.Mediator](serviceCollection).Validate(commands.RequestTypes()...), "O módulo de usuários deve registrar os handlers")
+	assert.Equal(t, http.StatusOK, w.Code, "O núcleo deve registrar as rotas de sistema")
+}
+
+func TestModulesWithoutUsers(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
+	enabled, err := modules.NewCatalog(Modules()...).Resolve(modules.DisabledBy(UsersModuleName))
+	assert.NoError(t, err)
+
+	// Execução
+	err = modules.Setup(serviceCollection, nil, enabled)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	modules.MapRoutes(router, serviceCollection, enabled)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/user", nil))
+
+	// Verificações
+	assert.NoError(t, err, "O núcleo não deve depender do módulo de usuários")
+	assert.Nil(t, utilities.GetService[repositories.IUserRepository](serviceCollection), "O repositório de usuários não deve ser registrado")
+	assert.Equal(t, http.StatusNotFound, w.Code, "As rotas de usuários não devem ser registradas")
+}
+
+func TestModulesRequireTokenSecret(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	enabled, err := modules.NewCatalog(Modules()...).Resolve(nil)
+	assert.NoError(t, err)
+
+	// Execução
+	err = modules.Setup(serviceCollection, nil, enabled)
+
+	// Verificações
+	assert.ErrorContains(t, err, "TokenSecret", "A aplicação não deve iniciar sem o segredo dos tokens")
+}
+
+func TestModulesCoreCannotBeDisabled(t *testing.T) {
+	// Execução
+	_, err := modules.NewCatalog(Modules()...).Resolve(modules.DisabledBy(CoreModuleName))
+
+	// Verificações
+	assert.ErrorIs(t, err, modules.ErrModuleDisabled, "Desativar o núcleo deve ser reportado enquanto houver módulos dependentes")
+}
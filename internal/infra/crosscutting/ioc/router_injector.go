@@ -0,0 +1,20 @@
+package ioc
+
+import (
+	"flickly/internal/api/commons/middlewares"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/modules"
+	"flickly/internal/infra/crosscutting/utilities"
+
+	"github.com/gin-gonic/gin"
+)
+
+// InjectRouter registra os middlewares da aplicação e, em seguida, as rotas dos módulos. O contêiner já deve
+// ter sido configurado com modules.Setup.
+func InjectRouter(router *gin.Engine, serviceCollection utilities.IServiceCollection, enabled []modules.Module) {
+	router.Use(middlewares.CorrelationID(), middlewares.Authentication(utilities.GetService[security.TokenService](serviceCollection)),
+		middlewares.ServiceScope(serviceCollection),
+		middlewares.Idempotency(utilities.GetService[idempotency.Store](serviceCollection), idempotency.DefaultTTL))
+	modules.MapRoutes(router, serviceCollection, enabled)
+}
@@ -0,0 +1,29 @@
+package ioc
+
+import (
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/infra/crosscutting/utilities"
+)
+
+// Expressões das tarefas agendadas da aplicação
+const (
+	purgeIdempotencyRecordsSpec = "@hourly"
+)
+
+// InjectScheduledTasks registra as tarefas periódicas no agendador e os handlers das requisições agendadas,
+// e valida que cada requisição agendada tem handler
+func InjectScheduledTasks(serviceCollection utilities.IServiceCollection) error {
+	mediatR := utilities.GetService[mediator.Registry](serviceCollection)
+	scheduler := utilities.GetService[*schedule.Scheduler](serviceCollection)
+
+	if purger, ok := utilities.GetService[idempotency.Store](serviceCollection).(idempotency.Purger); ok {
+		mediator.Register[idempotency.PurgeExpiredCommand, int64](mediatR, idempotency.NewPurgeExpiredCommandHandler(purger))
+		if err := scheduler.Register("idempotency.purge-expired", purgeIdempotencyRecordsSpec, idempotency.PurgeExpiredCommand{}); err != nil {
+			return err
+		}
+	}
+
+	return mediatR.Validate(scheduler.RequestTypes()...)
+}
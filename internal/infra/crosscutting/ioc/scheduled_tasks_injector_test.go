@@ -0,0 +1,26 @@
+package ioc
+
+import (
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/infra/crosscutting/utilities"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInjectScheduledTasks(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	InjectServices(serviceCollection)
+	scheduler := utilities.GetService[*schedule.Scheduler](serviceCollection)
+
+	// Execução
+	err := InjectScheduledTasks(serviceCollection)
+
+	// Verificações
+	assert.NoError(t, err, "Cada requisição agendada deve ter handler")
+	assert.Contains(t, scheduler.Names(), "idempotency.purge-expired", "A remoção dos registros de idempotência expirados deve ser agendada")
+	assert.NoError(t, utilities.GetService[mediator.Mediator](serviceCollection).Validate(scheduler.RequestTypes()...))
+	assert.Error(t, InjectScheduledTasks(serviceCollection), "Registrar as tarefas duas vezes deve ser reportado")
+}
@@ -1,15 +1,166 @@
 package ioc
 
 import (
+	"context"
+	"database/sql"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/jobs"
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/readmodels"
 	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/cache"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	"flickly/internal/infra/crosscutting/utilities"
+	infraaudit "flickly/internal/infra/data/audit"
+	infraidempotency "flickly/internal/infra/data/idempotency"
+	"flickly/internal/infra/data/memory"
+	infrasaga "flickly/internal/infra/data/saga"
+	infraschedule "flickly/internal/infra/data/schedule"
+	"flickly/internal/infra/data/sqlstore"
+	infrareadmodels "flickly/internal/infra/data/users/readmodels"
 	infrarepositories "flickly/internal/infra/data/users/repositories"
+	"flickly/internal/infra/messaging"
 )
 
+// InjectServices registra os serviços em memória de todos os módulos da aplicação
 func InjectServices(serviceCollection utilities.IServiceCollection) {
-	mediatR := mediator.NewMediatR()
-	// teste
+	InjectCoreServices(serviceCollection)
+	InjectUserServices(serviceCollection)
+}
+
+// InjectCoreServices registra a infraestrutura compartilhada pelos módulos com armazenamentos em memória
+func InjectCoreServices(serviceCollection utilities.IServiceCollection) {
+	outboxStore := messaging.NewOutboxMemoryStore()
+	auditStore := infraaudit.NewAuditMemoryStore()
+	jobQueue := messaging.NewJobMemoryQueue()
+	idempotencyStore := infraidempotency.NewIdempotencyMemoryStore()
+	sagaStore := infrasaga.NewSagaMemoryStore()
+
+	unitOfWorkFactory := uow.NewFactory(memory.NewTransactionProvider())
+	uow.AddRepository[outbox.Store](unitOfWorkFactory, outboxStore.WithTransaction)
+	uow.AddRepository[jobs.Queue](unitOfWorkFactory, jobQueue.WithTransaction)
+	uow.AddRepository[saga.Store](unitOfWorkFactory, sagaStore.WithTransaction)
+
+	mediatR := newMediator(unitOfWorkFactory, outboxStore, auditStore, jobQueue, idempotencyStore)
+	addMediator(serviceCollection, mediatR)
+	utilities.AddService[uow.Factory](serviceCollection, unitOfWorkFactory)
+	utilities.AddService[outbox.Store](serviceCollection, outboxStore)
+	utilities.AddService[messaging.Sink](serviceCollection, messaging.NewInProcessBus())
+	utilities.AddService[audit.Store](serviceCollection, auditStore)
+	utilities.AddService[jobs.Queue](serviceCollection, jobQueue)
+	utilities.AddService[idempotency.Store](serviceCollection, idempotencyStore)
+	utilities.AddService[saga.Store](serviceCollection, sagaStore)
+	utilities.AddService[schedule.Store](serviceCollection, infraschedule.NewScheduleMemoryStore())
+	utilities.AddConstructor[*saga.Manager](serviceCollection, utilities.Singleton, saga.NewManager)
+	utilities.AddConstructor[*schedule.Scheduler](serviceCollection, utilities.Singleton, schedule.NewScheduler)
+	utilities.AddConstructor[security.TokenService](serviceCollection, utilities.Singleton, func(secret infrasecurity.TokenSecret) security.TokenService {
+		return infrasecurity.NewHMACTokenService(secret)
+	})
+	utilities.AddService[security.PasswordHasher](serviceCollection, infrasecurity.NewPBKDF2PasswordHasher())
+	utilities.AddService[security.RoleProvider](serviceCollection, infrasecurity.NewStaticRoleProvider())
+}
+
+// InjectUserServices registra os repositórios de usuários em memória. Depende de InjectCoreServices.
+func InjectUserServices(serviceCollection utilities.IServiceCollection) {
+	userRepository := infrarepositories.NewCachedUserRepository(infrarepositories.NewUserRepository(), resolveCache(serviceCollection))
+	uow.AddRepository[repositories.IUserRepository](utilities.GetService[uow.Factory](serviceCollection), userRepository.WithTransaction)
+	utilities.AddService[repositories.IUserRepository](serviceCollection, userRepository)
+	utilities.AddService[readmodels.IUserViewRepository](serviceCollection, infrareadmodels.NewUserViewRepository())
+}
+
+// CoreSQLMigrations retorna os comandos que criam as tabelas da infraestrutura compartilhada
+func CoreSQLMigrations() []string {
+	migrations := append([]string{}, messaging.OutboxSQLMigrations...)
+	migrations = append(migrations, infraaudit.AuditSQLSchema, messaging.JobSQLSchema, infraidempotency.IdempotencySQLSchema, infrasaga.SagaSQLSchema)
+	return append(migrations, infraschedule.ScheduleSQLMigrations...)
+}
+
+// UserSQLMigrations retorna os comandos que criam as tabelas de usuários e do modelo de leitura
+func UserSQLMigrations() []string {
+	return append(append([]string{}, infrarepositories.UserSQLMigrations...), infrareadmodels.UserViewSQLSchema)
+}
+
+// InjectSQLServices substitui os armazenamentos em memória de todos os módulos pelos armazenamentos SQL
+func InjectSQLServices(serviceCollection utilities.IServiceCollection, db *sql.DB) error {
+	if err := sqlstore.Migrate(context.Background(), db, append(UserSQLMigrations(), CoreSQLMigrations()...)...); err != nil {
+		return err
+	}
+	InjectCoreSQLServices(serviceCollection, db)
+	InjectUserSQLServices(serviceCollection, db)
+	return nil
+}
+
+// InjectCoreSQLServices substitui os armazenamentos em memória da infraestrutura compartilhada pelos
+// armazenamentos SQL. As tabelas devem ter sido criadas com CoreSQLMigrations.
+func InjectCoreSQLServices(serviceCollection utilities.IServiceCollection, db *sql.DB) {
+	unitOfWorkFactory := uow.NewFactory(sqlstore.NewTransactionProvider(db))
+	outboxStore := messaging.NewOutboxSQLStore(db)
+	uow.AddRepository[outbox.Store](unitOfWorkFactory, outboxStore.WithTransaction)
+	jobQueue := messaging.NewJobSQLQueue(db)
+	uow.AddRepository[jobs.Queue](unitOfWorkFactory, jobQueue.WithTransaction)
+	sagaStore := infrasaga.NewSagaSQLStore(db)
+	uow.AddRepository[saga.Store](unitOfWorkFactory, sagaStore.WithTransaction)
+
+	auditStore := infraaudit.NewAuditSQLStore(db)
+	idempotencyStore := infraidempotency.NewIdempotencySQLStore(db)
+	mediatR := newMediator(unitOfWorkFactory, outboxStore, auditStore, jobQueue, idempotencyStore)
+	addMediator(serviceCollection, mediatR)
+	utilities.AddService[uow.Factory](serviceCollection, unitOfWorkFactory)
+	utilities.AddService[outbox.Store](serviceCollection, outboxStore)
+	utilities.AddService[audit.Store](serviceCollection, auditStore)
+	utilities.AddService[jobs.Queue](serviceCollection, jobQueue)
+	utilities.AddService[idempotency.Store](serviceCollection, idempotencyStore)
+	utilities.AddService[saga.Store](serviceCollection, sagaStore)
+	utilities.AddService[schedule.Store](serviceCollection, infraschedule.NewScheduleSQLStore(db))
+	utilities.AddConstructor[*saga.Manager](serviceCollection, utilities.Singleton, saga.NewManager)
+	utilities.AddConstructor[*schedule.Scheduler](serviceCollection, utilities.Singleton, schedule.NewScheduler)
+}
+
+// InjectUserSQLServices substitui os repositórios de usuários pelos repositórios SQL. Depende de
+// InjectCoreSQLServices; as tabelas devem ter sido criadas com UserSQLMigrations.
+func InjectUserSQLServices(serviceCollection utilities.IServiceCollection, db *sql.DB) {
+	userRepository := infrarepositories.NewCachedUserRepository(infrarepositories.NewUserSQLRepository(db), resolveCache(serviceCollection))
+	uow.AddRepository[repositories.IUserRepository](utilities.GetService[uow.Factory](serviceCollection), userRepository.WithTransaction)
+	utilities.AddService[repositories.IUserRepository](serviceCollection, userRepository)
+	utilities.AddService[readmodels.IUserViewRepository](serviceCollection, infrareadmodels.NewUserViewSQLRepository(db))
+}
+
+// resolveCache retorna o cache registrado ou registra um cache em processo
+func resolveCache(serviceCollection utilities.IServiceCollection) cache.Cache {
+	if registered := utilities.GetService[cache.Cache](serviceCollection); registered != nil {
+		return registered
+	}
+	lruCache := cache.NewLRUCache(cache.DefaultLRUCapacity)
+	utilities.AddService[cache.Cache](serviceCollection, lruCache)
+	return lruCache
+}
+
+// addMediator registra o mediador como Mediator e pelas interfaces menores das quais os consumidores dependem
+func addMediator(serviceCollection utilities.IServiceCollection, mediatR mediator.Mediator) {
 	utilities.AddService[mediator.Mediator](serviceCollection, mediatR)
-	utilities.AddService[repositories.IUserRepository](serviceCollection, infrarepositories.NewUserRepository())
+	utilities.AddService[mediator.Sender](serviceCollection, mediatR)
+	utilities.AddService[mediator.Publisher](serviceCollection, mediatR)
+	utilities.AddService[mediator.JobEnqueuer](serviceCollection, mediatR)
+	utilities.AddService[mediator.Registry](serviceCollection, mediatR)
+}
+
+// newMediator cria o mediador com os behaviors padrão da aplicação
+func newMediator(unitOfWorkFactory uow.Factory, outboxStore outbox.Store, auditStore audit.Store, jobQueue jobs.Queue, idempotencyStore idempotency.Store) mediator.Mediator {
+	return mediator.NewMediatR(
+		mediator.WithBehavior(mediator.RecoveryBehavior()),
+		mediator.WithBehavior(mediator.LoggingBehavior(nil)),
+		mediator.WithBehavior(mediator.TimingBehavior(nil)),
+		mediator.WithBehavior(mediator.ValidationBehavior()),
+		mediator.WithUnitOfWork(unitOfWorkFactory),
+		mediator.WithOutbox(outboxStore),
+		mediator.WithAuditTrail(auditStore),
+		mediator.WithJobQueue(jobQueue),
+		mediator.WithIdempotency(idempotencyStore, idempotency.DefaultTTL),
+	)
 }
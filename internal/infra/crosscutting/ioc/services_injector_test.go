@@ -1,16 +1,38 @@
 package ioc
 
 import (
+	"errors"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/domain/core/jobs"
 	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/readmodels"
 	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/cache"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	"flickly/internal/infra/crosscutting/utilities"
-	"github.com/stretchr/testify/assert"
+	infraaudit "flickly/internal/infra/data/audit"
+	infraidempotency "flickly/internal/infra/data/idempotency"
+	infrasaga "flickly/internal/infra/data/saga"
+	infraschedule "flickly/internal/infra/data/schedule"
+	infrareadmodels "flickly/internal/infra/data/users/readmodels"
+	infrarepositories "flickly/internal/infra/data/users/repositories"
+	"flickly/internal/infra/messaging"
 	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestInjectServices(t *testing.T) {
 	// Configuração
 	serviceCollection := utilities.NewServiceCollection()
+	utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
 
 	// Execução
 	InjectServices(serviceCollection)
@@ -19,8 +41,103 @@ func TestInjectServices(t *testing.T) {
 	// Verificar se o mediator foi registrado
 	mediatR := utilities.GetService[mediator.Mediator](serviceCollection)
 	assert.NotNil(t, mediatR, "O mediator deve ser registrado")
+	assert.Same(t, mediatR, utilities.GetService[mediator.Sender](serviceCollection), "O mediator deve ser registrado como Sender")
+	assert.Same(t, mediatR, utilities.GetService[mediator.Publisher](serviceCollection), "O mediator deve ser registrado como Publisher")
+	assert.Same(t, mediatR, utilities.GetService[mediator.JobEnqueuer](serviceCollection), "O mediator deve ser registrado como JobEnqueuer")
+	assert.Same(t, mediatR, utilities.GetService[mediator.Registry](serviceCollection), "O mediator deve ser registrado como Registry")
 
 	// Verificar se o repositório de usuários foi registrado
 	userRepo := utilities.GetService[repositories.IUserRepository](serviceCollection)
 	assert.NotNil(t, userRepo, "O repositório de usuários deve ser registrado")
+	assert.NotNil(t, utilities.GetService[readmodels.IUserViewRepository](serviceCollection), "O modelo de leitura de usuários deve ser registrado")
+
+	// Verificar se a fábrica de unidades de trabalho foi registrada
+	unitOfWorkFactory := utilities.GetService[uow.Factory](serviceCollection)
+	assert.NotNil(t, unitOfWorkFactory, "A fábrica de unidades de trabalho deve ser registrada")
+
+	// Verificar se o outbox e o destino das mensagens foram registrados
+	assert.NotNil(t, utilities.GetService[outbox.Store](serviceCollection), "O outbox deve ser registrado")
+	assert.NotNil(t, utilities.GetService[messaging.Sink](serviceCollection), "O destino das mensagens deve ser registrado")
+
+	// Verificar se o repositório de usuários usa o cache em processo
+	assert.IsType(t, &infrarepositories.CachedUserRepository{}, userRepo, "O repositório de usuários deve ser decorado com cache")
+	assert.IsType(t, &cache.LRUCache{}, utilities.GetService[cache.Cache](serviceCollection), "O cache em processo deve ser registrado por padrão")
+
+	// Verificar se a auditoria e a autenticação foram registradas
+	assert.NotNil(t, utilities.GetService[audit.Store](serviceCollection), "A trilha de auditoria deve ser registrada")
+	assert.NotNil(t, utilities.GetService[jobs.Queue](serviceCollection), "A fila de jobs deve ser registrada")
+	assert.NotNil(t, utilities.GetService[idempotency.Store](serviceCollection), "O armazenamento de idempotência deve ser registrado")
+	assert.NotNil(t, utilities.GetService[saga.Store](serviceCollection), "O armazenamento de sagas deve ser registrado")
+	assert.NotNil(t, utilities.GetService[*saga.Manager](serviceCollection), "O gerenciador de sagas deve ser registrado")
+	assert.NotNil(t, utilities.GetService[*schedule.Scheduler](serviceCollection), "O agendador deve ser registrado")
+	assert.NotNil(t, utilities.GetService[security.TokenService](serviceCollection), "O serviço de tokens deve ser registrado")
+	assert.NotNil(t, utilities.GetService[security.RoleProvider](serviceCollection), "O provedor de papéis deve ser registrado")
+	assert.NotNil(t, utilities.GetService[security.PasswordHasher](serviceCollection), "O hash de senhas deve ser registrado")
+	assert.NoError(t, serviceCollection.Build(), "As dependências dos serviços registrados devem ser resolvidas")
+}
+
+func TestInjectServices_UsesRegisteredCache(t *testing.T) {
+	// Configuração
+	serviceCollection := utilities.NewServiceCollection()
+	registered := cache.NewRedisCache("localhost:6379", "flickly:")
+	utilities.AddService[cache.Cache](serviceCollection, registered)
+
+	// Execução
+	InjectServices(serviceCollection)
+
+	// Verificações
+	assert.Same(t, registered, utilities.GetService[cache.Cache](serviceCollection), "O cache registrado antes da injeção deve ser mantido")
+}
+
+func TestInjectSQLServices(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE users").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS user_views").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS outbox_messages").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS handled_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS audit_entries").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS jobs").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS idempotency_records").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS sagas").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS scheduled_tasks").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS scheduled_task_runs").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE INDEX IF NOT EXISTS scheduled_task_runs_name_started_at").WillReturnResult(sqlmock.NewResult(0, 0))
+	serviceCollection := utilities.NewServiceCollection()
+
+	// Execução
+	err := InjectSQLServices(serviceCollection, db)
+
+	// Verificações
+	assert.NoError(t, err, "InjectSQLServices não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet(), "O esquema deve ser criado")
+	userRepo := utilities.GetService[repositories.IUserRepository](serviceCollection)
+	assert.IsType(t, &infrarepositories.CachedUserRepository{}, userRepo, "O repositório SQL deve ser registrado com cache")
+	assert.IsType(t, &infrareadmodels.UserViewSQLRepository{}, utilities.GetService[readmodels.IUserViewRepository](serviceCollection), "O modelo de leitura SQL deve ser registrado")
+	assert.NotNil(t, utilities.GetService[uow.Factory](serviceCollection), "A fábrica de unidades de trabalho deve ser registrada")
+	assert.NotNil(t, utilities.GetService[mediator.Mediator](serviceCollection), "O mediator deve ser registrado")
+	assert.IsType(t, &messaging.OutboxSQLStore{}, utilities.GetService[outbox.Store](serviceCollection), "O outbox SQL deve ser registrado")
+	assert.IsType(t, &infraaudit.AuditSQLStore{}, utilities.GetService[audit.Store](serviceCollection), "A trilha de auditoria SQL deve ser registrada")
+	assert.IsType(t, &messaging.JobSQLQueue{}, utilities.GetService[jobs.Queue](serviceCollection), "A fila de jobs SQL deve ser registrada")
+	assert.IsType(t, &infraidempotency.IdempotencySQLStore{}, utilities.GetService[idempotency.Store](serviceCollection), "O armazenamento de idempotência SQL deve ser registrado")
+	assert.IsType(t, &infrasaga.SagaSQLStore{}, utilities.GetService[saga.Store](serviceCollection), "O armazenamento de sagas SQL deve ser registrado")
+	assert.IsType(t, &infraschedule.ScheduleSQLStore{}, utilities.GetService[schedule.Store](serviceCollection), "O armazenamento de tarefas agendadas SQL deve ser registrado")
+}
+
+func TestInjectSQLServices_MigrationError(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectExec("CREATE TABLE IF NOT EXISTS users").WillReturnError(errors.New("permission denied"))
+	serviceCollection := utilities.NewServiceCollection()
+
+	// Execução
+	err := InjectSQLServices(serviceCollection, db)
+
+	// Verificações
+	assert.Error(t, err, "Erros de migração devem ser propagados")
+	assert.Nil(t, utilities.GetService[mediator.Mediator](serviceCollection), "Nenhum serviço deve ser registrado em caso de erro")
 }
@@ -0,0 +1,181 @@
+package modules
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DisabledModulesVariable é a variável de ambiente com os nomes dos módulos desativados, separados por vírgula
+const DisabledModulesVariable = "DISABLED_MODULES"
+
+var (
+	ErrDuplicateModule          = errors.New("module already registered")
+	ErrUnknownModule            = errors.New("unknown module")
+	ErrModuleDisabled           = errors.New("module disabled")
+	ErrCircularModuleDependency = errors.New("circular module dependency")
+)
+
+// Module agrupa tudo o que uma área funcional contribui para a aplicação. Todas as etapas são opcionais
+// e executadas por Setup e MapRoutes, sempre depois das etapas equivalentes dos módulos dos quais depende.
+type Module struct {
+	// Name identifica o módulo nas dependências e na configuração
+	Name string
+	// DependsOn lista os módulos que precisam estar ativos e ser configurados antes deste
+	DependsOn []string
+	// Services registra os serviços em memória do módulo
+	Services func(serviceCollection utilities.IServiceCollection)
+	// Migrations são os comandos de criação das tabelas do módulo, executados quando há banco de dados
+	Migrations []string
+	// SQLServices substitui os serviços em memória pelos serviços SQL, depois das migrações
+	SQLServices func(serviceCollection utilities.IServiceCollection, db *sql.DB)
+	// Mappings registra os perfis de mapeamento do módulo
+	Mappings func(serviceCollection utilities.IServiceCollection)
+	// Handlers registra os handlers do mediator e as tarefas agendadas, depois da validação do contêiner
+	Handlers func(serviceCollection utilities.IServiceCollection) error
+	// Routes registra as rotas HTTP do módulo
+	Routes func(router *gin.Engine, serviceCollection utilities.IServiceCollection)
+}
+
+// Catalog é o conjunto de módulos conhecidos pela aplicação
+type Catalog struct {
+	modules []Module
+}
+
+// NewCatalog cria um catálogo com os módulos informados
+func NewCatalog(modules ...Module) *Catalog {
+	return &Catalog{modules: modules}
+}
+
+// Register adiciona um módulo ao catálogo
+func (c *Catalog) Register(module Module) {
+	c.modules = append(c.modules, module)
+}
+
+// Resolve retorna os módulos ativos, ordenados de forma que cada módulo venha depois das suas dependências.
+// Módulos sem relação entre si mantêm a ordem de registro. enabled nil ativa todos os módulos.
+func (c *Catalog) Resolve(enabled func(name string) bool) ([]Module, error) {
+	byName := make(map[string]Module, len(c.modules))
+	for _, module := range c.modules {
+		if _, exists := byName[module.Name]; exists {
+			return nil, fmt.Errorf("module %s: %w", module.Name, ErrDuplicateModule)
+		}
+		byName[module.Name] = module
+	}
+
+	const (
+		visiting = iota + 1
+		visited
+	)
+	state := make(map[string]int, len(c.modules))
+	ordered := make([]Module, 0, len(c.modules))
+	var visit func(module Module, path []string) error
+	visit = func(module Module, path []string) error {
+		switch state[module.Name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("module %s: %w (%s)", module.Name, ErrCircularModuleDependency,
+				strings.Join(append(path, module.Name), " -> "))
+		}
+		state[module.Name] = visiting
+		for _, name := range module.DependsOn {
+			dependency, exists := byName[name]
+			if !exists {
+				return fmt.Errorf("module %s depends on %s: %w", module.Name, name, ErrUnknownModule)
+			}
+			if enabled != nil && !enabled(name) {
+				return fmt.Errorf("module %s depends on %s: %w", module.Name, name, ErrModuleDisabled)
+			}
+			if err := visit(dependency, append(path, module.Name)); err != nil {
+				return err
+			}
+		}
+		state[module.Name] = visited
+		ordered = append(ordered, module)
+		return nil
+	}
+
+	for _, module := range c.modules {
+		if enabled != nil && !enabled(module.Name) {
+			continue
+		}
+		if err := visit(module, nil); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// DisabledBy retorna um filtro para Resolve que desativa os módulos listados, separados por vírgula,
+// como no valor de DISABLED_MODULES
+func DisabledBy(list string) func(name string) bool {
+	disabled := make(map[string]bool)
+	for _, name := range strings.Split(list, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			disabled[name] = true
+		}
+	}
+	return func(name string) bool {
+		return !disabled[name]
+	}
+}
+
+// Setup configura os módulos na ordem informada, etapa por etapa: registra os serviços de todos os módulos,
+// executa as migrações e registra os serviços SQL quando db não é nil, registra os mapeamentos, valida o
+// contêiner com Build e, por fim, registra os handlers. Os módulos devem vir ordenados por Resolve.
+func Setup(serviceCollection utilities.IServiceCollection, db *sql.DB, modules []Module) error {
+	for _, module := range modules {
+		if module.Services != nil {
+			module.Services(serviceCollection)
+		}
+	}
+
+	if db != nil {
+		for _, module := range modules {
+			if err := sqlstore.Migrate(context.Background(), db, module.Migrations...); err != nil {
+				return fmt.Errorf("module %s: %w", module.Name, err)
+			}
+		}
+		for _, module := range modules {
+			if module.SQLServices != nil {
+				module.SQLServices(serviceCollection, db)
+			}
+		}
+	}
+
+	for _, module := range modules {
+		if module.Mappings != nil {
+			module.Mappings(serviceCollection)
+		}
+	}
+
+	if err := serviceCollection.Build(); err != nil {
+		return err
+	}
+
+	for _, module := range modules {
+		if module.Handlers == nil {
+			continue
+		}
+		if err := module.Handlers(serviceCollection); err != nil {
+			return fmt.Errorf("module %s: %w", module.Name, err)
+		}
+	}
+	return nil
+}
+
+// MapRoutes registra as rotas dos módulos na ordem informada
+func MapRoutes(router *gin.Engine, serviceCollection utilities.IServiceCollection, modules []Module) {
+	for _, module := range modules {
+		if module.Routes != nil {
+			module.Routes(router, serviceCollection)
+		}
+	}
+}
@@ -0,0 +1,165 @@
+package modules
+
+import (
+	"database/sql"
+	"errors"
+	"flickly/internal/infra/crosscutting/utilities"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// moduleDependencyForTest é uma dependência registrada por um módulo e consumida por outro
+type moduleDependencyForTest struct {
+	Value string
+}
+
+// moduleServiceForTest depende de moduleDependencyForTest
+type moduleServiceForTest struct {
+	Dependency *moduleDependencyForTest
+}
+
+func names(modules []Module) []string {
+	result := make([]string, 0, len(modules))
+	for _, module := range modules {
+		result = append(result, module.Name)
+	}
+	return result
+}
+
+func TestCatalogResolve(t *testing.T) {
+	// Configuração
+	catalog := NewCatalog(
+		Module{Name: "users", DependsOn: []string{"core"}},
+		Module{Name: "admin", DependsOn: []string{"core", "users"}},
+		Module{Name: "core"},
+	)
+	catalog.Register(Module{Name: "reports"})
+
+	// Execução
+	all, err := catalog.Resolve(nil)
+	withoutAdmin, errWithoutAdmin := catalog.Resolve(DisabledBy(" admin, reports "))
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"core", "users", "admin", "reports"}, names(all), "Dependências devem vir antes dos dependentes, mantendo a ordem de registro")
+	assert.NoError(t, errWithoutAdmin)
+	assert.Equal(t, []string{"core", "users"}, names(withoutAdmin), "Módulos desativados devem ser ignorados")
+}
+
+func TestCatalogResolveErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		modules  []Module
+		disabled string
+		expected error
+	}{
+		{"duplicado", []Module{{Name: "core"}, {Name: "core"}}, "", ErrDuplicateModule},
+		{"desconhecido", []Module{{Name: "users", DependsOn: []string{"core"}}}, "", ErrUnknownModule},
+		{"dependência desativada", []Module{{Name: "core"}, {Name: "users", DependsOn: []string{"core"}}}, "core", ErrModuleDisabled},
+		{"ciclo", []Module{{Name: "a", DependsOn: []string{"b"}}, {Name: "b", DependsOn: []string{"a"}}}, "", ErrCircularModuleDependency},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// Execução
+			_, err := NewCatalog(test.modules...).Resolve(DisabledBy(test.disabled))
+
+			// Verificações
+			assert.ErrorIs(t, err, test.expected)
+		})
+	}
+}
+
+func TestSetup(t *testing.T) {
+	// Configuração
+	var steps []string
+	serviceCollection := utilities.NewServiceCollection()
+	core := Module{
+		Name: "core",
+		Services: func(sc utilities.IServiceCollection) {
+			steps = append(steps, "core.services")
+			utilities.AddService[*moduleDependencyForTest](sc, &moduleDependencyForTest{Value: "memory"})
+		},
+		Migrations: []string{"CREATE TABLE core"},
+		SQLServices: func(sc utilities.IServiceCollection, db *sql.DB) {
+			steps = append(steps, "core.sql")
+			utilities.AddService[*moduleDependencyForTest](sc, &moduleDependencyForTest{Value: "sql"})
+		},
+		Handlers: func(sc utilities.IServiceCollection) error {
+			steps = append(steps, "core.handlers")
+			return nil
+		},
+	}
+	feature := Module{
+		Name:      "feature",
+		DependsOn: []string{"core"},
+		Services: func(sc utilities.IServiceCollection) {
+			steps = append(steps, "feature.services")
+			utilities.AddConstructor[*moduleServiceForTest](sc, utilities.Singleton, func(dependency *moduleDependencyForTest) *moduleServiceForTest {
+				return &moduleServiceForTest{Dependency: dependency}
+			})
+		},
+		Migrations: []string{"CREATE TABLE feature"},
+		Mappings: func(sc utilities.IServiceCollection) {
+			steps = append(steps, "feature.mappings")
+		},
+		Routes: func(router *gin.Engine, sc utilities.IServiceCollection) {
+			router.GET("/feature", func(c *gin.Context) {
+				c.String(http.StatusOK, utilities.GetService[*moduleServiceForTest](sc).Dependency.Value)
+			})
+		},
+	}
+	modules, err := NewCatalog(feature, core).Resolve(nil)
+	assert.NoError(t, err)
+
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec("CREATE TABLE core").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE feature").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	// Execução
+	err = Setup(serviceCollection, db, modules)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	MapRoutes(router, serviceCollection, modules)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/feature", nil))
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet(), "As migrações devem rodar na ordem dos módulos")
+	assert.Equal(t, []string{"core.services", "feature.services", "core.sql", "feature.mappings", "core.handlers"}, steps, "As etapas devem rodar em fases, na ordem dos módulos")
+	assert.Equal(t, "sql", w.Body.String(), "As rotas devem usar os serviços SQL registrados pelos módulos")
+}
+
+func TestSetupErrors(t *testing.T) {
+	// Configuração
+	handlerErr := errors.New("handler failed")
+	missing := Module{
+		Name: "missing",
+		Services: func(sc utilities.IServiceCollection) {
+			utilities.AddConstructor[*moduleServiceForTest](sc, utilities.Singleton, func(dependency *moduleDependencyForTest) *moduleServiceForTest {
+				return &moduleServiceForTest{Dependency: dependency}
+			})
+		},
+	}
+	failing := Module{
+		Name:     "failing",
+		Handlers: func(sc utilities.IServiceCollection) error { return handlerErr },
+	}
+
+	// Execução
+	buildErr := Setup(utilities.NewServiceCollection(), nil, []Module{missing})
+	setupErr := Setup(utilities.NewServiceCollection(), nil, []Module{failing})
+
+	// Verificações
+	assert.ErrorIs(t, buildErr, utilities.ErrServiceNotRegistered, "Dependências ausentes devem ser reportadas antes dos handlers")
+	assert.ErrorIs(t, setupErr, handlerErr)
+	assert.Contains(t, setupErr.Error(), "module failing", "O erro deve identificar o módulo")
+}
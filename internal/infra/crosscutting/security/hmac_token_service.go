@@ -0,0 +1,76 @@
+package security
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"flickly/internal/domain/core/security"
+	"strings"
+	"time"
+)
+
+// DefaultTokenTTL é a validade dos tokens emitidos por HMACTokenService
+const DefaultTokenTTL = time.Hour
+
+// HMACTokenService emite tokens assinados com HMAC-SHA256 no formato "<claims>.<assinatura>" (base64url)
+type HMACTokenService struct {
+	secret []byte
+	TTL    time.Duration
+	Now    func() time.Time
+}
+
+type tokenClaims struct {
+	security.Principal
+	ExpiresAt int64 `json:"exp"`
+}
+
+// TokenSecret é o segredo que assina os tokens de acesso, configurado por TOKEN_SECRET. Deve ser o mesmo em todas
+// as instâncias para que os tokens continuem válidos entre reinícios e réplicas.
+type TokenSecret string
+
+// NewHMACTokenService cria o serviço com o segredo informado, que não pode ser vazio
+func NewHMACTokenService(secret TokenSecret) *HMACTokenService {
+	if len(secret) == 0 {
+		panic("o segredo dos tokens de acesso deve ser informado")
+	}
+	return &HMACTokenService{
+		secret: []byte(secret),
+		TTL:    DefaultTokenTTL,
+		Now:    time.Now,
+	}
+}
+
+func (s *HMACTokenService) Issue(principal security.Principal) (string, time.Duration, error) {
+	claims, err := json.Marshal(tokenClaims{Principal: principal, ExpiresAt: s.Now().Add(s.TTL).Unix()})
+	if err != nil {
+		return "", 0, err
+	}
+	encodedClaims := base64.RawURLEncoding.EncodeToString(claims)
+	return encodedClaims + "." + s.sign(encodedClaims), s.TTL, nil
+}
+
+func (s *HMACTokenService) Validate(token string) (security.Principal, error) {
+	encodedClaims, signature, found := strings.Cut(token, ".")
+	if !found || !hmac.Equal([]byte(signature), []byte(s.sign(encodedClaims))) {
+		return security.Principal{}, security.ErrInvalidToken
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encodedClaims)
+	if err != nil {
+		return security.Principal{}, security.ErrInvalidToken
+	}
+	var claims tokenClaims
+	if err = json.Unmarshal(data, &claims); err != nil || !claims.IsAuthenticated() {
+		return security.Principal{}, security.ErrInvalidToken
+	}
+	if s.Now().Unix() >= claims.ExpiresAt {
+		return security.Principal{}, security.ErrInvalidToken
+	}
+	return claims.Principal, nil
+}
+
+func (s *HMACTokenService) sign(encodedClaims string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encodedClaims))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,71 @@
+package security
+
+import (
+	"flickly/internal/domain/core/security"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHMACTokenService_IssueValidate(t *testing.T) {
+	// Configuração
+	service := NewHMACTokenService("secret")
+	principal := security.Principal{Subject: "user-1", Name: "Test User", Roles: []string{security.RoleAdmin}}
+
+	// Execução
+	token, expiresIn, err := service.Issue(principal)
+	assert.NoError(t, err, "Issue não deve retornar erro")
+	validated, err := service.Validate(token)
+
+	// Verificações
+	assert.NoError(t, err, "O token emitido deve ser válido")
+	assert.Equal(t, principal, validated, "O principal deve ser recuperado do token")
+	assert.Equal(t, DefaultTokenTTL, expiresIn)
+}
+
+func TestHMACTokenService_InvalidTokens(t *testing.T) {
+	// Configuração
+	service := NewHMACTokenService("secret")
+	token, _, _ := service.Issue(security.Principal{Subject: "user-1"})
+	claims, _, _ := strings.Cut(token, ".")
+	otherService := NewHMACTokenService("other")
+	otherToken, _, _ := otherService.Issue(security.Principal{Subject: "user-1", Roles: []string{security.RoleAdmin}})
+	_, signature, _ := strings.Cut(otherToken, ".")
+
+	// Execução e verificações
+	for _, invalid := range []string{"", "token-simulado", claims + ".invalid", otherToken, claims + "." + signature} {
+		_, err := service.Validate(invalid)
+		assert.Equal(t, security.ErrInvalidToken, err, "O token %q deve ser rejeitado", invalid)
+	}
+}
+
+func TestHMACTokenService_Expiration(t *testing.T) {
+	// Configuração
+	service := NewHMACTokenService("secret")
+	now := time.Now()
+	service.Now = func() time.Time { return now }
+	token, _, _ := service.Issue(security.Principal{Subject: "user-1"})
+
+	// Execução
+	now = now.Add(DefaultTokenTTL)
+	_, err := service.Validate(token)
+
+	// Verificações
+	assert.Equal(t, security.ErrInvalidToken, err, "Tokens expirados devem ser rejeitados")
+}
+
+func TestHMACTokenService_RequiresSecret(t *testing.T) {
+	// Verificações
+	assert.Panics(t, func() { NewHMACTokenService("") }, "O serviço não deve ser criado sem segredo")
+}
+
+func TestStaticRoleProvider(t *testing.T) {
+	// Configuração
+	provider := NewStaticRoleProvider(" Admin@Example.com ", "")
+
+	// Verificações
+	assert.Equal(t, []string{security.RoleAdmin}, provider.RolesFor("admin@example.com"), "Emails configurados devem ser administradores")
+	assert.Empty(t, provider.RolesFor("user@example.com"), "Outros emails não devem ter papéis")
+}
@@ -0,0 +1,66 @@
+package security
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// DefaultPasswordIterations é o número de iterações de PBKDF2-SHA256 recomendado pela OWASP
+const DefaultPasswordIterations = 600000
+
+const (
+	passwordHashAlgorithm = "pbkdf2-sha256"
+	passwordSaltLength    = 16
+	passwordKeyLength     = 32
+)
+
+// PBKDF2PasswordHasher gera hashes PBKDF2-SHA256 no formato "pbkdf2-sha256$<iterações>$<sal>$<hash>" (base64).
+// As iterações ficam no hash, por isso hashes gerados com outra configuração continuam verificáveis.
+type PBKDF2PasswordHasher struct {
+	Iterations int
+}
+
+// NewPBKDF2PasswordHasher cria o hasher com DefaultPasswordIterations
+func NewPBKDF2PasswordHasher() *PBKDF2PasswordHasher {
+	return &PBKDF2PasswordHasher{Iterations: DefaultPasswordIterations}
+}
+
+func (h *PBKDF2PasswordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, passwordSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, h.Iterations, passwordKeyLength)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s$%d$%s$%s", passwordHashAlgorithm, h.Iterations,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (h *PBKDF2PasswordHasher) Verify(hash string, password string) bool {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 4 || parts[0] != passwordHashAlgorithm {
+		return false
+	}
+	iterations, err := strconv.Atoi(parts[1])
+	if err != nil || iterations <= 0 {
+		return false
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return false
+	}
+	expected, err := base64.RawStdEncoding.DecodeString(parts[3])
+	if err != nil || len(expected) == 0 {
+		return false
+	}
+	key, err := pbkdf2.Key(sha256.New, password, salt, iterations, len(expected))
+	return err == nil && subtle.ConstantTimeCompare(key, expected) == 1
+}
@@ -0,0 +1,36 @@
+package security
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPBKDF2PasswordHasher(t *testing.T) {
+	// Configuração
+	hasher := &PBKDF2PasswordHasher{Iterations: 1000}
+
+	// Execução
+	hash, err := hasher.Hash("Senha@123")
+	otherHash, _ := hasher.Hash("Senha@123")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "pbkdf2-sha256$1000$"), "O hash deve registrar o algoritmo e as iterações")
+	assert.NotEqual(t, hash, otherHash, "Cada hash deve usar um sal próprio")
+	assert.NotContains(t, hash, "Senha@123", "O hash não deve conter a senha")
+	assert.True(t, hasher.Verify(hash, "Senha@123"), "A senha correta deve ser aceita")
+	assert.True(t, NewPBKDF2PasswordHasher().Verify(hash, "Senha@123"), "As iterações devem ser lidas do hash")
+	assert.False(t, hasher.Verify(hash, "senha@123"), "Senhas diferentes devem ser rejeitadas")
+}
+
+func TestPBKDF2PasswordHasher_InvalidHashes(t *testing.T) {
+	// Configuração
+	hasher := &PBKDF2PasswordHasher{Iterations: 1000}
+
+	// Verificações
+	for _, hash := range []string{"", "Senha@123", "bcrypt$1000$c2FsdA$a2V5", "pbkdf2-sha256$0$c2FsdA$a2V5", "pbkdf2-sha256$1000$!$a2V5", "pbkdf2-sha256$1000$c2FsdA$"} {
+		assert.False(t, hasher.Verify(hash, "Senha@123"), "O hash %q deve ser rejeitado", hash)
+	}
+}
@@ -0,0 +1,29 @@
+package security
+
+import (
+	"flickly/internal/domain/core/security"
+	"strings"
+)
+
+// StaticRoleProvider concede o papel de administrador aos emails configurados
+type StaticRoleProvider struct {
+	adminEmails map[string]bool
+}
+
+// NewStaticRoleProvider cria o provedor com a lista de emails administradores
+func NewStaticRoleProvider(adminEmails ...string) *StaticRoleProvider {
+	provider := &StaticRoleProvider{adminEmails: make(map[string]bool)}
+	for _, email := range adminEmails {
+		if email = strings.ToLower(strings.TrimSpace(email)); email != "" {
+			provider.adminEmails[email] = true
+		}
+	}
+	return provider
+}
+
+func (p *StaticRoleProvider) RolesFor(email string) []string {
+	if p.adminEmails[strings.ToLower(email)] {
+		return []string{security.RoleAdmin}
+	}
+	return nil
+}
@@ -1,30 +1,63 @@
 package utilities
 
 import (
+	"encoding"
 	"errors"
+	"fmt"
 	"reflect"
 )
 
+// mapperTag é a tag que, nos campos de destino, indica o campo de origem, ou um método da origem sem parâmetros
+// e com um único retorno; "-" ignora o campo. Nos campos de origem, "-" impede que o campo seja mapeado.
+const mapperTag = "mapper"
+
+var (
+	textMarshalerType   = reflect.TypeFor[encoding.TextMarshaler]()
+	textUnmarshalerType = reflect.TypeFor[encoding.TextUnmarshaler]()
+	stringerType        = reflect.TypeFor[fmt.Stringer]()
+)
+
+// Converter converte um valor de origem para o tipo de destino registrado
+type Converter func(source reflect.Value) (reflect.Value, error)
+
+// typePair identifica uma conversão entre dois tipos
+type typePair struct {
+	source      reflect.Type
+	destination reflect.Type
+}
+
 // Mapper é a interface principal do automapper
 type Mapper interface {
 	Map(source, destination interface{}) error
 	AddMapping(sourceType, destType reflect.Type, mapping func(source, destination reflect.Value) error)
 	MapSlice(source, destination interface{}) error
+	// AddConverter registra a conversão entre dois tipos, aplicada a todos os campos mapeados com esses tipos.
+	// Conversores registrados têm precedência sobre as conversões padrão.
+	AddConverter(sourceType, destType reflect.Type, converter Converter)
 }
 
-// AutoMapper é a implementação padrão do Mapper
+// AutoMapper é a implementação padrão do Mapper. Sem mapeamento personalizado, os campos são mapeados pelo nome
+// ou pela tag mapper do destino, com as conversões padrão: ponteiro e valor, tipos que implementam
+// encoding.TextMarshaler e encoding.TextUnmarshaler e string (como uuid.UUID e time.Time, em RFC 3339),
+// fmt.Stringer para string, tipos nomeados do mesmo tipo básico e ampliação numérica sem perda. Campos sem
+// conversão possível são ignorados.
 type AutoMapper struct {
-	mappings map[string]func(source, destination reflect.Value) error
+	mappings   map[string]func(source, destination reflect.Value) error
+	converters map[typePair]Converter
 }
 
 // NewAutoMapper cria uma nova instância do AutoMapper
 func NewAutoMapper() Mapper {
 	return &AutoMapper{
-		mappings: make(map[string]func(source, destination reflect.Value) error),
+		mappings:   make(map[string]func(source, destination reflect.Value) error),
+		converters: make(map[typePair]Converter),
 	}
 }
 
-// Map mapeia os campos de source para destination
+// Map mapeia os campos de source para destination, recursivamente: structs aninhadas, slices, arrays, mapas e
+// ponteiros são mapeados elemento a elemento, usando os mapeamentos registrados para os tipos dos elementos e
+// alocando os ponteiros de destino. Referências repetidas ao mesmo ponteiro de origem, inclusive em ciclos,
+// são mapeadas para o mesmo ponteiro de destino.
 func (m *AutoMapper) Map(source, destination interface{}) error {
 	sourceValue := reflect.ValueOf(source)
 	destValue := reflect.ValueOf(destination)
@@ -34,58 +67,391 @@ func (m *AutoMapper) Map(source, destination interface{}) error {
 		return errors.New("destination must be a pointer")
 	}
 
-	// Obter o valor apontado
-	destValue = destValue.Elem()
-
-	// Verificar se source é um ponteiro
+	state := newMappingState()
+	// Verificar se source é um ponteiro; as referências a ele dentro do grafo apontam para destination
 	if sourceValue.Kind() == reflect.Ptr {
+		if sourceValue.IsNil() {
+			return errors.New("source must not be nil")
+		}
+		state.visited[state.key(sourceValue, destValue.Type())] = destValue
 		sourceValue = sourceValue.Elem()
 	}
 
-	// Obter os tipos
-	sourceType := sourceValue.Type()
-	destType := destValue.Type()
-
-	// Criar chave para o mapeamento
-	key := sourceType.String() + "->" + destType.String()
+	// Obter o valor apontado
+	destValue = destValue.Elem()
 
 	// Verificar se já existe um mapeamento personalizado
-	if mapping, exists := m.mappings[key]; exists {
+	if mapping, exists := m.mappings[mappingKey(sourceValue.Type(), destValue.Type())]; exists {
 		return mapping(sourceValue, destValue)
 	}
 
 	// Mapeamento padrão
-	return m.defaultMapping(sourceValue, destValue)
+	if sourceValue.Kind() == reflect.Struct && destValue.Kind() == reflect.Struct {
+		_, err := m.defaultMapping(sourceValue, destValue, state)
+		return err
+	}
+	ok, err := m.convert(sourceValue, destValue, state)
+	if err == nil && !ok {
+		err = fmt.Errorf("cannot map %s to %s", sourceValue.Type(), destValue.Type())
+	}
+	return err
 }
 
-// defaultMapping implementa o mapeamento padrão entre structs
-func (m *AutoMapper) defaultMapping(source, destination reflect.Value) error {
-	// Verificar se ambos são structs
-	if source.Kind() != reflect.Struct || destination.Kind() != reflect.Struct {
-		return errors.New("both source and destination must be structs")
-	}
+// mappingKey identifica um mapeamento personalizado
+func mappingKey(sourceType, destType reflect.Type) string {
+	return sourceType.String() + "->" + destType.String()
+}
+
+// ErrMappingCycle indica um ciclo de ponteiros mapeado para valores, que não pode ser representado no destino
+var ErrMappingCycle = errors.New("mapping cycle")
+
+// mappingState acompanha os ponteiros de origem de uma chamada a Map
+type mappingState struct {
+	// visited guarda o ponteiro de destino criado para cada ponteiro de origem
+	visited map[pointerVisit]reflect.Value
+	// active são os ponteiros de origem sendo mapeados para valores
+	active map[pointerVisit]bool
+}
+
+// pointerVisit identifica um ponteiro de origem mapeado para um tipo de destino
+type pointerVisit struct {
+	pointer     uintptr
+	source      reflect.Type
+	destination reflect.Type
+}
+
+func newMappingState() *mappingState {
+	return &mappingState{visited: make(map[pointerVisit]reflect.Value), active: make(map[pointerVisit]bool)}
+}
 
+func (s *mappingState) key(source reflect.Value, destType reflect.Type) pointerVisit {
+	return pointerVisit{pointer: source.Pointer(), source: source.Type(), destination: destType}
+}
+
+// defaultMapping implementa o mapeamento padrão entre structs e retorna se algum campo foi mapeado
+func (m *AutoMapper) defaultMapping(source, destination reflect.Value, state *mappingState) (bool, error) {
+	mapped := false
 	// Iterar sobre os campos da struct de destino
 	for i := 0; i < destination.NumField(); i++ {
 		destField := destination.Type().Field(i)
-		sourceField := source.FieldByName(destField.Name)
+		if !destField.IsExported() {
+			continue
+		}
+		sourceName := destField.Name
+		tag, tagged := destField.Tag.Lookup(mapperTag)
+		if tagged {
+			if tag == "-" {
+				continue
+			}
+			sourceName = tag
+		}
 
-		// Se o campo existe na source
-		if sourceField.IsValid() {
-			// Se os tipos são compatíveis
-			if sourceField.Type().AssignableTo(destField.Type) {
-				destination.Field(i).Set(sourceField)
+		sourceStructField, ok := source.Type().FieldByName(sourceName)
+		if !ok && tagged {
+			// A tag também pode indicar um método da origem, sem parâmetros e com um único retorno
+			if method, ok := sourceMethod(source, sourceName); ok {
+				converted, err := m.convert(method.Call(nil)[0], destination.Field(i), state)
+				if err != nil {
+					return false, fmt.Errorf("field %s: %w", destField.Name, err)
+				}
+				mapped = mapped || converted
 			}
+			continue
+		}
+		if !ok || !sourceStructField.IsExported() || sourceStructField.Tag.Get(mapperTag) == "-" {
+			continue
+		}
+		sourceField, err := source.FieldByIndexErr(sourceStructField.Index)
+		if err != nil {
+			// Campo de uma struct embutida por ponteiro nulo
+			continue
+		}
+		converted, err := m.convert(sourceField, destination.Field(i), state)
+		if err != nil {
+			return false, fmt.Errorf("field %s: %w", destField.Name, err)
 		}
+		mapped = mapped || converted
 	}
 
-	return nil
+	return mapped, nil
+}
+
+// sourceMethod retorna o método exportado de source sem parâmetros e com um único retorno. Métodos promovidos de
+// campos embutidos nulos, como o error de um DomainError sem erro, não são retornados: o campo de destino fica
+// com o valor zero.
+func sourceMethod(source reflect.Value, name string) (reflect.Value, bool) {
+	if !source.CanInterface() {
+		return reflect.Value{}, false
+	}
+	method := source.MethodByName(name)
+	if !method.IsValid() && source.CanAddr() {
+		method = source.Addr().MethodByName(name)
+	}
+	if !method.IsValid() || method.Type().NumIn() != 0 || method.Type().NumOut() != 1 || promotedFromNil(source, name) {
+		return reflect.Value{}, false
+	}
+	return method, true
+}
+
+// promotedFromNil indica se o método da struct vem de um campo embutido, interface ou ponteiro, nulo, cuja
+// chamada causaria panic
+func promotedFromNil(source reflect.Value, name string) bool {
+	for i := 0; i < source.NumField(); i++ {
+		if !source.Type().Field(i).Anonymous {
+			continue
+		}
+		field := source.Field(i)
+		switch field.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if _, ok := field.Type().MethodByName(name); !ok {
+				continue
+			}
+			if field.IsNil() {
+				return true
+			}
+			if field.Kind() == reflect.Ptr && field.Elem().Kind() == reflect.Struct && promotedFromNil(field.Elem(), name) {
+				return true
+			}
+		case reflect.Struct:
+			if _, ok := reflect.PointerTo(field.Type()).MethodByName(name); ok && promotedFromNil(field, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// convert atribui source a destination, aplicando os conversores registrados, as conversões padrão e o
+// mapeamento recursivo de structs, slices, arrays, mapas e ponteiros. Retorna false quando não há conversão
+// entre os tipos, sem alterar destination.
+func (m *AutoMapper) convert(source, destination reflect.Value, state *mappingState) (bool, error) {
+	sourceType, destType := source.Type(), destination.Type()
+
+	if converter, ok := m.converters[typePair{source: sourceType, destination: destType}]; ok {
+		converted, err := converter(source)
+		if err != nil {
+			return false, err
+		}
+		destination.Set(converted)
+		return true, nil
+	}
+
+	switch {
+	case sourceType.AssignableTo(destType):
+		destination.Set(source)
+		return true, nil
+	case sourceType.Kind() == reflect.Ptr && destType.Kind() == reflect.Ptr:
+		if source.IsNil() {
+			destination.SetZero()
+			return true, nil
+		}
+		key := state.key(source, destType)
+		if target, ok := state.visited[key]; ok {
+			destination.Set(target)
+			return true, nil
+		}
+		target := reflect.New(destType.Elem())
+		state.visited[key] = target
+		ok, err := m.convert(source.Elem(), target.Elem(), state)
+		if !ok {
+			delete(state.visited, key)
+			return false, err
+		}
+		destination.Set(target)
+		return true, nil
+	case sourceType.Kind() == reflect.Ptr:
+		if source.IsNil() {
+			destination.SetZero()
+			return true, nil
+		}
+		key := state.key(source, destType)
+		if state.active[key] {
+			return false, fmt.Errorf("%w: %s to %s", ErrMappingCycle, sourceType, destType)
+		}
+		state.active[key] = true
+		defer delete(state.active, key)
+		return m.convert(source.Elem(), destination, state)
+	case destType.Kind() == reflect.Ptr:
+		target := reflect.New(destType.Elem())
+		ok, err := m.convert(source, target.Elem(), state)
+		if ok {
+			destination.Set(target)
+		}
+		return ok, err
+	}
+
+	if destType.Kind() == reflect.String {
+		if sourceType.Implements(textMarshalerType) {
+			text, err := source.Interface().(encoding.TextMarshaler).MarshalText()
+			if err != nil {
+				return false, err
+			}
+			destination.SetString(string(text))
+			return true, nil
+		}
+		if sourceType.Implements(stringerType) {
+			destination.SetString(source.Interface().(fmt.Stringer).String())
+			return true, nil
+		}
+	}
+	if sourceType.Kind() == reflect.String && reflect.PointerTo(destType).Implements(textUnmarshalerType) {
+		target := reflect.New(destType)
+		if err := target.Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(source.String())); err != nil {
+			return false, err
+		}
+		destination.Set(target.Elem())
+		return true, nil
+	}
+
+	if isBasic(sourceType) && (sourceType.Kind() == destType.Kind() || widens(sourceType, destType)) {
+		destination.Set(source.Convert(destType))
+		return true, nil
+	}
+
+	switch {
+	case sourceType.Kind() == reflect.Struct && destType.Kind() == reflect.Struct:
+		return m.convertStruct(source, destination, state)
+	case isSequence(sourceType) && isSequence(destType):
+		return m.convertSequence(source, destination, state)
+	case sourceType.Kind() == reflect.Map && destType.Kind() == reflect.Map:
+		return m.convertMap(source, destination, state)
+	}
+	return false, nil
+}
+
+// convertStruct mapeia a struct com o mapeamento registrado para os tipos ou com o mapeamento padrão. Sem
+// mapeamento registrado, retorna false quando nenhum campo foi mapeado, sem alterar destination.
+func (m *AutoMapper) convertStruct(source, destination reflect.Value, state *mappingState) (bool, error) {
+	target := reflect.New(destination.Type()).Elem()
+	mapped := true
+	var err error
+	if mapping, exists := m.mappings[mappingKey(source.Type(), destination.Type())]; exists {
+		err = mapping(source, target)
+	} else {
+		mapped, err = m.defaultMapping(source, target, state)
+	}
+	if err != nil || !mapped {
+		return false, err
+	}
+	destination.Set(target)
+	return true, nil
+}
+
+// isSequence indica se o tipo é slice ou array
+func isSequence(t reflect.Type) bool {
+	return t.Kind() == reflect.Slice || t.Kind() == reflect.Array
+}
+
+// convertSequence mapeia os elementos de slices e arrays. Arrays de destino recebem até o seu tamanho; slices
+// nulos continuam nulos.
+func (m *AutoMapper) convertSequence(source, destination reflect.Value, state *mappingState) (bool, error) {
+	destType := destination.Type()
+	if source.Kind() == reflect.Slice && source.IsNil() {
+		destination.SetZero()
+		return true, nil
+	}
+
+	length := source.Len()
+	var target reflect.Value
+	if destType.Kind() == reflect.Slice {
+		target = reflect.MakeSlice(destType, length, length)
+	} else {
+		target = reflect.New(destType).Elem()
+		length = min(length, destType.Len())
+	}
+	for i := 0; i < length; i++ {
+		ok, err := m.convert(source.Index(i), target.Index(i), state)
+		if err != nil {
+			return false, fmt.Errorf("index %d: %w", i, err)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	destination.Set(target)
+	return true, nil
+}
+
+// convertMap mapeia as chaves e os valores do mapa; mapas nulos continuam nulos
+func (m *AutoMapper) convertMap(source, destination reflect.Value, state *mappingState) (bool, error) {
+	destType := destination.Type()
+	if source.IsNil() {
+		destination.SetZero()
+		return true, nil
+	}
+
+	target := reflect.MakeMapWithSize(destType, source.Len())
+	iterator := source.MapRange()
+	for iterator.Next() {
+		key := reflect.New(destType.Key()).Elem()
+		value := reflect.New(destType.Elem()).Elem()
+		ok, err := m.convert(iterator.Key(), key, state)
+		if err == nil && ok {
+			ok, err = m.convert(iterator.Value(), value, state)
+		}
+		if err != nil {
+			return false, fmt.Errorf("key %v: %w", iterator.Key(), err)
+		}
+		if !ok {
+			return false, nil
+		}
+		target.SetMapIndex(key, value)
+	}
+	destination.Set(target)
+	return true, nil
+}
+
+// isBasic indica se o tipo é bool, numérico ou string
+func isBasic(t reflect.Type) bool {
+	return t.Kind() == reflect.Bool || t.Kind() == reflect.String || isInteger(t) || isUnsigned(t) || isFloat(t)
+}
+
+func isInteger(t reflect.Type) bool {
+	return t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64
+}
+
+func isUnsigned(t reflect.Type) bool {
+	return t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64
+}
+
+func isFloat(t reflect.Type) bool {
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+// widens indica se a conversão numérica entre os tipos preserva todos os valores de origem
+func widens(source, destination reflect.Type) bool {
+	switch {
+	case isInteger(source) && isInteger(destination), isUnsigned(source) && isUnsigned(destination),
+		isFloat(source) && isFloat(destination):
+		return destination.Bits() >= source.Bits()
+	case isUnsigned(source) && isInteger(destination):
+		return destination.Bits() > source.Bits()
+	case (isInteger(source) || isUnsigned(source)) && isFloat(destination):
+		// A mantissa de float32 tem 24 bits e a de float64, 53
+		return source.Bits() < 24 || (destination.Bits() == 64 && source.Bits() <= 32)
+	}
+	return false
 }
 
 // AddMapping adiciona um mapeamento personalizado
 func (m *AutoMapper) AddMapping(sourceType, destType reflect.Type, mapping func(source, destination reflect.Value) error) {
-	key := sourceType.String() + "->" + destType.String()
-	m.mappings[key] = mapping
+	m.mappings[mappingKey(sourceType, destType)] = mapping
+}
+
+// AddConverter adiciona uma conversão entre tipos
+func (m *AutoMapper) AddConverter(sourceType, destType reflect.Type, converter Converter) {
+	m.converters[typePair{source: sourceType, destination: destType}] = converter
+}
+
+// AddConverter registra uma conversão tipada de S para D no mapper
+func AddConverter[S, D any](mapper Mapper, converter func(source S) (D, error)) {
+	mapper.AddConverter(reflect.TypeFor[S](), reflect.TypeFor[D](), func(source reflect.Value) (reflect.Value, error) {
+		converted, err := converter(source.Interface().(S))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(&converted).Elem(), nil
+	})
 }
 
 // MapSlice mapeia um slice de source para um slice de destination
@@ -1,10 +1,13 @@
 package utilities
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -159,3 +162,342 @@ func TestAutoMapper_MapSlice(t *testing.T) {
 		assert.Equal(t, source[1].Address, dest[1].Address)
 	})
 }
+
+// Status é uma enumeração representada como texto pelo fmt.Stringer
+type Status int
+
+func (s Status) String() string {
+	return [...]string{"pending", "active"}[s]
+}
+
+// Role é um tipo nomeado sobre string
+type Role string
+
+// Money é convertido por um conversor registrado
+type Money struct {
+	Cents int64
+}
+
+type TaggedSource struct {
+	ID        uuid.UUID
+	Name      string
+	Email     *string
+	Secret    string `mapper:"-"`
+	CreatedAt time.Time
+	Status    Status
+	Role      Role
+	Age       int32
+	Score     float32
+	Count     uint16
+	Price     Money
+	Nickname  string
+}
+
+type TaggedDestination struct {
+	ID        string
+	FullName  string `mapper:"Name"`
+	Email     string
+	Secret    string
+	CreatedAt string
+	Status    string
+	Role      string
+	Age       int64
+	Score     float64
+	Count     int32
+	Price     string
+	Nickname  *string
+	Name      string `mapper:"-"`
+}
+
+func TestAutoMapper_MapWithTagsAndConversions(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	AddConverter(mapper, func(source Money) (string, error) {
+		return fmt.Sprintf("%d.%02d", source.Cents/100, source.Cents%100), nil
+	})
+	email := "john@example.com"
+	source := TaggedSource{
+		ID:        uuid.New(),
+		Name:      "John Doe",
+		Email:     &email,
+		Secret:    "secret",
+		CreatedAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC),
+		Status:    1,
+		Role:      "admin",
+		Age:       30,
+		Score:     9.5,
+		Count:     7,
+		Price:     Money{Cents: 1999},
+		Nickname:  "johnny",
+	}
+
+	// Execução
+	var dest TaggedDestination
+	err := mapper.Map(source, &dest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, source.ID.String(), dest.ID, "uuid.UUID deve ser convertido para string")
+	assert.Equal(t, "John Doe", dest.FullName, "A tag deve indicar o campo de origem")
+	assert.Empty(t, dest.Name, "Campos com a tag - devem ser ignorados")
+	assert.Equal(t, email, dest.Email, "Ponteiros devem ser convertidos para o valor")
+	assert.Empty(t, dest.Secret, "Campos de origem com a tag - não devem ser mapeados")
+	assert.Equal(t, "2024-05-01T12:30:00Z", dest.CreatedAt, "time.Time deve ser convertido para RFC 3339")
+	assert.Equal(t, "active", dest.Status, "Enumerações devem ser convertidas pelo fmt.Stringer")
+	assert.Equal(t, "admin", dest.Role, "Tipos nomeados devem ser convertidos para o tipo básico")
+	assert.Equal(t, int64(30), dest.Age)
+	assert.Equal(t, float64(9.5), dest.Score)
+	assert.Equal(t, int32(7), dest.Count)
+	assert.Equal(t, "19.99", dest.Price, "Conversores registrados devem ser aplicados")
+	if assert.NotNil(t, dest.Nickname) {
+		assert.Equal(t, "johnny", *dest.Nickname, "Valores devem ser convertidos para ponteiros")
+	}
+}
+
+// MethodSource expõe valores apenas por métodos, inclusive o Error de um erro embutido
+type MethodSource struct {
+	error
+	Name string
+}
+
+func (s *MethodSource) Greeting() string {
+	return "Olá, " + s.Name
+}
+
+func (s MethodSource) Summary() string {
+	return "resumo"
+}
+
+func (s MethodSource) Format(prefix string) string {
+	return prefix + s.Name
+}
+
+type MethodDestination struct {
+	InternalMessage string `mapper:"Error"`
+	Greeting        string `mapper:"Greeting"`
+	Format          string `mapper:"Format"`
+	Summary         string
+}
+
+func TestAutoMapper_MapFromMethods(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	source := &MethodSource{error: errors.New("falha interna"), Name: "John"}
+
+	// Execução
+	var dest MethodDestination
+	err := mapper.Map(source, &dest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, "falha interna", dest.InternalMessage, "A tag deve indicar métodos promovidos de campos embutidos")
+	assert.Equal(t, "Olá, John", dest.Greeting, "Métodos com receptor ponteiro devem ser usados quando a origem é endereçável")
+	assert.Empty(t, dest.Format, "Métodos com parâmetros não devem ser chamados")
+	assert.Empty(t, dest.Summary, "Métodos só devem ser chamados quando indicados pela tag")
+}
+
+// EmbeddedMethodSource expõe os métodos de um *MethodSource embutido
+type EmbeddedMethodSource struct {
+	*MethodSource
+}
+
+func TestAutoMapper_MapFromMethodsOfNilEmbeddedFields(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+
+	// Execução
+	var dest MethodDestination
+	err := mapper.Map(&MethodSource{Name: "John"}, &dest)
+	var embeddedDest MethodDestination
+	embeddedErr := mapper.Map(&EmbeddedMethodSource{}, &embeddedDest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, dest.InternalMessage, "Métodos promovidos de uma interface nula devem ser ignorados")
+	assert.Equal(t, "Olá, John", dest.Greeting, "Os demais métodos devem continuar sendo mapeados")
+	assert.NoError(t, embeddedErr)
+	assert.Equal(t, MethodDestination{}, embeddedDest, "Métodos promovidos de um ponteiro nulo devem ser ignorados")
+}
+
+// UnrelatedSource não tem campos em comum com DestinationAddress
+type UnrelatedSource struct {
+	Code string
+}
+
+func TestAutoMapper_MapStructWithoutCommonFields(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	source := struct {
+		Name    string
+		Address UnrelatedSource
+		History []UnrelatedSource
+	}{Name: "John", Address: UnrelatedSource{Code: "x"}, History: []UnrelatedSource{{Code: "y"}}}
+
+	// Execução
+	var dest struct {
+		Name    string
+		Address *DestinationAddress
+		History []DestinationAddress
+	}
+	err := mapper.Map(source, &dest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, "John", dest.Name)
+	assert.Nil(t, dest.Address, "Structs sem campos mapeados não devem ser convertidas")
+	assert.Nil(t, dest.History, "Os elementos sem campos mapeados não devem ser convertidos")
+}
+
+func TestAutoMapper_MapParsesStrings(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	id := uuid.New()
+	source := struct {
+		ID        string
+		CreatedAt string
+		Age       int64
+	}{ID: id.String(), CreatedAt: "2024-05-01T12:30:00Z", Age: 30}
+
+	// Execução
+	var dest struct {
+		ID        uuid.UUID
+		CreatedAt *time.Time
+		Age       int32
+	}
+	err := mapper.Map(source, &dest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, id, dest.ID, "string deve ser convertida para uuid.UUID")
+	if assert.NotNil(t, dest.CreatedAt) {
+		assert.True(t, dest.CreatedAt.Equal(time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)), "string RFC 3339 deve ser convertida para time.Time")
+	}
+	assert.Zero(t, dest.Age, "Conversões numéricas com perda devem ser ignoradas")
+}
+
+func TestAutoMapper_MapConversionErrors(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	converterErr := errors.New("invalid price")
+	AddConverter(mapper, func(source Money) (string, error) {
+		return "", converterErr
+	})
+
+	// Execução
+	var invalidID struct{ ID uuid.UUID }
+	parseErr := mapper.Map(struct{ ID string }{ID: "invalid"}, &invalidID)
+	var price struct{ Price string }
+	priceErr := mapper.Map(struct{ Price Money }{Price: Money{Cents: 1}}, &price)
+
+	// Verificações
+	assert.ErrorContains(t, parseErr, "field ID", "O erro deve identificar o campo")
+	assert.ErrorIs(t, priceErr, converterErr, "Erros dos conversores devem ser retornados")
+}
+
+type SourceAddress struct {
+	Street string
+	City   string
+}
+
+type DestinationAddress struct {
+	Street string
+	City   string
+}
+
+type SourceTeam struct {
+	Name      string
+	Leader    *SourceUser
+	Members   []SourceUser
+	Addresses map[string]SourceAddress
+	Main      SourceAddress
+	Backup    *SourceAddress
+	Tags      [2]Role
+	IDs       []uuid.UUID
+}
+
+type DestinationTeam struct {
+	Name      string
+	Leader    *DifferentUser
+	Members   []DestinationUser
+	Addresses map[string]*DestinationAddress
+	Main      *DestinationAddress
+	Backup    DestinationAddress
+	Tags      []string
+	IDs       []string
+}
+
+func TestAutoMapper_MapNested(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	mapper.AddMapping(reflect.TypeOf(SourceUser{}), reflect.TypeOf(DifferentUser{}), func(source, dest reflect.Value) error {
+		dest.FieldByName("FullName").Set(source.FieldByName("Name"))
+		return nil
+	})
+	id := uuid.New()
+	source := SourceTeam{
+		Name:      "core",
+		Leader:    &SourceUser{ID: 1, Name: "John Doe"},
+		Members:   []SourceUser{{ID: 2, Name: "Jane Doe"}, {ID: 3, Name: "Jim Doe"}},
+		Addresses: map[string]SourceAddress{"office": {Street: "123 Main St", City: "Springfield"}},
+		Main:      SourceAddress{Street: "456 Oak St", City: "Shelbyville"},
+		Tags:      [2]Role{"backend", "frontend"},
+		IDs:       []uuid.UUID{id},
+	}
+
+	// Execução
+	var dest DestinationTeam
+	err := mapper.Map(&source, &dest)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", dest.Leader.FullName, "Mapeamentos registrados devem ser usados para os campos aninhados")
+	assert.Equal(t, []DestinationUser{{ID: 2, Name: "Jane Doe"}, {ID: 3, Name: "Jim Doe"}}, dest.Members, "Slices devem ser mapeados elemento a elemento")
+	if assert.Contains(t, dest.Addresses, "office") {
+		assert.Equal(t, "Springfield", dest.Addresses["office"].City, "Mapas devem ser mapeados alocando os ponteiros de destino")
+	}
+	assert.Equal(t, &DestinationAddress{Street: "456 Oak St", City: "Shelbyville"}, dest.Main, "Structs aninhadas devem ser mapeadas")
+	assert.Equal(t, DestinationAddress{}, dest.Backup, "Ponteiros nulos devem resultar no valor zero")
+	assert.Equal(t, []string{"backend", "frontend"}, dest.Tags, "Arrays devem ser mapeados para slices")
+	assert.Equal(t, []string{id.String()}, dest.IDs, "As conversões devem ser aplicadas aos elementos")
+}
+
+type SourceNode struct {
+	Name     string
+	Parent   *SourceNode
+	Children []*SourceNode
+}
+
+type DestinationNode struct {
+	Name     string
+	Parent   *DestinationNode
+	Children []*DestinationNode
+}
+
+type ValueNode struct {
+	Name     string
+	Children []ValueNode
+}
+
+func TestAutoMapper_MapCycles(t *testing.T) {
+	// Configuração
+	mapper := NewAutoMapper()
+	root := &SourceNode{Name: "root"}
+	child := &SourceNode{Name: "child", Parent: root}
+	root.Children = []*SourceNode{child, child}
+	child.Children = []*SourceNode{root}
+
+	// Execução
+	var dest DestinationNode
+	err := mapper.Map(root, &dest)
+	var values ValueNode
+	valuesErr := mapper.Map(root, &values)
+
+	// Verificações
+	assert.NoError(t, err)
+	if assert.Len(t, dest.Children, 2) {
+		assert.Same(t, dest.Children[0], dest.Children[1], "O mesmo ponteiro de origem deve ser mapeado para o mesmo ponteiro de destino")
+		assert.Same(t, &dest, dest.Children[0].Parent, "Referências à origem devem apontar para o destino")
+		assert.Same(t, &dest, dest.Children[0].Children[0])
+	}
+	assert.ErrorIs(t, valuesErr, ErrMappingCycle, "Ciclos mapeados para valores devem ser reportados")
+}
@@ -1,43 +1,669 @@
 package utilities
 
-import "reflect"
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+)
 
-// IServiceCollection interface principal sem genéricos
+// Lifetime define por quanto tempo a instância criada por uma fábrica é reutilizada
+type Lifetime int
+
+const (
+	// Singleton cria a instância na primeira resolução e a reutiliza em todo o contêiner
+	Singleton Lifetime = iota
+	// Scoped cria uma instância por escopo (requisição HTTP ou job)
+	Scoped
+	// Transient cria uma nova instância a cada resolução
+	Transient
+)
+
+func (l Lifetime) String() string {
+	switch l {
+	case Singleton:
+		return "singleton"
+	case Scoped:
+		return "scoped"
+	case Transient:
+		return "transient"
+	}
+	return fmt.Sprintf("Lifetime(%d)", int(l))
+}
+
+// Factory cria a instância de um serviço a partir do contêiner que a resolve
+type Factory func(collection IServiceCollection) interface{}
+
+// Erros retornados na resolução de serviços
+var (
+	ErrServiceNotRegistered = errors.New("service not registered")
+	ErrCircularDependency   = errors.New("circular dependency")
+	ErrScopeRequired        = errors.New("scoped service resolved outside of a scope")
+	ErrScopedInSingleton    = errors.New("scoped service captured by a singleton")
+)
+
+// ServiceKey identifica um registro pelo tipo do serviço e pela chave, vazia nos registros sem chave
+type ServiceKey struct {
+	Type reflect.Type
+	Key  string
+}
+
+func (k ServiceKey) String() string {
+	if k.Key == "" {
+		return k.Type.String()
+	}
+	return fmt.Sprintf("%s[%s]", k.Type, k.Key)
+}
+
+// ResolutionError indica o serviço que não pôde ser resolvido e o caminho percorrido até ele
+type ResolutionError struct {
+	Service ServiceKey
+	// Path é a sequência de serviços resolvidos até Service, incluindo ele
+	Path []ServiceKey
+	Err  error
+}
+
+func (e *ResolutionError) Error() string {
+	names := make([]string, len(e.Path))
+	for i, service := range e.Path {
+		names[i] = service.String()
+	}
+	return fmt.Sprintf("unable to resolve %s: %v (resolution path: %s)", e.Service, e.Err, strings.Join(names, " -> "))
+}
+
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// IServiceCollection interface principal sem genéricos. Um tipo pode ser registrado várias vezes: a resolução
+// usa o último registro e GetServicesByType retorna todos, na ordem de registro.
 type IServiceCollection interface {
 	AddServiceInstance(serviceType reflect.Type, implementation interface{}) IServiceCollection
+	// AddServiceFactory registra uma fábrica chamada conforme o tempo de vida informado
+	AddServiceFactory(serviceType reflect.Type, lifetime Lifetime, factory Factory) IServiceCollection
+	// AddServiceConstructor registra uma função construtora cujos parâmetros são resolvidos pelo tipo. A função
+	// retorna o serviço e, opcionalmente, um error; parâmetros IServiceCollection recebem o contêiner que resolve
+	// e parâmetros []T sem registro próprio recebem todos os serviços sem chave do tipo T.
+	AddServiceConstructor(serviceType reflect.Type, lifetime Lifetime, constructor interface{}) IServiceCollection
+	// AddKeyedServiceInstance, AddKeyedServiceFactory e AddKeyedServiceConstructor registram o serviço com uma
+	// chave, resolvido apenas por GetKeyedServiceByType
+	AddKeyedServiceInstance(serviceType reflect.Type, key string, implementation interface{}) IServiceCollection
+	AddKeyedServiceFactory(serviceType reflect.Type, key string, lifetime Lifetime, factory Factory) IServiceCollection
+	AddKeyedServiceConstructor(serviceType reflect.Type, key string, lifetime Lifetime, constructor interface{}) IServiceCollection
 	GetServiceByType(serviceType reflect.Type) interface{}
+	GetKeyedServiceByType(serviceType reflect.Type, key string) interface{}
+	// GetServicesByType retorna todos os serviços sem chave do tipo, na ordem de registro
+	GetServicesByType(serviceType reflect.Type) []interface{}
+	// DecorateService registra uma função que envolve as instâncias sem chave do tipo. A função recebe a instância
+	// e retorna o serviço decorado; os demais parâmetros são resolvidos como os de um construtor. Os decoradores são
+	// aplicados na ordem de registro, na criação da instância, e devem ser registrados antes da primeira resolução.
+	DecorateService(serviceType reflect.Type, decorator interface{}) IServiceCollection
+	// CreateScope cria um escopo que resolve os serviços Scoped em instâncias próprias. Serviços registrados
+	// no escopo só são visíveis nele.
+	CreateScope() IServiceCollection
+	// CreateChild cria um contêiner independente com os registros e decoradores visíveis neste. Registros feitos no
+	// filho substituem os herdados sem alterar este contêiner, e os serviços criados por fábrica ou construtor são
+	// recriados no filho, com as dependências substituídas. Instâncias registradas com AddServiceInstance são
+	// compartilhadas e continuam gerenciadas por este contêiner, exceto quando o filho as decora: nesse caso o
+	// filho decora e gerencia a sua própria cópia do registro.
+	CreateChild() IServiceCollection
+	// Start cria os serviços Singleton e chama Start nos que implementam Starter, na ordem das dependências. Se um
+	// deles falhar, os serviços já iniciados são parados em ordem inversa; os demais são parados por Stop.
+	Start(ctx context.Context) error
+	// Stop para, em ordem inversa à de criação, os serviços criados pelo contêiner que implementam Stopper ou
+	// io.Closer; no escopo, apenas os serviços Scoped criados nele. O prazo do contexto limita a espera.
+	Stop(ctx context.Context) error
+	// Describe descreve os registros visíveis no contêiner, na ordem de registro, para diagnóstico
+	Describe() []ServiceDescription
+	// Build valida as dependências dos construtores registrados: serviços não registrados, dependências
+	// circulares e serviços Scoped usados por Singletons. As fábricas não são verificadas.
+	Build() error
+}
+
+var serviceCollectionType = reflect.TypeFor[IServiceCollection]()
+
+// registration é um serviço registrado como instância, fábrica ou construtor
+type registration struct {
+	key          ServiceKey
+	lifetime     Lifetime
+	factory      Factory
+	constructor  reflect.Value
+	dependencies []reflect.Type
+	// implementationType é o tipo da instância registrada com AddServiceInstance
+	implementationType reflect.Type
+	// registered é a instância registrada com AddServiceInstance, antes dos decoradores
+	registered interface{}
+	// instance é a instância criada, já decorada
+	instance interface{}
+	created  bool
+	// owner é o contêiner onde o serviço foi registrado; os Singletons resolvem as dependências nele
+	owner *serviceCollection
+	mu    sync.Mutex
+}
+
+// decorator é uma função registrada com DecorateService
+type decorator struct {
+	function reflect.Value
+	// dependencies são os parâmetros resolvidos pelo contêiner, depois da instância decorada
+	dependencies []reflect.Type
 }
 
 type serviceCollection struct {
-	services map[reflect.Type]interface{}
+	parent        *serviceCollection
+	isScope       bool
+	registrations map[ServiceKey][]*registration
+	decorators    map[ServiceKey][]*decorator
+	// order guarda as chaves na ordem em que foram registradas pela primeira vez
+	order  []ServiceKey
+	scoped map[*registration]interface{}
+	// managed são as instâncias criadas pelo contêiner que serão iniciadas e paradas
+	managed []*managedInstance
+	mu      sync.RWMutex
 }
 
 func NewServiceCollection() IServiceCollection {
+	return newServiceCollection(nil, false)
+}
+
+func newServiceCollection(parent *serviceCollection, isScope bool) *serviceCollection {
 	return &serviceCollection{
-		services: make(map[reflect.Type]interface{}),
+		parent:        parent,
+		isScope:       isScope,
+		registrations: make(map[ServiceKey][]*registration),
+		decorators:    make(map[ServiceKey][]*decorator),
+		scoped:        make(map[*registration]interface{}),
 	}
 }
 
 // AddServiceInstance implementa a interface não-genérica
 func (c *serviceCollection) AddServiceInstance(serviceType reflect.Type, implementation interface{}) IServiceCollection {
-	implementationValue := reflect.ValueOf(implementation)
+	return c.AddKeyedServiceInstance(serviceType, "", implementation)
+}
 
-	if !implementationValue.Type().Implements(serviceType) {
-		panic("a implementação não satisfaz a interface esperada")
-	}
+// AddServiceFactory implementa a interface não-genérica
+func (c *serviceCollection) AddServiceFactory(serviceType reflect.Type, lifetime Lifetime, factory Factory) IServiceCollection {
+	return c.AddKeyedServiceFactory(serviceType, "", lifetime, factory)
+}
+
+// AddServiceConstructor implementa a interface não-genérica
+func (c *serviceCollection) AddServiceConstructor(serviceType reflect.Type, lifetime Lifetime, constructor interface{}) IServiceCollection {
+	return c.AddKeyedServiceConstructor(serviceType, "", lifetime, constructor)
+}
 
-	c.services[serviceType] = implementation
+// AddKeyedServiceInstance implementa a interface não-genérica
+func (c *serviceCollection) AddKeyedServiceInstance(serviceType reflect.Type, key string, implementation interface{}) IServiceCollection {
+	checkImplementation(serviceType, implementation)
+	c.register(&registration{key: ServiceKey{Type: serviceType, Key: key}, lifetime: Singleton, registered: implementation, implementationType: reflect.TypeOf(implementation)})
+	return c
+}
+
+// AddKeyedServiceFactory implementa a interface não-genérica
+func (c *serviceCollection) AddKeyedServiceFactory(serviceType reflect.Type, key string, lifetime Lifetime, factory Factory) IServiceCollection {
+	c.register(&registration{key: ServiceKey{Type: serviceType, Key: key}, lifetime: lifetime, factory: factory})
+	return c
+}
+
+// AddKeyedServiceConstructor implementa a interface não-genérica
+func (c *serviceCollection) AddKeyedServiceConstructor(serviceType reflect.Type, key string, lifetime Lifetime, constructor interface{}) IServiceCollection {
+	constructorValue, dependencies := checkFunction("o construtor", serviceType, constructor)
+	c.register(&registration{key: ServiceKey{Type: serviceType, Key: key}, lifetime: lifetime, constructor: constructorValue, dependencies: dependencies})
+	return c
+}
+
+// DecorateService implementa a interface não-genérica
+func (c *serviceCollection) DecorateService(serviceType reflect.Type, decoratorFunction interface{}) IServiceCollection {
+	function, dependencies := checkFunction("o decorador", serviceType, decoratorFunction)
+	if len(dependencies) == 0 || dependencies[0] != serviceType {
+		panic(fmt.Sprintf("o decorador de %s deve receber a instância decorada no primeiro parâmetro", serviceType))
+	}
+	serviceKey := ServiceKey{Type: serviceType}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.decorators[serviceKey] = append(c.decorators[serviceKey], &decorator{function: function, dependencies: dependencies[1:]})
+	// As instâncias herdadas por CreateChild são criadas pelo contêiner de origem, que não vê este decorador
+	for i, inherited := range c.registrations[serviceKey] {
+		if inherited.owner != c && inherited.registered != nil {
+			c.registrations[serviceKey][i] = &registration{
+				key:                inherited.key,
+				lifetime:           Singleton,
+				implementationType: inherited.implementationType,
+				registered:         inherited.registered,
+				owner:              c,
+			}
+		}
+	}
 	return c
 }
 
-// GetServiceByType implementa a interface não-genérica
+// checkFunction verifica se a função retorna o serviço e, opcionalmente, um error e retorna os tipos dos parâmetros
+func checkFunction(kind string, serviceType reflect.Type, function interface{}) (reflect.Value, []reflect.Type) {
+	functionValue := reflect.ValueOf(function)
+	functionType := functionValue.Type()
+	if functionType.Kind() != reflect.Func || functionType.IsVariadic() {
+		panic(fmt.Sprintf("%s de %s deve ser uma função sem parâmetros variádicos", kind, serviceType))
+	}
+	if functionType.NumOut() == 0 || functionType.NumOut() > 2 || !functionType.Out(0).AssignableTo(serviceType) ||
+		(functionType.NumOut() == 2 && functionType.Out(1) != reflect.TypeFor[error]()) {
+		panic(fmt.Sprintf("%s de %s deve retornar o serviço e, opcionalmente, um error", kind, serviceType))
+	}
+
+	parameters := make([]reflect.Type, functionType.NumIn())
+	for i := range parameters {
+		parameters[i] = functionType.In(i)
+	}
+	return functionValue, parameters
+}
+
+// GetServiceByType implementa a interface não-genérica. Retorna nil para tipos não registrados e causa panic
+// com um *ResolutionError quando uma dependência do serviço não pode ser resolvida.
 func (c *serviceCollection) GetServiceByType(serviceType reflect.Type) interface{} {
-	if service, ok := c.services[serviceType]; ok {
-		return service
+	return c.GetKeyedServiceByType(serviceType, "")
+}
+
+// GetKeyedServiceByType implementa a interface não-genérica
+func (c *serviceCollection) GetKeyedServiceByType(serviceType reflect.Type, key string) interface{} {
+	serviceKey := ServiceKey{Type: serviceType, Key: key}
+	if serviceKey != (ServiceKey{Type: serviceCollectionType}) && c.lookup(serviceKey) == nil {
+		return nil
+	}
+	instance, err := c.resolve(serviceKey, nil)
+	if err != nil {
+		panic(err)
+	}
+	return instance
+}
+
+// GetServicesByType implementa a interface não-genérica
+func (c *serviceCollection) GetServicesByType(serviceType reflect.Type) []interface{} {
+	instances, err := c.resolveAll(serviceType, nil)
+	if err != nil {
+		panic(err)
+	}
+	return instances
+}
+
+// CreateScope implementa a interface não-genérica
+func (c *serviceCollection) CreateScope() IServiceCollection {
+	return newServiceCollection(c, true)
+}
+
+// CreateChild implementa a interface não-genérica
+func (c *serviceCollection) CreateChild() IServiceCollection {
+	var chain []*serviceCollection
+	for current := c; current != nil; current = current.parent {
+		chain = append([]*serviceCollection{current}, chain...)
+	}
+
+	child := newServiceCollection(nil, false)
+	for _, current := range chain {
+		current.mu.RLock()
+		for _, serviceKey := range current.order {
+			if _, ok := child.registrations[serviceKey]; !ok {
+				child.order = append(child.order, serviceKey)
+			}
+			for _, inherited := range current.registrations[serviceKey] {
+				if inherited.factory == nil && !inherited.constructor.IsValid() {
+					child.registrations[serviceKey] = append(child.registrations[serviceKey], inherited)
+					continue
+				}
+				child.registrations[serviceKey] = append(child.registrations[serviceKey], &registration{
+					key:          inherited.key,
+					lifetime:     inherited.lifetime,
+					factory:      inherited.factory,
+					constructor:  inherited.constructor,
+					dependencies: inherited.dependencies,
+					owner:        child,
+				})
+			}
+		}
+		for serviceKey, decorators := range current.decorators {
+			child.decorators[serviceKey] = append(child.decorators[serviceKey], decorators...)
+		}
+		current.mu.RUnlock()
+	}
+	return child
+}
+
+func (c *serviceCollection) register(registration *registration) {
+	registration.owner = c
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.registrations[registration.key]; !ok {
+		c.order = append(c.order, registration.key)
+	}
+	c.registrations[registration.key] = append(c.registrations[registration.key], registration)
+}
+
+// lookup procura o último registro no contêiner e, em seguida, nos contêineres de onde ele foi criado
+func (c *serviceCollection) lookup(serviceKey ServiceKey) *registration {
+	for current := c; current != nil; current = current.parent {
+		current.mu.RLock()
+		registrations := current.registrations[serviceKey]
+		current.mu.RUnlock()
+		if len(registrations) > 0 {
+			return registrations[len(registrations)-1]
+		}
 	}
 	return nil
 }
 
+// lookupAll retorna os registros visíveis no contêiner, começando pelos registrados na raiz
+func (c *serviceCollection) lookupAll(serviceKey ServiceKey) []*registration {
+	var all []*registration
+	for current := c; current != nil; current = current.parent {
+		current.mu.RLock()
+		all = append(append([]*registration{}, current.registrations[serviceKey]...), all...)
+		current.mu.RUnlock()
+	}
+	return all
+}
+
+// dependencyKey indica se o parâmetro recebe todos os serviços do tipo do elemento ([]T sem registro próprio)
+func (c *serviceCollection) dependencyKey(dependency reflect.Type) (ServiceKey, bool) {
+	serviceKey := ServiceKey{Type: dependency}
+	if dependency.Kind() == reflect.Slice && c.lookup(serviceKey) == nil {
+		return ServiceKey{Type: dependency.Elem()}, true
+	}
+	return serviceKey, false
+}
+
+// resolve obtém o serviço conforme o tempo de vida, acumulando em path os serviços em resolução
+func (c *serviceCollection) resolve(serviceKey ServiceKey, path []ServiceKey) (interface{}, error) {
+	if serviceKey == (ServiceKey{Type: serviceCollectionType}) {
+		return c, nil
+	}
+	path, err := enter(serviceKey, path)
+	if err != nil {
+		return nil, err
+	}
+	registration := c.lookup(serviceKey)
+	if registration == nil {
+		return nil, &ResolutionError{Service: serviceKey, Path: path, Err: ErrServiceNotRegistered}
+	}
+	return c.instance(registration, path)
+}
+
+// resolveAll obtém todos os serviços sem chave do tipo, na ordem de registro
+func (c *serviceCollection) resolveAll(serviceType reflect.Type, path []ServiceKey) ([]interface{}, error) {
+	serviceKey := ServiceKey{Type: serviceType}
+	path, err := enter(serviceKey, path)
+	if err != nil {
+		return nil, err
+	}
+	registrations := c.lookupAll(serviceKey)
+	instances := make([]interface{}, 0, len(registrations))
+	for _, registration := range registrations {
+		instance, err := c.instance(registration, path)
+		if err != nil {
+			return nil, err
+		}
+		instances = append(instances, instance)
+	}
+	return instances, nil
+}
+
+// enter acrescenta o serviço ao caminho de resolução, falhando se ele já estiver sendo resolvido
+func enter(serviceKey ServiceKey, path []ServiceKey) ([]ServiceKey, error) {
+	extended := append(path[:len(path):len(path)], serviceKey)
+	for _, resolving := range path {
+		if resolving == serviceKey {
+			return nil, &ResolutionError{Service: serviceKey, Path: extended, Err: ErrCircularDependency}
+		}
+	}
+	return extended, nil
+}
+
+// instance obtém a instância do registro conforme o tempo de vida
+func (c *serviceCollection) instance(registration *registration, path []ServiceKey) (interface{}, error) {
+	switch registration.lifetime {
+	case Singleton:
+		registration.mu.Lock()
+		defer registration.mu.Unlock()
+		if !registration.created {
+			instance, err := registration.owner.create(registration, path)
+			if err != nil {
+				return nil, err
+			}
+			registration.instance, registration.created = instance, true
+			if instance != nil {
+				registration.owner.track(instance)
+			}
+		}
+		return registration.instance, nil
+	case Scoped:
+		return c.resolveScoped(registration, path)
+	default:
+		return c.create(registration, path)
+	}
+}
+
+// resolveScoped reutiliza a instância do escopo mais próximo. A instância é criada fora do lock para que
+// possa resolver outros serviços do mesmo escopo.
+func (c *serviceCollection) resolveScoped(registration *registration, path []ServiceKey) (interface{}, error) {
+	scope := c
+	for scope != nil && !scope.isScope {
+		scope = scope.parent
+	}
+	if scope == nil {
+		return nil, &ResolutionError{Service: registration.key, Path: path, Err: ErrScopeRequired}
+	}
+
+	scope.mu.RLock()
+	instance, ok := scope.scoped[registration]
+	scope.mu.RUnlock()
+	if ok {
+		return instance, nil
+	}
+	instance, err := scope.create(registration, path)
+	if err != nil {
+		return nil, err
+	}
+	scope.mu.Lock()
+	if existing, ok := scope.scoped[registration]; ok {
+		scope.mu.Unlock()
+		return existing, nil
+	}
+	scope.scoped[registration] = instance
+	scope.mu.Unlock()
+	if instance != nil {
+		scope.track(instance)
+	}
+	return instance, nil
+}
+
+// create obtém a instância pela fábrica, pelo construtor ou pelo registro e aplica os decoradores
+func (c *serviceCollection) create(registration *registration, path []ServiceKey) (interface{}, error) {
+	instance := registration.registered
+	switch {
+	case registration.factory != nil:
+		instance = registration.factory(c)
+		if instance != nil {
+			checkImplementation(registration.key.Type, instance)
+		}
+	case registration.constructor.IsValid():
+		var err error
+		instance, err = c.call(registration.key, registration.constructor, nil, registration.dependencies, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if instance == nil {
+		return nil, nil
+	}
+	for _, decorator := range c.decoratorsFor(registration.key) {
+		inner := reflect.New(registration.key.Type).Elem()
+		inner.Set(reflect.ValueOf(instance))
+		var err error
+		instance, err = c.call(registration.key, decorator.function, []reflect.Value{inner}, decorator.dependencies, path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return instance, nil
+}
+
+// call chama o construtor ou o decorador com os argumentos informados seguidos das dependências resolvidas
+func (c *serviceCollection) call(serviceKey ServiceKey, function reflect.Value, arguments []reflect.Value, dependencies []reflect.Type, path []ServiceKey) (interface{}, error) {
+	for _, dependency := range dependencies {
+		argument, err := c.argument(dependency, path)
+		if err != nil {
+			return nil, err
+		}
+		arguments = append(arguments, argument)
+	}
+	results := function.Call(arguments)
+	if len(results) == 2 && !results[1].IsNil() {
+		return nil, &ResolutionError{Service: serviceKey, Path: path, Err: results[1].Interface().(error)}
+	}
+	return results[0].Interface(), nil
+}
+
+// decoratorsFor retorna os decoradores visíveis no contêiner, começando pelos registrados na raiz
+func (c *serviceCollection) decoratorsFor(serviceKey ServiceKey) []*decorator {
+	var all []*decorator
+	for current := c; current != nil; current = current.parent {
+		current.mu.RLock()
+		all = append(append([]*decorator{}, current.decorators[serviceKey]...), all...)
+		current.mu.RUnlock()
+	}
+	return all
+}
+
+// argument resolve um parâmetro do construtor
+func (c *serviceCollection) argument(dependency reflect.Type, path []ServiceKey) (reflect.Value, error) {
+	serviceKey, all := c.dependencyKey(dependency)
+	if all {
+		instances, err := c.resolveAll(serviceKey.Type, path)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		slice := reflect.MakeSlice(dependency, len(instances), len(instances))
+		for i, instance := range instances {
+			if instance != nil {
+				slice.Index(i).Set(reflect.ValueOf(instance))
+			}
+		}
+		return slice, nil
+	}
+
+	instance, err := c.resolve(serviceKey, path)
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	if instance == nil {
+		return reflect.Zero(dependency), nil
+	}
+	return reflect.ValueOf(instance), nil
+}
+
+// Build implementa a interface não-genérica
+func (c *serviceCollection) Build() error {
+	validator := graphValidator{collection: c, visited: make(map[graphNode]bool), onPath: make(map[ServiceKey]bool)}
+	for _, serviceKey := range c.serviceKeys() {
+		for _, registration := range c.lookupAll(serviceKey) {
+			validator.visitRegistration(registration, []ServiceKey{serviceKey}, false)
+		}
+	}
+	return errors.Join(validator.errs...)
+}
+
+// serviceKeys retorna as chaves visíveis no contêiner, começando pelas registradas na raiz
+func (c *serviceCollection) serviceKeys() []ServiceKey {
+	var chain []*serviceCollection
+	for current := c; current != nil; current = current.parent {
+		chain = append([]*serviceCollection{current}, chain...)
+	}
+	seen := make(map[ServiceKey]bool)
+	var serviceKeys []ServiceKey
+	for _, collection := range chain {
+		collection.mu.RLock()
+		for _, serviceKey := range collection.order {
+			if !seen[serviceKey] {
+				seen[serviceKey] = true
+				serviceKeys = append(serviceKeys, serviceKey)
+			}
+		}
+		collection.mu.RUnlock()
+	}
+	return serviceKeys
+}
+
+// graphNode identifica um registro visitado por Build; o mesmo registro é visitado de novo abaixo de um Singleton
+type graphNode struct {
+	registration   *registration
+	underSingleton bool
+}
+
+// graphValidator percorre as dependências dos construtores em profundidade, reportando cada problema uma vez
+type graphValidator struct {
+	collection *serviceCollection
+	visited    map[graphNode]bool
+	onPath     map[ServiceKey]bool
+	errs       []error
+}
+
+// visit valida a dependência de um construtor
+func (v *graphValidator) visit(dependency reflect.Type, path []ServiceKey, underSingleton bool) {
+	serviceKey, all := v.collection.dependencyKey(dependency)
+	if serviceKey == (ServiceKey{Type: serviceCollectionType}) {
+		return
+	}
+	if v.onPath[serviceKey] {
+		v.errs = append(v.errs, &ResolutionError{Service: serviceKey, Path: append(path[:len(path):len(path)], serviceKey), Err: ErrCircularDependency})
+		return
+	}
+	path = append(path[:len(path):len(path)], serviceKey)
+	registrations := v.collection.lookupAll(serviceKey)
+	if !all {
+		if len(registrations) == 0 {
+			v.errs = append(v.errs, &ResolutionError{Service: serviceKey, Path: path, Err: ErrServiceNotRegistered})
+			return
+		}
+		registrations = registrations[len(registrations)-1:]
+	}
+	for _, registration := range registrations {
+		v.visitRegistration(registration, path, underSingleton)
+	}
+}
+
+// visitRegistration valida o registro e, em seguida, as dependências do seu construtor
+func (v *graphValidator) visitRegistration(registration *registration, path []ServiceKey, underSingleton bool) {
+	if registration.lifetime == Scoped && underSingleton {
+		v.errs = append(v.errs, &ResolutionError{Service: registration.key, Path: path, Err: ErrScopedInSingleton})
+		return
+	}
+	node := graphNode{registration: registration, underSingleton: underSingleton}
+	if v.visited[node] {
+		return
+	}
+	v.visited[node] = true
+	v.onPath[registration.key] = true
+	defer delete(v.onPath, registration.key)
+	creator := v.collection
+	if registration.lifetime == Singleton {
+		creator = registration.owner
+		underSingleton = true
+	}
+	dependencies := registration.dependencies
+	for _, decorator := range creator.decoratorsFor(registration.key) {
+		dependencies = append(dependencies[:len(dependencies):len(dependencies)], decorator.dependencies...)
+	}
+	for _, dependency := range dependencies {
+		v.visit(dependency, path, underSingleton)
+	}
+}
+
+func checkImplementation(serviceType reflect.Type, implementation interface{}) {
+	implementationValue := reflect.ValueOf(implementation)
+
+	if !implementationValue.Type().AssignableTo(serviceType) {
+		panic("a implementação não satisfaz a interface esperada")
+	}
+}
+
 // Funções auxiliares genéricas (não são parte da interface)
 
 // AddService é uma função helper genérica para adicionar serviços
@@ -46,6 +672,47 @@ func AddService[T any](container IServiceCollection, implementation interface{})
 	return container.AddServiceInstance(typeOfInterface, implementation)
 }
 
+// AddSingleton registra uma fábrica chamada uma única vez, na primeira resolução
+func AddSingleton[T any](container IServiceCollection, factory func(collection IServiceCollection) T) IServiceCollection {
+	return addFactory(container, Singleton, factory)
+}
+
+// AddScoped registra uma fábrica chamada uma vez por escopo
+func AddScoped[T any](container IServiceCollection, factory func(collection IServiceCollection) T) IServiceCollection {
+	return addFactory(container, Scoped, factory)
+}
+
+// AddTransient registra uma fábrica chamada a cada resolução
+func AddTransient[T any](container IServiceCollection, factory func(collection IServiceCollection) T) IServiceCollection {
+	return addFactory(container, Transient, factory)
+}
+
+func addFactory[T any](container IServiceCollection, lifetime Lifetime, factory func(collection IServiceCollection) T) IServiceCollection {
+	return container.AddServiceFactory(reflect.TypeFor[T](), lifetime, func(collection IServiceCollection) interface{} {
+		return factory(collection)
+	})
+}
+
+// AddConstructor registra um construtor cujos parâmetros são resolvidos automaticamente pelo tipo
+func AddConstructor[T any](container IServiceCollection, lifetime Lifetime, constructor interface{}) IServiceCollection {
+	return container.AddServiceConstructor(reflect.TypeFor[T](), lifetime, constructor)
+}
+
+// AddKeyedService registra a instância com uma chave
+func AddKeyedService[T any](container IServiceCollection, key string, implementation interface{}) IServiceCollection {
+	return container.AddKeyedServiceInstance(reflect.TypeFor[T](), key, implementation)
+}
+
+// AddKeyedConstructor registra o construtor com uma chave
+func AddKeyedConstructor[T any](container IServiceCollection, key string, lifetime Lifetime, constructor interface{}) IServiceCollection {
+	return container.AddKeyedServiceConstructor(reflect.TypeFor[T](), key, lifetime, constructor)
+}
+
+// Decorate registra um decorador func(inner T, dependências...) T para os serviços sem chave do tipo T
+func Decorate[T any](container IServiceCollection, decorator interface{}) IServiceCollection {
+	return container.DecorateService(reflect.TypeFor[T](), decorator)
+}
+
 // GetService é uma função helper genérica para obter serviços
 func GetService[T any](container IServiceCollection) T {
 	typeOfInterface := reflect.TypeOf((*T)(nil)).Elem()
@@ -56,3 +723,25 @@ func GetService[T any](container IServiceCollection) T {
 	}
 	return service.(T)
 }
+
+// GetKeyedService retorna o serviço registrado com a chave ou o zero value quando não houver
+func GetKeyedService[T any](container IServiceCollection, key string) T {
+	service := container.GetKeyedServiceByType(reflect.TypeFor[T](), key)
+	if service == nil {
+		var zero T
+		return zero
+	}
+	return service.(T)
+}
+
+// GetServices retorna todos os serviços sem chave do tipo, na ordem de registro
+func GetServices[T any](container IServiceCollection) []T {
+	services := container.GetServicesByType(reflect.TypeFor[T]())
+	result := make([]T, 0, len(services))
+	for _, service := range services {
+		if typed, ok := service.(T); ok {
+			result = append(result, typed)
+		}
+	}
+	return result
+}
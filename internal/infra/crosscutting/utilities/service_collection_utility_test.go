@@ -1,7 +1,11 @@
 package utilities
 
 import (
+	"errors"
 	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 )
 
@@ -109,4 +113,547 @@ func TestGetService(t *testing.T) {
 	if nilService != nil {
 		t.Fatal("GetService deve retornar zero value para tipos não registrados")
 	}
-} 
\ No newline at end of file
+}
+
+func TestAddService_ConcreteType(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	implementation := &MockImplementation{}
+
+	// Tipos concretos podem ser registrados diretamente
+	AddService[*MockImplementation](serviceCollection, implementation)
+
+	if GetService[*MockImplementation](serviceCollection) != implementation {
+		t.Fatal("GetService deve retornar a instância registrada para o tipo concreto")
+	}
+}
+
+func TestLifetime_Singleton(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	created := 0
+	AddSingleton[MockInterface](serviceCollection, func(collection IServiceCollection) MockInterface {
+		created++
+		return &MockImplementation{}
+	})
+
+	// Execução
+	if created != 0 {
+		t.Fatal("A fábrica Singleton só deve ser chamada na primeira resolução")
+	}
+	first := GetService[MockInterface](serviceCollection)
+	second := GetService[MockInterface](serviceCollection.CreateScope())
+
+	// Verificações
+	if first != second || created != 1 {
+		t.Fatal("Singleton deve ser criado uma única vez e compartilhado pelos escopos")
+	}
+}
+
+// scopedDependency é um serviço com estado que depende do valor registrado no escopo
+type scopedDependency struct {
+	name string
+}
+
+func TestLifetime_Transient(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddTransient[*scopedDependency](serviceCollection, func(collection IServiceCollection) *scopedDependency {
+		return &scopedDependency{}
+	})
+
+	// Execução
+	first := GetService[*scopedDependency](serviceCollection)
+	second := GetService[*scopedDependency](serviceCollection)
+
+	// Verificações
+	if first == nil || first == second {
+		t.Fatal("Transient deve criar uma nova instância a cada resolução")
+	}
+}
+
+func TestLifetime_Scoped(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddScoped[*scopedDependency](serviceCollection, func(collection IServiceCollection) *scopedDependency {
+		return &scopedDependency{name: GetService[string](collection)}
+	})
+	scopeA := serviceCollection.CreateScope()
+	AddService[string](scopeA, "a")
+	scopeB := serviceCollection.CreateScope()
+	AddService[string](scopeB, "b")
+
+	// Execução
+	first := GetService[*scopedDependency](scopeA)
+	second := GetService[*scopedDependency](scopeA)
+	other := GetService[*scopedDependency](scopeB)
+
+	// Verificações
+	if first != second {
+		t.Fatal("Scoped deve retornar a mesma instância dentro do escopo")
+	}
+	if first == other || first.name != "a" || other.name != "b" {
+		t.Fatal("Cada escopo deve ter a sua instância, criada com os serviços do escopo")
+	}
+	if GetService[string](serviceCollection) != "" {
+		t.Fatal("Serviços registrados no escopo não devem ser visíveis no contêiner raiz")
+	}
+}
+
+func TestLifetime_ScopedOutsideScope(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	AddScoped[*scopedDependency](serviceCollection, func(collection IServiceCollection) *scopedDependency {
+		return &scopedDependency{}
+	})
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Resolver um serviço Scoped fora de um escopo deve causar panic")
+		}
+	}()
+	GetService[*scopedDependency](serviceCollection)
+}
+
+func TestLifetime_SingletonConcurrent(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	var created int32
+	AddSingleton[*MockImplementation](serviceCollection, func(collection IServiceCollection) *MockImplementation {
+		atomic.AddInt32(&created, 1)
+		return &MockImplementation{}
+	})
+
+	// Execução
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			GetService[*MockImplementation](serviceCollection.CreateScope())
+		}()
+	}
+	wg.Wait()
+
+	// Verificações
+	if atomic.LoadInt32(&created) != 1 {
+		t.Fatal("Resoluções concorrentes devem criar o Singleton uma única vez")
+	}
+}
+
+// wiredService depende de MockInterface e do valor string registrados no contêiner
+type wiredService struct {
+	dependency MockInterface
+	name       string
+}
+
+func newWiredService(dependency MockInterface, name string) *wiredService {
+	return &wiredService{dependency: dependency, name: name}
+}
+
+// cyclicA e cyclicB dependem um do outro
+type cyclicA struct{}
+type cyclicB struct{}
+
+func newCyclicA(*cyclicB) *cyclicA { return &cyclicA{} }
+func newCyclicB(*cyclicA) *cyclicB { return &cyclicB{} }
+
+func TestAddConstructor(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	implementation := &MockImplementation{}
+	AddService[MockInterface](serviceCollection, implementation)
+	AddService[string](serviceCollection, "wired")
+	AddConstructor[*wiredService](serviceCollection, Singleton, newWiredService)
+
+	// Execução
+	service := GetService[*wiredService](serviceCollection)
+
+	// Verificações
+	if service == nil || service.dependency != implementation || service.name != "wired" {
+		t.Fatal("Os parâmetros do construtor devem ser resolvidos pelo tipo")
+	}
+	if GetService[*wiredService](serviceCollection) != service {
+		t.Fatal("O construtor Singleton deve ser chamado uma única vez")
+	}
+	if err := serviceCollection.Build(); err != nil {
+		t.Fatalf("Build não deve retornar erro para um grafo válido: %v", err)
+	}
+}
+
+func TestAddConstructor_ReceivesCollection(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	AddConstructor[IServiceCollection](serviceCollection, Transient, func(collection IServiceCollection) IServiceCollection {
+		return collection
+	})
+	scope := serviceCollection.CreateScope()
+
+	if GetService[IServiceCollection](scope) != scope {
+		t.Fatal("Parâmetros IServiceCollection devem receber o contêiner que resolve o serviço")
+	}
+}
+
+func TestAddConstructor_ReturnsError(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	constructorErr := errors.New("falha")
+	AddConstructor[*wiredService](serviceCollection, Transient, func() (*wiredService, error) {
+		return nil, constructorErr
+	})
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, constructorErr) {
+			t.Fatalf("O erro do construtor deve ser propagado, recebido %v", err)
+		}
+	}()
+	GetService[*wiredService](serviceCollection)
+}
+
+func TestAddConstructor_InvalidSignature(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Construtores que não retornam o serviço devem causar panic")
+		}
+	}()
+	AddConstructor[MockInterface](serviceCollection, Singleton, func() string { return "" })
+}
+
+func TestAddConstructor_UnresolvedDependency(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[string](serviceCollection, "wired")
+	AddConstructor[*wiredService](serviceCollection, Singleton, newWiredService)
+
+	// Execução
+	var err error
+	func() {
+		defer func() { err, _ = recover().(error) }()
+		GetService[*wiredService](serviceCollection)
+	}()
+
+	// Verificações
+	var resolutionErr *ResolutionError
+	if !errors.As(err, &resolutionErr) || !errors.Is(err, ErrServiceNotRegistered) {
+		t.Fatalf("A resolução deve falhar com ErrServiceNotRegistered, recebido %v", err)
+	}
+	if resolutionErr.Service.Type != reflect.TypeFor[MockInterface]() || len(resolutionErr.Path) != 2 ||
+		resolutionErr.Path[0].Type != reflect.TypeFor[*wiredService]() {
+		t.Fatalf("O erro deve indicar o tipo não resolvido e o caminho de resolução, recebido %v", resolutionErr)
+	}
+	if !strings.Contains(err.Error(), "*utilities.wiredService -> utilities.MockInterface") {
+		t.Fatalf("A mensagem deve listar o caminho de resolução, recebido %q", err.Error())
+	}
+}
+
+func TestBuild_ReportsInvalidGraph(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddConstructor[*wiredService](serviceCollection, Singleton, newWiredService)
+	AddService[MockInterface](serviceCollection, &MockImplementation{})
+	AddConstructor[*cyclicA](serviceCollection, Singleton, newCyclicA)
+	AddConstructor[*cyclicB](serviceCollection, Transient, newCyclicB)
+	AddScoped[string](serviceCollection, func(collection IServiceCollection) string { return "scoped" })
+
+	// Execução
+	err := serviceCollection.Build()
+
+	// Verificações
+	if !errors.Is(err, ErrCircularDependency) {
+		t.Fatalf("Build deve detectar dependências circulares, recebido %v", err)
+	}
+	if !errors.Is(err, ErrScopedInSingleton) {
+		t.Fatalf("Build deve detectar serviços Scoped usados por Singletons, recebido %v", err)
+	}
+	if !strings.Contains(err.Error(), "*utilities.cyclicA -> *utilities.cyclicB -> *utilities.cyclicA") {
+		t.Fatalf("O erro deve listar o ciclo, recebido %q", err.Error())
+	}
+}
+
+func TestBuild_ReportsMissingDependency(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	AddConstructor[*wiredService](serviceCollection, Transient, newWiredService)
+
+	err := serviceCollection.Build()
+
+	if !errors.Is(err, ErrServiceNotRegistered) || strings.Count(err.Error(), "unable to resolve") != 2 {
+		t.Fatalf("Build deve listar cada dependência não registrada, recebido %v", err)
+	}
+}
+
+func TestAddConstructor_CycleAtResolution(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	AddConstructor[*cyclicA](serviceCollection, Singleton, newCyclicA)
+	AddConstructor[*cyclicB](serviceCollection, Singleton, newCyclicB)
+
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.Is(err, ErrCircularDependency) {
+			t.Fatalf("Resolver um ciclo deve falhar com ErrCircularDependency, recebido %v", err)
+		}
+	}()
+	GetService[*cyclicA](serviceCollection)
+}
+
+// namedImplementation implementa MockInterface retornando o nome informado
+type namedImplementation struct {
+	name string
+}
+
+func (n *namedImplementation) DoSomething() string {
+	return n.name
+}
+
+func TestAddKeyedService(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "default"})
+	AddKeyedService[MockInterface](serviceCollection, "primary", &namedImplementation{name: "primary"})
+	AddKeyedConstructor[MockInterface](serviceCollection, "secondary", Transient, func() MockInterface {
+		return &namedImplementation{name: "secondary"}
+	})
+
+	// Execução e verificações
+	if GetService[MockInterface](serviceCollection).DoSomething() != "default" {
+		t.Fatal("Registros com chave não devem substituir o registro sem chave")
+	}
+	if GetKeyedService[MockInterface](serviceCollection, "primary").DoSomething() != "primary" ||
+		GetKeyedService[MockInterface](serviceCollection.CreateScope(), "secondary").DoSomething() != "secondary" {
+		t.Fatal("GetKeyedService deve retornar o serviço registrado com a chave")
+	}
+	if GetKeyedService[MockInterface](serviceCollection, "unknown") != nil {
+		t.Fatal("GetKeyedService deve retornar zero value para chaves não registradas")
+	}
+	if len(GetServices[MockInterface](serviceCollection)) != 1 {
+		t.Fatal("GetServices não deve retornar os serviços registrados com chave")
+	}
+}
+
+func TestGetServices(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "first"})
+	AddSingleton[MockInterface](serviceCollection, func(collection IServiceCollection) MockInterface {
+		return &namedImplementation{name: "second"}
+	})
+	scope := serviceCollection.CreateScope()
+	AddService[MockInterface](scope, &namedImplementation{name: "third"})
+
+	// Execução
+	services := GetServices[MockInterface](scope)
+
+	// Verificações
+	var names []string
+	for _, service := range services {
+		names = append(names, service.DoSomething())
+	}
+	if strings.Join(names, ",") != "first,second,third" {
+		t.Fatalf("GetServices deve retornar todas as implementações na ordem de registro, recebido %v", names)
+	}
+	if GetService[MockInterface](scope).DoSomething() != "third" {
+		t.Fatal("GetService deve retornar o último registro")
+	}
+	if len(GetServices[error](serviceCollection)) != 0 {
+		t.Fatal("GetServices deve retornar uma lista vazia para tipos não registrados")
+	}
+}
+
+// compositeService recebe todas as implementações de MockInterface
+type compositeService struct {
+	all []MockInterface
+}
+
+func TestAddConstructor_SliceDependency(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddConstructor[*compositeService](serviceCollection, Transient, func(all []MockInterface) *compositeService {
+		return &compositeService{all: all}
+	})
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "a"})
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "b"})
+
+	// Execução
+	service := GetService[*compositeService](serviceCollection)
+
+	// Verificações
+	if len(service.all) != 2 || service.all[0].DoSomething() != "a" || service.all[1].DoSomething() != "b" {
+		t.Fatal("Parâmetros []T devem receber todas as implementações na ordem de registro")
+	}
+	if err := serviceCollection.Build(); err != nil {
+		t.Fatalf("Build não deve retornar erro para parâmetros []T: %v", err)
+	}
+}
+
+// prefixDecorator envolve MockInterface acrescentando o prefixo ao resultado
+type prefixDecorator struct {
+	inner  MockInterface
+	prefix string
+}
+
+func (p *prefixDecorator) DoSomething() string {
+	return p.prefix + p.inner.DoSomething()
+}
+
+func TestDecorate(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "service"})
+	AddService[string](serviceCollection, "cache:")
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface, prefix string) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: prefix}
+	})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: "metrics:"}
+	})
+
+	// Execução
+	service := GetService[MockInterface](serviceCollection)
+
+	// Verificações
+	if service.DoSomething() != "metrics:cache:service" {
+		t.Fatalf("Os decoradores devem ser aplicados na ordem de registro, recebido %q", service.DoSomething())
+	}
+	if GetService[MockInterface](serviceCollection) != service {
+		t.Fatal("A instância Singleton deve ser decorada uma única vez")
+	}
+	if err := serviceCollection.Build(); err != nil {
+		t.Fatalf("Build não deve retornar erro para decoradores com dependências registradas: %v", err)
+	}
+}
+
+func TestDecorate_Lifetimes(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	decorated := 0
+	AddScoped[*scopedDependency](serviceCollection, func(collection IServiceCollection) *scopedDependency {
+		return &scopedDependency{name: "scoped"}
+	})
+	AddTransient[MockInterface](serviceCollection, func(collection IServiceCollection) MockInterface {
+		return &namedImplementation{name: "transient"}
+	})
+	Decorate[*scopedDependency](serviceCollection, func(inner *scopedDependency) *scopedDependency {
+		decorated++
+		return &scopedDependency{name: "decorated " + inner.name}
+	})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface, dependency *scopedDependency) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: dependency.name + " "}
+	})
+	scope := serviceCollection.CreateScope()
+
+	// Execução
+	first := GetService[MockInterface](scope)
+	second := GetService[MockInterface](scope)
+
+	// Verificações
+	if first.DoSomething() != "decorated scoped transient" || first == second {
+		t.Fatalf("Serviços Transient devem ser decorados a cada resolução, recebido %q", first.DoSomething())
+	}
+	if decorated != 1 {
+		t.Fatal("Serviços Scoped devem ser decorados uma vez por escopo")
+	}
+	GetService[*scopedDependency](serviceCollection.CreateScope())
+	if decorated != 2 {
+		t.Fatal("Cada escopo deve decorar a sua instância")
+	}
+}
+
+func TestDecorate_InvalidSignature(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatal("Decoradores que não recebem a instância decorada devem causar panic")
+		}
+	}()
+	Decorate[MockInterface](serviceCollection, func(name string) MockInterface { return nil })
+}
+
+func TestBuild_ReportsDecoratorDependencies(t *testing.T) {
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &MockImplementation{})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface, prefix string) MockInterface { return inner })
+
+	err := serviceCollection.Build()
+
+	if !errors.Is(err, ErrServiceNotRegistered) || !strings.Contains(err.Error(), "utilities.MockInterface -> string") {
+		t.Fatalf("Build deve validar as dependências dos decoradores, recebido %v", err)
+	}
+}
+
+func TestCreateChild(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	shared := &scopedDependency{name: "shared"}
+	AddService[*scopedDependency](serviceCollection, shared)
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "production"})
+	AddService[string](serviceCollection, "name")
+	AddConstructor[*wiredService](serviceCollection, Singleton, newWiredService)
+	parentService := GetService[*wiredService](serviceCollection)
+
+	// Execução
+	child := serviceCollection.CreateChild()
+	AddService[MockInterface](child, &namedImplementation{name: "fake"})
+	childService := GetService[*wiredService](child)
+
+	// Verificações
+	if childService == parentService || childService.dependency.DoSomething() != "fake" {
+		t.Fatal("Os Singletons do filho devem ser recriados com as dependências substituídas")
+	}
+	if GetService[*wiredService](serviceCollection) != parentService || GetService[MockInterface](serviceCollection).DoSomething() != "production" {
+		t.Fatal("Os registros do filho não devem alterar o contêiner original")
+	}
+	if GetService[*scopedDependency](child) != shared {
+		t.Fatal("As instâncias registradas devem ser compartilhadas com o filho")
+	}
+	if len(GetServices[MockInterface](child)) != 2 || child.Build() != nil {
+		t.Fatal("O filho deve herdar os registros e acrescentar os seus")
+	}
+}
+
+func TestCreateChild_DecorateInheritedInstance(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "root"})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: "root "}
+	})
+	GetService[MockInterface](serviceCollection)
+
+	// Execução
+	child := serviceCollection.CreateChild()
+	Decorate[MockInterface](child, func(inner MockInterface) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: "child "}
+	})
+
+	// Verificações
+	if result := GetService[MockInterface](child).DoSomething(); result != "child root root" {
+		t.Fatalf("O decorador do filho deve ser aplicado à instância herdada, após os herdados, recebido %q", result)
+	}
+	if result := GetService[MockInterface](serviceCollection).DoSomething(); result != "root root" {
+		t.Fatalf("O decorador do filho não deve alterar o contêiner original, recebido %q", result)
+	}
+}
+
+func TestCreateChild_FromScope(t *testing.T) {
+	// Configuração
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "root"})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface) MockInterface {
+		return &prefixDecorator{inner: inner, prefix: "decorated "}
+	})
+	scope := serviceCollection.CreateScope()
+	AddService[string](scope, "scope")
+
+	// Execução
+	child := scope.CreateChild()
+	AddTransient[MockInterface](child, func(collection IServiceCollection) MockInterface {
+		return &namedImplementation{name: GetService[string](collection)}
+	})
+
+	// Verificações
+	if GetService[MockInterface](child).DoSomething() != "decorated scope" {
+		t.Fatal("O filho deve herdar os registros do escopo e os decoradores da raiz")
+	}
+	if GetService[MockInterface](serviceCollection).DoSomething() != "decorated root" {
+		t.Fatal("O contêiner original não deve ser alterado")
+	}
+}
@@ -0,0 +1,126 @@
+package utilities
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"runtime"
+	"strings"
+	"text/tabwriter"
+)
+
+// ServiceDescription descreve um registro do contêiner para diagnóstico
+type ServiceDescription struct {
+	// Service é o tipo do serviço seguido da chave entre colchetes, quando houver
+	Service  string `json:"service"`
+	Lifetime string `json:"lifetime"`
+	// Implementation é o tipo da instância registrada, a função construtora ou "factory"
+	Implementation string   `json:"implementation"`
+	Dependencies   []string `json:"dependencies,omitempty"`
+	// Decorators são as funções decoradoras na ordem em que são aplicadas
+	Decorators            []string `json:"decorators,omitempty"`
+	DecoratorDependencies []string `json:"decoratorDependencies,omitempty"`
+	// Overridden indica um registro substituído por outro posterior; ele só é usado por GetServices
+	Overridden bool `json:"overridden,omitempty"`
+}
+
+// Describe implementa a interface não-genérica
+func (c *serviceCollection) Describe() []ServiceDescription {
+	var descriptions []ServiceDescription
+	for _, serviceKey := range c.serviceKeys() {
+		registrations := c.lookupAll(serviceKey)
+		for i, registration := range registrations {
+			description := ServiceDescription{
+				Service:        serviceKey.String(),
+				Lifetime:       registration.lifetime.String(),
+				Implementation: registration.implementation(),
+				Dependencies:   typeNames(registration.dependencies),
+				Overridden:     i < len(registrations)-1,
+			}
+			creator := c
+			if registration.lifetime == Singleton {
+				creator = registration.owner
+			}
+			for _, decorator := range creator.decoratorsFor(serviceKey) {
+				description.Decorators = append(description.Decorators, functionName(decorator.function))
+				description.DecoratorDependencies = append(description.DecoratorDependencies, typeNames(decorator.dependencies)...)
+			}
+			descriptions = append(descriptions, description)
+		}
+	}
+	return descriptions
+}
+
+// implementation descreve como o registro cria a instância
+func (r *registration) implementation() string {
+	switch {
+	case r.factory != nil:
+		return "factory"
+	case r.constructor.IsValid():
+		return functionName(r.constructor)
+	case r.implementationType != nil:
+		return r.implementationType.String()
+	}
+	return ""
+}
+
+// functionName retorna o nome da função sem o caminho do pacote, como saga.NewManager
+func functionName(function reflect.Value) string {
+	name := runtime.FuncForPC(function.Pointer()).Name()
+	return name[strings.LastIndex(name, "/")+1:]
+}
+
+func typeNames(types []reflect.Type) []string {
+	if len(types) == 0 {
+		return nil
+	}
+	names := make([]string, len(types))
+	for i, serviceType := range types {
+		names[i] = serviceType.String()
+	}
+	return names
+}
+
+// WriteServicesText escreve os serviços em uma tabela de texto
+func WriteServicesText(w io.Writer, services []ServiceDescription) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "SERVICE\tLIFETIME\tIMPLEMENTATION\tDEPENDENCIES\tDECORATORS")
+	for _, service := range services {
+		name := service.Service
+		if service.Overridden {
+			name += " (overridden)"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", name, service.Lifetime, service.Implementation,
+			orDash(strings.Join(service.Dependencies, ", ")), orDash(strings.Join(service.Decorators, ", ")))
+	}
+	return table.Flush()
+}
+
+func orDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// WriteServicesDOT escreve o grafo de dependências no formato DOT do Graphviz. Registros substituídos são omitidos
+// e as dependências dos decoradores aparecem tracejadas.
+func WriteServicesDOT(w io.Writer, services []ServiceDescription) error {
+	var builder strings.Builder
+	builder.WriteString("digraph services {\n\trankdir=LR;\n\tnode [shape=box, fontname=\"Helvetica\"];\n")
+	for _, service := range services {
+		if service.Overridden {
+			continue
+		}
+		fmt.Fprintf(&builder, "\t%q [label=%q];\n", service.Service, service.Service+"\n"+service.Lifetime+" · "+service.Implementation)
+		for _, dependency := range service.Dependencies {
+			fmt.Fprintf(&builder, "\t%q -> %q;\n", service.Service, dependency)
+		}
+		for _, dependency := range service.DecoratorDependencies {
+			fmt.Fprintf(&builder, "\t%q -> %q [style=dashed];\n", service.Service, dependency)
+		}
+	}
+	builder.WriteString("}\n")
+	_, err := io.WriteString(w, builder.String())
+	return err
+}
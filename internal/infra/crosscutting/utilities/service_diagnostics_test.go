@@ -0,0 +1,79 @@
+package utilities
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func newDiagnosticsCollection() IServiceCollection {
+	serviceCollection := NewServiceCollection()
+	AddService[MockInterface](serviceCollection, &MockImplementation{})
+	AddService[MockInterface](serviceCollection, &namedImplementation{name: "last"})
+	AddService[string](serviceCollection, "wired")
+	AddConstructor[*wiredService](serviceCollection, Scoped, newWiredService)
+	AddTransient[*compositeService](serviceCollection, func(collection IServiceCollection) *compositeService {
+		return &compositeService{}
+	})
+	Decorate[MockInterface](serviceCollection, func(inner MockInterface, prefix string) MockInterface { return inner })
+	return serviceCollection
+}
+
+func TestDescribe(t *testing.T) {
+	// Configuração
+	serviceCollection := newDiagnosticsCollection()
+
+	// Execução
+	services := serviceCollection.Describe()
+
+	// Verificações
+	if len(services) != 5 {
+		t.Fatalf("Describe deve retornar todos os registros, recebido %d", len(services))
+	}
+	if !services[0].Overridden || services[1].Overridden || services[1].Implementation != "*utilities.namedImplementation" {
+		t.Fatalf("O registro substituído deve ser indicado, recebido %+v", services[:2])
+	}
+	if len(services[1].Decorators) != 1 || services[1].DecoratorDependencies[0] != "string" {
+		t.Fatalf("Os decoradores e as suas dependências devem ser descritos, recebido %+v", services[1])
+	}
+	wired := services[3]
+	if wired.Service != "*utilities.wiredService" || wired.Lifetime != "scoped" || wired.Implementation != "utilities.newWiredService" ||
+		strings.Join(wired.Dependencies, ",") != "utilities.MockInterface,string" {
+		t.Fatalf("O construtor e as suas dependências devem ser descritos, recebido %+v", wired)
+	}
+	if services[4].Implementation != "factory" || services[4].Lifetime != "transient" {
+		t.Fatalf("As fábricas devem ser descritas, recebido %+v", services[4])
+	}
+	if _, err := json.Marshal(services); err != nil {
+		t.Fatalf("A descrição deve ser serializável em JSON: %v", err)
+	}
+}
+
+func TestWriteServicesText(t *testing.T) {
+	var builder strings.Builder
+
+	err := WriteServicesText(&builder, newDiagnosticsCollection().Describe())
+
+	if err != nil || !strings.HasPrefix(builder.String(), "SERVICE") || !strings.Contains(builder.String(), "utilities.MockInterface (overridden)") ||
+		!strings.Contains(builder.String(), "utilities.newWiredService") {
+		t.Fatalf("A tabela deve listar os serviços, recebido %q", builder.String())
+	}
+}
+
+func TestWriteServicesDOT(t *testing.T) {
+	var builder strings.Builder
+
+	err := WriteServicesDOT(&builder, newDiagnosticsCollection().Describe())
+
+	dot := builder.String()
+	if err != nil || !strings.HasPrefix(dot, "digraph services {") || !strings.HasSuffix(dot, "}\n") {
+		t.Fatalf("O grafo deve estar no formato DOT, recebido %q", dot)
+	}
+	if !strings.Contains(dot, `"*utilities.wiredService" -> "utilities.MockInterface";`) ||
+		!strings.Contains(dot, `"utilities.MockInterface" -> "string" [style=dashed];`) {
+		t.Fatalf("O grafo deve conter as dependências dos construtores e dos decoradores, recebido %q", dot)
+	}
+	if strings.Count(dot, `"utilities.MockInterface" [label=`) != 1 {
+		t.Fatal("Registros substituídos não devem aparecer no grafo")
+	}
+}
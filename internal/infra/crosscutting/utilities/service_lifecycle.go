@@ -0,0 +1,133 @@
+package utilities
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// Starter é implementado pelos serviços iniciados por IServiceCollection.Start
+type Starter interface {
+	Start(ctx context.Context) error
+}
+
+// Stopper é implementado pelos serviços parados por IServiceCollection.Stop. Serviços que implementam apenas
+// io.Closer são fechados com Close.
+type Stopper interface {
+	Stop(ctx context.Context) error
+}
+
+// managedInstance é uma instância criada pelo contêiner que implementa Starter, Stopper ou io.Closer
+type managedInstance struct {
+	instance interface{}
+	started  bool
+}
+
+// track guarda a instância criada pelo contêiner para Start e Stop, na ordem de criação. Como as dependências
+// de um serviço são criadas antes dele, essa ordem respeita as dependências.
+func (c *serviceCollection) track(instance interface{}) {
+	switch instance.(type) {
+	case Starter, Stopper, io.Closer:
+	default:
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if reflect.TypeOf(instance).Comparable() {
+		for _, managed := range c.managed {
+			if reflect.TypeOf(managed.instance).Comparable() && managed.instance == instance {
+				return
+			}
+		}
+	}
+	c.managed = append(c.managed, &managedInstance{instance: instance})
+}
+
+// Start implementa a interface não-genérica
+func (c *serviceCollection) Start(ctx context.Context) error {
+	for _, serviceKey := range c.serviceKeys() {
+		registration := c.lookup(serviceKey)
+		if registration.lifetime != Singleton {
+			continue
+		}
+		if _, err := c.instance(registration, []ServiceKey{serviceKey}); err != nil {
+			return err
+		}
+	}
+
+	c.mu.RLock()
+	managed := append([]*managedInstance{}, c.managed...)
+	c.mu.RUnlock()
+	for _, current := range managed {
+		starter, ok := current.instance.(Starter)
+		if !ok || current.started {
+			continue
+		}
+		if err := starter.Start(ctx); err != nil {
+			return errors.Join(fmt.Errorf("start %T: %w", current.instance, err), rollback(ctx, managed))
+		}
+		current.started = true
+	}
+	return nil
+}
+
+// rollback para, em ordem inversa, apenas as instâncias iniciadas por Start. As demais continuam sendo
+// acompanhadas e são paradas por Stop.
+func rollback(ctx context.Context, managed []*managedInstance) error {
+	var errs []error
+	for i := len(managed) - 1; i >= 0; i-- {
+		if !managed[i].started {
+			continue
+		}
+		managed[i].started = false
+		if err := stopInstance(ctx, managed[i].instance); err != nil {
+			errs = append(errs, fmt.Errorf("stop %T: %w", managed[i].instance, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Stop implementa a interface não-genérica
+func (c *serviceCollection) Stop(ctx context.Context) error {
+	c.mu.Lock()
+	managed := c.managed
+	c.managed = nil
+	c.mu.Unlock()
+
+	var errs []error
+	for i := len(managed) - 1; i >= 0; i-- {
+		if err := stopInstance(ctx, managed[i].instance); err != nil {
+			errs = append(errs, fmt.Errorf("stop %T: %w", managed[i].instance, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// stopInstance para a instância, desistindo de esperar quando o prazo do contexto vence
+func stopInstance(ctx context.Context, instance interface{}) error {
+	var stop func() error
+	switch service := instance.(type) {
+	case Stopper:
+		stop = func() error { return service.Stop(ctx) }
+	case io.Closer:
+		stop = service.Close
+	default:
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		done <- stop()
+	}()
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
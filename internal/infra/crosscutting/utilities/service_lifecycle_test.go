@@ -0,0 +1,162 @@
+package utilities
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// lifecycleLog registra a ordem das chamadas de Start, Stop e Close
+type lifecycleLog struct {
+	calls []string
+}
+
+// startStopService é um serviço iniciado e parado pelo contêiner
+type startStopService struct {
+	name     string
+	log      *lifecycleLog
+	startErr error
+	block    chan struct{}
+}
+
+func (s *startStopService) Start(ctx context.Context) error {
+	s.log.calls = append(s.log.calls, "start "+s.name)
+	return s.startErr
+}
+
+func (s *startStopService) Stop(ctx context.Context) error {
+	s.log.calls = append(s.log.calls, "stop "+s.name)
+	if s.block != nil {
+		<-s.block
+	}
+	return nil
+}
+
+// closerService implementa apenas io.Closer
+type closerService struct {
+	log *lifecycleLog
+}
+
+func (c *closerService) Close() error {
+	c.log.calls = append(c.log.calls, "close")
+	return nil
+}
+
+// dependentService depende de *closerService e de *startStopService
+type dependentService struct {
+	startStopService
+}
+
+func TestLifecycle_StartAndStopInDependencyOrder(t *testing.T) {
+	// Configuração
+	log := &lifecycleLog{}
+	serviceCollection := NewServiceCollection()
+	AddConstructor[*dependentService](serviceCollection, Singleton, func(_ *closerService, _ *startStopService) *dependentService {
+		return &dependentService{startStopService{name: "dependent", log: log}}
+	})
+	AddConstructor[*startStopService](serviceCollection, Singleton, func() *startStopService {
+		return &startStopService{name: "dependency", log: log}
+	})
+	AddService[*closerService](serviceCollection, &closerService{log: log})
+
+	// Execução
+	startErr := serviceCollection.Start(context.Background())
+	stopErr := serviceCollection.Stop(context.Background())
+
+	// Verificações
+	if startErr != nil || stopErr != nil {
+		t.Fatalf("Start e Stop não devem retornar erro: %v, %v", startErr, stopErr)
+	}
+	expected := "start dependency,start dependent,stop dependent,stop dependency,close"
+	if strings.Join(log.calls, ",") != expected {
+		t.Fatalf("Os serviços devem ser iniciados na ordem das dependências e parados em ordem inversa, recebido %v", log.calls)
+	}
+}
+
+func TestLifecycle_StartFailureStopsStartedServices(t *testing.T) {
+	// Configuração
+	log := &lifecycleLog{}
+	startErr := errors.New("falha")
+	serviceCollection := NewServiceCollection()
+	AddService[*startStopService](serviceCollection, &startStopService{name: "first", log: log})
+	AddService[*dependentService](serviceCollection, &dependentService{startStopService{name: "second", log: log, startErr: startErr}})
+
+	// Execução
+	err := serviceCollection.Start(context.Background())
+
+	// Verificações
+	if !errors.Is(err, startErr) {
+		t.Fatalf("O erro de Start deve ser retornado, recebido %v", err)
+	}
+	if strings.Join(log.calls, ",") != "start first,start second,stop first" {
+		t.Fatalf("Apenas os serviços iniciados devem ser parados quando a inicialização falha, recebido %v", log.calls)
+	}
+}
+
+// thirdService é um terceiro serviço iniciado e parado pelo contêiner
+type thirdService struct {
+	startStopService
+}
+
+func TestLifecycle_StartFailureStopsOnlyStartedServices(t *testing.T) {
+	// Configuração
+	log := &lifecycleLog{}
+	startErr := errors.New("falha")
+	serviceCollection := NewServiceCollection()
+	AddService[*startStopService](serviceCollection, &startStopService{name: "first", log: log})
+	AddService[*dependentService](serviceCollection, &dependentService{startStopService{name: "second", log: log, startErr: startErr}})
+	AddService[*thirdService](serviceCollection, &thirdService{startStopService{name: "third", log: log}})
+
+	// Execução
+	err := serviceCollection.Start(context.Background())
+
+	// Verificações
+	if !errors.Is(err, startErr) {
+		t.Fatalf("O erro de Start deve ser retornado, recebido %v", err)
+	}
+	if strings.Join(log.calls, ",") != "start first,start second,stop first" {
+		t.Fatalf("Apenas o serviço iniciado antes da falha deve ser parado, recebido %v", log.calls)
+	}
+}
+
+func TestLifecycle_StopDeadline(t *testing.T) {
+	// Configuração
+	block := make(chan struct{})
+	defer close(block)
+	serviceCollection := NewServiceCollection()
+	AddService[*startStopService](serviceCollection, &startStopService{name: "slow", log: &lifecycleLog{}, block: block})
+	GetService[*startStopService](serviceCollection)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Execução
+	err := serviceCollection.Stop(ctx)
+
+	// Verificações
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop deve desistir de esperar quando o prazo vence, recebido %v", err)
+	}
+}
+
+func TestLifecycle_ScopeStopsScopedServices(t *testing.T) {
+	// Configuração
+	log := &lifecycleLog{}
+	serviceCollection := NewServiceCollection()
+	AddScoped[*closerService](serviceCollection, func(collection IServiceCollection) *closerService {
+		return &closerService{log: log}
+	})
+	AddService[*startStopService](serviceCollection, &startStopService{name: "singleton", log: log})
+	scope := serviceCollection.CreateScope()
+	GetService[*closerService](scope)
+	GetService[*startStopService](scope)
+
+	// Execução
+	err := scope.Stop(context.Background())
+
+	// Verificações
+	if err != nil || strings.Join(log.calls, ",") != "close" {
+		t.Fatalf("O escopo deve fechar apenas os serviços Scoped criados nele, recebido %v, %v", log.calls, err)
+	}
+}
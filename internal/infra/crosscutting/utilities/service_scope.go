@@ -0,0 +1,19 @@
+package utilities
+
+import "context"
+
+type serviceScopeKey struct{}
+
+// WithScope retorna uma cópia do contexto com o escopo de serviços da requisição ou do job
+func WithScope(ctx context.Context, scope IServiceCollection) context.Context {
+	return context.WithValue(ctx, serviceScopeKey{}, scope)
+}
+
+// ScopeFromContext retorna o escopo de serviços guardado no contexto ou nil quando não houver
+func ScopeFromContext(ctx context.Context) IServiceCollection {
+	if ctx == nil {
+		return nil
+	}
+	scope, _ := ctx.Value(serviceScopeKey{}).(IServiceCollection)
+	return scope
+}
@@ -0,0 +1,39 @@
+package audit
+
+import (
+	"flickly/internal/domain/core/audit"
+	"sync"
+)
+
+// AuditMemoryStore é a trilha de auditoria em memória; os registros só podem ser incluídos
+type AuditMemoryStore struct {
+	entries []audit.Entry
+	mu      sync.RWMutex
+}
+
+// NewAuditMemoryStore cria uma nova trilha de auditoria em memória
+func NewAuditMemoryStore() *AuditMemoryStore {
+	return &AuditMemoryStore{}
+}
+
+func (s *AuditMemoryStore) Append(entry *audit.Entry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, *entry)
+	return nil
+}
+
+func (s *AuditMemoryStore) Find(filter audit.Filter) ([]audit.Entry, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var entries []audit.Entry
+	for i := len(s.entries) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(entries) == filter.Limit {
+			break
+		}
+		if filter.Matches(s.entries[i]) {
+			entries = append(entries, s.entries[i])
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,31 @@
+package audit
+
+import (
+	"flickly/internal/domain/core/audit"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAuditMemoryStore_AppendFind(t *testing.T) {
+	// Configuração
+	store := NewAuditMemoryStore()
+	now := time.Now()
+	_ = store.Append(&audit.Entry{Actor: "user-1", RequestType: "CreateUserCommand", Timestamp: now.Add(-time.Hour)})
+	_ = store.Append(&audit.Entry{Actor: "user-2", RequestType: "UpdateUserCommand", Timestamp: now})
+	_ = store.Append(&audit.Entry{Actor: "user-1", RequestType: "UpdateUserCommand", Timestamp: now.Add(time.Second)})
+
+	// Execução
+	all, err := store.Find(audit.Filter{})
+	byActor, _ := store.Find(audit.Filter{Actor: "user-1"})
+	limited, _ := store.Find(audit.Filter{RequestType: "UpdateUserCommand", Limit: 1})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, all, 3, "Sem filtro todos os registros devem ser retornados")
+	assert.Equal(t, "user-1", all[0].Actor, "Os registros mais recentes devem vir primeiro")
+	assert.Len(t, byActor, 2, "O filtro por ator deve ser aplicado")
+	assert.Len(t, limited, 1, "O limite deve ser respeitado")
+	assert.Equal(t, "user-1", limited[0].Actor)
+}
@@ -0,0 +1,96 @@
+package audit
+
+import (
+	"context"
+	"flickly/internal/domain/core/audit"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"strings"
+)
+
+// AuditSQLSchema cria a tabela usada por AuditSQLStore. A aplicação apenas inclui registros;
+// em produção o usuário do banco não deve ter permissão de UPDATE ou DELETE nessa tabela.
+const AuditSQLSchema = `CREATE TABLE IF NOT EXISTS audit_entries (
+	id UUID PRIMARY KEY,
+	timestamp TIMESTAMP NOT NULL,
+	actor TEXT NOT NULL,
+	request_type TEXT NOT NULL,
+	entity_id TEXT NOT NULL DEFAULT '',
+	payload TEXT NOT NULL,
+	result TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	correlation_id TEXT NOT NULL DEFAULT ''
+)`
+
+const auditSQLColumns = `id, timestamp, actor, request_type, entity_id, payload, result, error, correlation_id`
+
+// AuditSQLStore é a implementação de audit.Store em banco de dados SQL
+type AuditSQLStore struct {
+	db sqlstore.DBTX
+}
+
+// NewAuditSQLStore cria uma trilha de auditoria que usa a conexão informada
+func NewAuditSQLStore(db sqlstore.DBTX) *AuditSQLStore {
+	return &AuditSQLStore{db: db}
+}
+
+func (s *AuditSQLStore) Append(entry *audit.Entry) error {
+	_, err := s.db.ExecContext(context.Background(),
+		`INSERT INTO audit_entries (`+auditSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		entry.ID, entry.Timestamp, entry.Actor, entry.RequestType, entry.EntityID, string(entry.Payload),
+		entry.Result, entry.Error, entry.CorrelationID)
+	return err
+}
+
+func (s *AuditSQLStore) Find(filter audit.Filter) ([]audit.Entry, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Actor != "" {
+		addCondition("actor = $%d", filter.Actor)
+	}
+	if filter.EntityID != "" {
+		addCondition("entity_id = $%d", filter.EntityID)
+	}
+	if filter.RequestType != "" {
+		addCondition("request_type = $%d", filter.RequestType)
+	}
+	if filter.From != nil {
+		addCondition("timestamp >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("timestamp < $%d", *filter.To)
+	}
+
+	query := `SELECT ` + auditSQLColumns + ` FROM audit_entries`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY timestamp DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+
+	rows, err := s.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []audit.Entry
+	for rows.Next() {
+		var entry audit.Entry
+		var payload string
+		if err := rows.Scan(&entry.ID, &entry.Timestamp, &entry.Actor, &entry.RequestType, &entry.EntityID, &payload,
+			&entry.Result, &entry.Error, &entry.CorrelationID); err != nil {
+			return nil, err
+		}
+		entry.Payload = []byte(payload)
+		entries = append(entries, entry)
+	}
+	return entries, rows.Err()
+}
@@ -0,0 +1,74 @@
+package audit
+
+import (
+	"flickly/internal/domain/core/audit"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var auditSQLColumnNames = []string{"id", "timestamp", "actor", "request_type", "entity_id", "payload", "result", "error", "correlation_id"}
+
+func TestAuditSQLStore_Append(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	entry, _ := audit.NewEntry("user-1", "CreateUserCommand", map[string]string{"password": "x"}, nil, nil, "corr-1")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO audit_entries")).
+		WithArgs(entry.ID, entry.Timestamp, "user-1", "CreateUserCommand", "", `{"password":"***"}`, audit.ResultSuccess, "", "corr-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewAuditSQLStore(db).Append(entry)
+
+	// Verificações
+	assert.NoError(t, err, "Append não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditSQLStore_Find(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	from := time.Now().Add(-time.Hour)
+	to := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM audit_entries WHERE actor = $1 AND entity_id = $2 AND request_type = $3 AND timestamp >= $4 AND timestamp < $5 ORDER BY timestamp DESC LIMIT $6")).
+		WithArgs("user-1", "e-1", "UpdateUserCommand", from, to, 10).
+		WillReturnRows(sqlmock.NewRows(auditSQLColumnNames).
+			AddRow(id, to, "user-1", "UpdateUserCommand", "e-1", `{"name":"a"}`, audit.ResultFailure, "conflict", "corr-1"))
+
+	// Execução
+	entries, err := NewAuditSQLStore(db).Find(audit.Filter{
+		Actor: "user-1", EntityID: "e-1", RequestType: "UpdateUserCommand", From: &from, To: &to, Limit: 10,
+	})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, id, entries[0].ID, "O ID deve ser lido corretamente")
+	assert.JSONEq(t, `{"name":"a"}`, string(entries[0].Payload), "O payload deve ser lido corretamente")
+	assert.Equal(t, "conflict", entries[0].Error)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestAuditSQLStore_FindWithoutFilter(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT " + auditSQLColumns + " FROM audit_entries ORDER BY timestamp DESC")).
+		WithArgs().
+		WillReturnRows(sqlmock.NewRows(auditSQLColumnNames))
+
+	// Execução
+	entries, err := NewAuditSQLStore(db).Find(audit.Filter{})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
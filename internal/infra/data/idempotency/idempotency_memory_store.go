@@ -0,0 +1,81 @@
+package idempotency
+
+import (
+	"flickly/internal/domain/core/idempotency"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// purgeInterval é o intervalo mínimo entre as remoções dos registros expirados
+const purgeInterval = time.Minute
+
+// IdempotencyMemoryStore guarda os registros de idempotência em memória; serve a uma única instância da aplicação.
+// Os registros expirados são removidos durante as reservas.
+type IdempotencyMemoryStore struct {
+	records   map[string]idempotency.Record
+	lastPurge time.Time
+	mu        sync.Mutex
+}
+
+// NewIdempotencyMemoryStore cria um armazenamento de idempotência em memória vazio
+func NewIdempotencyMemoryStore() *IdempotencyMemoryStore {
+	return &IdempotencyMemoryStore{records: make(map[string]idempotency.Record)}
+}
+
+func (s *IdempotencyMemoryStore) Reserve(record idempotency.Record) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	if now.Sub(s.lastPurge) >= purgeInterval {
+		s.purge(now)
+	}
+	if existing, ok := s.records[record.Key]; ok && !existing.Expired(now) {
+		return &existing, false, nil
+	}
+	s.records[record.Key] = record
+	return nil, true, nil
+}
+
+func (s *IdempotencyMemoryStore) Complete(key string, response idempotency.Response, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	record, ok := s.records[key]
+	if !ok {
+		return fmt.Errorf("idempotency key %s not found", key)
+	}
+	record.Status = idempotency.StatusCompleted
+	record.StatusCode = response.StatusCode
+	record.Headers = response.Headers
+	record.Body = response.Body
+	record.ExpiresAt = expiresAt
+	s.records[key] = record
+	return nil
+}
+
+func (s *IdempotencyMemoryStore) Release(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+// Purge remove os registros expirados e retorna quantos foram removidos
+func (s *IdempotencyMemoryStore) Purge(now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.purge(now), nil
+}
+
+// purge remove os registros expirados
+func (s *IdempotencyMemoryStore) purge(now time.Time) int64 {
+	var removed int64
+	for key, record := range s.records {
+		if record.Expired(now) {
+			delete(s.records, key)
+			removed++
+		}
+	}
+	s.lastPurge = now
+	return removed
+}
@@ -0,0 +1,79 @@
+package idempotency
+
+import (
+	"flickly/internal/domain/core/idempotency"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIdempotencyMemoryStore_ReserveComplete(t *testing.T) {
+	// Configuração
+	store := NewIdempotencyMemoryStore()
+	record := idempotency.NewRecord("key-1", "abc", time.Minute)
+
+	// Execução
+	_, reserved, err := store.Reserve(record)
+	inProgress, reservedAgain, _ := store.Reserve(record)
+	completeErr := store.Complete("key-1", idempotency.Response{StatusCode: 201, Headers: map[string]string{"Location": "/user/1"}, Body: []byte(`{}`)}, time.Now().Add(time.Hour))
+	completed, _, _ := store.Reserve(record)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NoError(t, completeErr)
+	assert.True(t, reserved, "Uma chave livre deve ser reservada")
+	assert.False(t, reservedAgain, "Uma chave ocupada não deve ser reservada de novo")
+	assert.Equal(t, idempotency.StatusInProgress, inProgress.Status)
+	assert.Equal(t, idempotency.StatusCompleted, completed.Status, "A resposta deve ser guardada")
+	assert.Equal(t, 201, completed.StatusCode)
+	assert.Equal(t, "/user/1", completed.Headers["Location"])
+	assert.Equal(t, []byte(`{}`), completed.Body)
+}
+
+func TestIdempotencyMemoryStore_ExpiredAndRelease(t *testing.T) {
+	// Configuração
+	store := NewIdempotencyMemoryStore()
+	_, _, _ = store.Reserve(idempotency.NewRecord("expired", "abc", -time.Second))
+	_, _, _ = store.Reserve(idempotency.NewRecord("released", "abc", time.Minute))
+
+	// Execução
+	releaseErr := store.Release("released")
+	_, expiredReserved, _ := store.Reserve(idempotency.NewRecord("expired", "def", time.Minute))
+	_, releasedReserved, _ := store.Reserve(idempotency.NewRecord("released", "def", time.Minute))
+
+	// Verificações
+	assert.NoError(t, releaseErr)
+	assert.True(t, expiredReserved, "Registros expirados devem ser substituídos")
+	assert.True(t, releasedReserved, "Chaves liberadas devem poder ser reservadas")
+	assert.Error(t, store.Complete("unknown", idempotency.Response{}, time.Now()), "Chaves desconhecidas devem ser reportadas")
+}
+
+func TestIdempotencyMemoryStore_Purge(t *testing.T) {
+	// Configuração
+	store := NewIdempotencyMemoryStore()
+	_, _, _ = store.Reserve(idempotency.NewRecord("expired", "abc", -time.Second))
+	store.lastPurge = time.Time{}
+
+	// Execução
+	_, _, _ = store.Reserve(idempotency.NewRecord("other", "abc", time.Minute))
+
+	// Verificações
+	assert.NotContains(t, store.records, "expired", "Os registros expirados devem ser removidos durante as reservas")
+	assert.Contains(t, store.records, "other")
+}
+
+func TestIdempotencyMemoryStore_PurgeNow(t *testing.T) {
+	// Configuração
+	store := NewIdempotencyMemoryStore()
+	_, _, _ = store.Reserve(idempotency.NewRecord("expired", "abc", -time.Second))
+	_, _, _ = store.Reserve(idempotency.NewRecord("other", "abc", time.Minute))
+
+	// Execução
+	removed, err := store.Purge(time.Now())
+
+	// Verificações
+	assert.NoError(t, err, "Purge não deve retornar erro")
+	assert.Equal(t, int64(1), removed, "Apenas os registros expirados devem ser removidos")
+	assert.Contains(t, store.records, "other")
+}
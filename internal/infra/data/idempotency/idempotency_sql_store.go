@@ -0,0 +1,122 @@
+package idempotency
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"flickly/internal/domain/core/idempotency"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"time"
+)
+
+// IdempotencySQLSchema cria a tabela usada por IdempotencySQLStore
+const IdempotencySQLSchema = `CREATE TABLE IF NOT EXISTS idempotency_records (
+	key TEXT PRIMARY KEY,
+	fingerprint TEXT NOT NULL,
+	status TEXT NOT NULL,
+	status_code INT NOT NULL DEFAULT 0,
+	headers TEXT NOT NULL DEFAULT '{}',
+	body BYTEA NULL,
+	created_at TIMESTAMP NOT NULL,
+	expires_at TIMESTAMP NOT NULL
+)`
+
+const idempotencySQLColumns = `key, fingerprint, status, status_code, headers, body, created_at, expires_at`
+
+// reserveAttempts limita as tentativas quando o registro encontrado é liberado antes de ser lido
+const reserveAttempts = 3
+
+// IdempotencySQLStore é a implementação de idempotency.Store em banco de dados SQL, compartilhada entre instâncias
+type IdempotencySQLStore struct {
+	db sqlstore.DBTX
+}
+
+// NewIdempotencySQLStore cria um armazenamento de idempotência que usa a conexão informada
+func NewIdempotencySQLStore(db sqlstore.DBTX) *IdempotencySQLStore {
+	return &IdempotencySQLStore{db: db}
+}
+
+// Reserve grava o registro com um único INSERT ... ON CONFLICT, que só substitui registros expirados.
+// Se a chave estiver ocupada, lê o registro existente.
+func (s *IdempotencySQLStore) Reserve(record idempotency.Record) (*idempotency.Record, bool, error) {
+	for attempt := 0; attempt < reserveAttempts; attempt++ {
+		result, err := s.db.ExecContext(context.Background(),
+			`INSERT INTO idempotency_records (`+idempotencySQLColumns+`) VALUES ($1, $2, $3, 0, '{}', NULL, $4, $5)
+			ON CONFLICT (key) DO UPDATE SET fingerprint = EXCLUDED.fingerprint, status = EXCLUDED.status,
+				status_code = 0, headers = '{}', body = NULL, created_at = EXCLUDED.created_at, expires_at = EXCLUDED.expires_at
+			WHERE idempotency_records.expires_at <= EXCLUDED.created_at`,
+			record.Key, record.Fingerprint, record.Status, record.CreatedAt, record.ExpiresAt)
+		if err != nil {
+			return nil, false, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return nil, false, err
+		}
+		if affected > 0 {
+			return nil, true, nil
+		}
+		existing, err := s.get(record.Key)
+		if err != nil {
+			return nil, false, err
+		}
+		if existing != nil {
+			return existing, false, nil
+		}
+	}
+	return nil, false, fmt.Errorf("idempotency key %s could not be reserved", record.Key)
+}
+
+func (s *IdempotencySQLStore) Complete(key string, response idempotency.Response, expiresAt time.Time) error {
+	headers, err := json.Marshal(response.Headers)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(context.Background(),
+		`UPDATE idempotency_records SET status = $1, status_code = $2, headers = $3, body = $4, expires_at = $5 WHERE key = $6`,
+		idempotency.StatusCompleted, response.StatusCode, string(headers), response.Body, expiresAt, key)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("idempotency key %s not found", key)
+	}
+	return nil
+}
+
+func (s *IdempotencySQLStore) Release(key string) error {
+	_, err := s.db.ExecContext(context.Background(), `DELETE FROM idempotency_records WHERE key = $1`, key)
+	return err
+}
+
+// Purge remove os registros expirados e retorna quantos foram removidos
+func (s *IdempotencySQLStore) Purge(now time.Time) (int64, error) {
+	result, err := s.db.ExecContext(context.Background(), `DELETE FROM idempotency_records WHERE expires_at <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+func (s *IdempotencySQLStore) get(key string) (*idempotency.Record, error) {
+	var record idempotency.Record
+	var headers string
+	err := s.db.QueryRowContext(context.Background(), `SELECT `+idempotencySQLColumns+` FROM idempotency_records WHERE key = $1`, key).
+		Scan(&record.Key, &record.Fingerprint, &record.Status, &record.StatusCode, &headers, &record.Body, &record.CreatedAt, &record.ExpiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = json.Unmarshal([]byte(headers), &record.Headers); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
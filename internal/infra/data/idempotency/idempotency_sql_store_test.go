@@ -0,0 +1,115 @@
+package idempotency
+
+import (
+	"flickly/internal/domain/core/idempotency"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var idempotencySQLColumnNames = []string{"key", "fingerprint", "status", "status_code", "headers", "body", "created_at", "expires_at"}
+
+func TestIdempotencySQLStore_Reserve(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	record := idempotency.NewRecord("key-1", "abc", time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_records")).
+		WithArgs("key-1", "abc", idempotency.StatusInProgress, record.CreatedAt, record.ExpiresAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	existing, reserved, err := NewIdempotencySQLStore(db).Reserve(record)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, reserved, "A chave livre deve ser reservada")
+	assert.Nil(t, existing)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencySQLStore_ReserveExisting(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	record := idempotency.NewRecord("key-1", "abc", time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (key) DO UPDATE")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM idempotency_records WHERE key = $1")).WithArgs("key-1").
+		WillReturnRows(sqlmock.NewRows(idempotencySQLColumnNames).
+			AddRow("key-1", "abc", idempotency.StatusCompleted, 201, `{"Location":"/user/1"}`, []byte(`{}`), record.CreatedAt, record.ExpiresAt))
+
+	// Execução
+	existing, reserved, err := NewIdempotencySQLStore(db).Reserve(record)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.False(t, reserved, "Uma chave ocupada não deve ser reservada")
+	assert.Equal(t, idempotency.StatusCompleted, existing.Status)
+	assert.Equal(t, 201, existing.StatusCode)
+	assert.Equal(t, "/user/1", existing.Headers["Location"], "Os cabeçalhos devem ser lidos")
+	assert.Equal(t, []byte(`{}`), existing.Body)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencySQLStore_ReserveReleasedMeanwhile(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	record := idempotency.NewRecord("key-1", "abc", time.Minute)
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_records")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM idempotency_records")).WillReturnRows(sqlmock.NewRows(idempotencySQLColumnNames))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO idempotency_records")).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	_, reserved, err := NewIdempotencySQLStore(db).Reserve(record)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.True(t, reserved, "Se o registro foi liberado antes de ser lido, a reserva deve ser repetida")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencySQLStore_CompleteRelease(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	expiresAt := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE idempotency_records SET status = $1")).
+		WithArgs(idempotency.StatusCompleted, 201, `{"Location":"/user/1"}`, []byte(`{}`), expiresAt, "key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE idempotency_records")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_records WHERE key = $1")).WithArgs("key-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	store := NewIdempotencySQLStore(db)
+
+	// Execução
+	completeErr := store.Complete("key-1", idempotency.Response{StatusCode: 201, Headers: map[string]string{"Location": "/user/1"}, Body: []byte(`{}`)}, expiresAt)
+	unknownErr := store.Complete("unknown", idempotency.Response{}, expiresAt)
+	releaseErr := store.Release("key-1")
+
+	// Verificações
+	assert.NoError(t, completeErr)
+	assert.Error(t, unknownErr, "Chaves desconhecidas devem ser reportadas")
+	assert.NoError(t, releaseErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestIdempotencySQLStore_Purge(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM idempotency_records WHERE expires_at <= $1")).WithArgs(now).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+
+	// Execução
+	purged, err := NewIdempotencySQLStore(db).Purge(now)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged, "Os registros expirados devem ser removidos")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
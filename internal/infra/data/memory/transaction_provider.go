@@ -0,0 +1,106 @@
+package memory
+
+import (
+	"context"
+	"flickly/internal/domain/core/uow"
+	"sync"
+)
+
+// TransactionProvider abre transações sobre armazenamentos em memória.
+// As transações são serializadas: apenas uma fica aberta por vez em todo o processo, o que limita o
+// armazenamento em memória a um escritor transacional por vez e o torna adequado apenas a testes e desenvolvimento.
+type TransactionProvider struct {
+	lock chan struct{}
+}
+
+// NewTransactionProvider cria um novo provedor de transações em memória
+func NewTransactionProvider() *TransactionProvider {
+	return &TransactionProvider{lock: make(chan struct{}, 1)}
+}
+
+// Begin abre uma transação, aguardando o término da transação corrente se houver.
+// Retorna o erro do contexto se ele for cancelado durante a espera.
+func (p *TransactionProvider) Begin(ctx context.Context) (uow.Transaction, error) {
+	select {
+	case p.lock <- struct{}{}:
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return &Transaction{provider: p}, nil
+}
+
+// Transaction acumula as escritas dos armazenamentos vinculados a ela e as aplica, na ordem em que foram feitas,
+// apenas no commit. O rollback descarta as escritas acumuladas; as alterações feitas fora da transação, como as
+// do worker de jobs ou do relay do outbox, nunca são desfeitas.
+type Transaction struct {
+	provider *TransactionProvider
+	checked  []func() error
+	writes   []func()
+	finished bool
+	mu       sync.Mutex
+}
+
+// Defer acumula uma escrita para ser aplicada no commit. A escrita não pode falhar: as validações devem ser
+// feitas antes, enquanto a transação ainda pode ser desfeita.
+func (t *Transaction) Defer(write func()) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.finished {
+		return uow.ErrTransactionFinished
+	}
+	t.writes = append(t.writes, write)
+	return nil
+}
+
+// DeferChecked acumula uma escrita que valida novamente o estado do armazenamento no commit, como o controle
+// de concorrência otimista, e só o altera se a validação passar. As escritas verificadas são aplicadas antes
+// das demais; a primeira que falhar interrompe o commit, descarta as escritas seguintes e tem o seu erro
+// retornado por Commit. Escritas verificadas de armazenamentos diferentes não são atômicas entre si.
+func (t *Transaction) DeferChecked(write func() error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.finished {
+		return uow.ErrTransactionFinished
+	}
+	t.checked = append(t.checked, write)
+	return nil
+}
+
+// Commit aplica as escritas acumuladas
+func (t *Transaction) Commit() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.finished {
+		return uow.ErrTransactionFinished
+	}
+	defer t.finish()
+	for _, write := range t.checked {
+		if err := write(); err != nil {
+			return err
+		}
+	}
+	for _, write := range t.writes {
+		write()
+	}
+	return nil
+}
+
+// Rollback descarta as escritas acumuladas
+func (t *Transaction) Rollback() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.finished {
+		return uow.ErrTransactionFinished
+	}
+	t.finish()
+	return nil
+}
+
+// finish encerra a transação, descartando as escritas acumuladas e liberando o provedor para a próxima.
+// Deve ser chamado com mu bloqueado.
+func (t *Transaction) finish() {
+	t.finished = true
+	t.checked = nil
+	t.writes = nil
+	<-t.provider.lock
+}
@@ -0,0 +1,122 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/uow"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransaction_Commit(t *testing.T) {
+	// Configuração
+	provider := NewTransactionProvider()
+	var applied []int
+
+	// Execução
+	tx, err := provider.Begin(context.Background())
+	assert.NoError(t, err)
+	assert.NoError(t, tx.(*Transaction).Defer(func() { applied = append(applied, 1) }))
+	assert.NoError(t, tx.(*Transaction).Defer(func() { applied = append(applied, 2) }))
+	assert.Empty(t, applied, "As escritas não devem ser aplicadas antes do commit")
+	err = tx.Commit()
+
+	// Verificações
+	assert.NoError(t, err, "Commit não deve retornar erro")
+	assert.Equal(t, []int{1, 2}, applied, "As escritas devem ser aplicadas no commit, na ordem em que foram feitas")
+	assert.Equal(t, uow.ErrTransactionFinished, tx.Commit(), "Uma transação finalizada não pode ser confirmada novamente")
+	assert.Equal(t, uow.ErrTransactionFinished, tx.(*Transaction).Defer(func() {}), "Uma transação finalizada não aceita escritas")
+}
+
+func TestTransaction_Rollback(t *testing.T) {
+	// Configuração
+	provider := NewTransactionProvider()
+	applied := false
+
+	// Execução
+	tx, _ := provider.Begin(context.Background())
+	assert.NoError(t, tx.(*Transaction).Defer(func() { applied = true }))
+	err := tx.Rollback()
+
+	// Verificações
+	assert.NoError(t, err, "Rollback não deve retornar erro")
+	assert.False(t, applied, "As escritas da transação devem ser descartadas")
+	assert.Equal(t, uow.ErrTransactionFinished, tx.Rollback(), "Uma transação finalizada não pode ser desfeita novamente")
+}
+
+func TestTransaction_CommitChecked(t *testing.T) {
+	// Configuração
+	provider := NewTransactionProvider()
+	var applied []string
+	conflict := errors.New("conflict")
+
+	// Execução
+	tx, _ := provider.Begin(context.Background())
+	assert.NoError(t, tx.(*Transaction).Defer(func() { applied = append(applied, "write") }))
+	assert.NoError(t, tx.(*Transaction).DeferChecked(func() error {
+		applied = append(applied, "checked")
+		return nil
+	}))
+	assert.NoError(t, tx.(*Transaction).DeferChecked(func() error { return conflict }))
+	assert.NoError(t, tx.(*Transaction).DeferChecked(func() error {
+		applied = append(applied, "after conflict")
+		return nil
+	}))
+	err := tx.Commit()
+
+	// Verificações
+	assert.Equal(t, conflict, err, "O erro da escrita verificada deve ser retornado pelo commit")
+	assert.Equal(t, []string{"checked"}, applied, "As escritas verificadas vêm antes das demais e as seguintes à falha são descartadas")
+	assert.Equal(t, uow.ErrTransactionFinished, tx.Rollback(), "Um commit com falha também encerra a transação")
+	next, err := provider.Begin(context.Background())
+	assert.NoError(t, err, "Um commit com falha deve liberar o provedor")
+	assert.NoError(t, next.Rollback())
+}
+
+func TestTransactionProvider_SerializesTransactions(t *testing.T) {
+	// Configuração
+	provider := NewTransactionProvider()
+	first, _ := provider.Begin(context.Background())
+	started := make(chan struct{})
+
+	// Execução
+	go func() {
+		second, _ := provider.Begin(context.Background())
+		close(started)
+		_ = second.Commit()
+	}()
+
+	// Verificações
+	select {
+	case <-started:
+		t.Fatal("A segunda transação não deve começar antes do fim da primeira")
+	case <-time.After(20 * time.Millisecond):
+	}
+	assert.NoError(t, first.Commit())
+	select {
+	case <-started:
+	case <-time.After(time.Second):
+		t.Fatal("A segunda transação deve começar após o fim da primeira")
+	}
+}
+
+func TestTransactionProvider_BeginHonorsCancellation(t *testing.T) {
+	// Configuração
+	provider := NewTransactionProvider()
+	first, _ := provider.Begin(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Execução
+	second, err := provider.Begin(ctx)
+
+	// Verificações
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "A espera pela transação corrente deve respeitar o contexto")
+	assert.Nil(t, second)
+	assert.NoError(t, first.Commit())
+	third, err := provider.Begin(context.Background())
+	assert.NoError(t, err, "A desistência não deve impedir as próximas transações")
+	assert.NoError(t, third.Rollback())
+}
@@ -0,0 +1,126 @@
+package saga
+
+import (
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/memory"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaMemoryStore é a implementação em memória de saga.Store
+type SagaMemoryStore struct {
+	States []saga.State
+	mu     sync.RWMutex
+}
+
+// NewSagaMemoryStore cria um armazenamento de sagas em memória vazio
+func NewSagaMemoryStore() *SagaMemoryStore {
+	return &SagaMemoryStore{}
+}
+
+func (s *SagaMemoryStore) Create(state *saga.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.States = append(s.States, clone(*state))
+	return nil
+}
+
+func (s *SagaMemoryStore) Save(state *saga.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.States {
+		if s.States[i].ID == state.ID {
+			s.States[i] = clone(*state)
+			return nil
+		}
+	}
+	return fmt.Errorf("saga %s not found", state.ID)
+}
+
+func (s *SagaMemoryStore) Get(id uuid.UUID) (*saga.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, state := range s.States {
+		if state.ID == id {
+			found := clone(state)
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (s *SagaMemoryStore) Claim(now time.Time, lease time.Duration, limit int) ([]saga.State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var ready []*saga.State
+	for i := range s.States {
+		state := &s.States[i]
+		if (state.Status == saga.StatusRunning || state.Status == saga.StatusCompensating) && !state.NextAttemptAt.After(now) {
+			ready = append(ready, state)
+		}
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].CreatedAt.Before(ready[j].CreatedAt)
+	})
+	if limit > 0 && len(ready) > limit {
+		ready = ready[:limit]
+	}
+	claimed := make([]saga.State, 0, len(ready))
+	for _, state := range ready {
+		state.NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, clone(*state))
+	}
+	return claimed, nil
+}
+
+func (s *SagaMemoryStore) Find(filter saga.Filter) ([]saga.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var found []saga.State
+	for _, state := range s.States {
+		if filter.Matches(state) {
+			found = append(found, clone(state))
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		return found[i].UpdatedAt.After(found[j].UpdatedAt)
+	})
+	if filter.Limit > 0 && len(found) > filter.Limit {
+		found = found[:filter.Limit]
+	}
+	return found, nil
+}
+
+// WithTransaction cria um armazenamento que inclui as sagas criadas apenas no commit da transação em memória
+// informada. As demais operações não participam da transação.
+func (s *SagaMemoryStore) WithTransaction(tx uow.Transaction) saga.Store {
+	memoryTransaction, ok := tx.(*memory.Transaction)
+	if !ok {
+		return s
+	}
+	return &transactionSagaMemoryStore{SagaMemoryStore: s, tx: memoryTransaction}
+}
+
+// transactionSagaMemoryStore é o armazenamento usado dentro de uma transação em memória
+type transactionSagaMemoryStore struct {
+	*SagaMemoryStore
+	tx *memory.Transaction
+}
+
+func (s *transactionSagaMemoryStore) Create(state *saga.State) error {
+	created := clone(*state)
+	return s.tx.Defer(func() {
+		_ = s.SagaMemoryStore.Create(&created)
+	})
+}
+
+// clone copia o histórico para que alterações no estado retornado não afetem o armazenado
+func clone(state saga.State) saga.State {
+	state.History = append([]saga.Transition{}, state.History...)
+	return state
+}
@@ -0,0 +1,118 @@
+package saga
+
+import (
+	"context"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/data/memory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestState(name string) *saga.State {
+	return saga.NewState(name, []byte(`{}`), time.Minute, security.Principal{Subject: "user-1"}, "corr-1")
+}
+
+func TestSagaMemoryStore_CreateSaveGet(t *testing.T) {
+	// Configuração
+	store := NewSagaMemoryStore()
+	state := newTestState("DeleteAccount")
+
+	// Execução
+	createErr := store.Create(state)
+	state.Step = 1
+	state.History = append(state.History, saga.Transition{Step: "sessions", Action: saga.ActionExecute})
+	saveErr := store.Save(state)
+	found, _ := store.Get(state.ID)
+	missing, _ := store.Get(uuid.New())
+	found.History[0].Step = "changed"
+	again, _ := store.Get(state.ID)
+
+	// Verificações
+	assert.NoError(t, createErr)
+	assert.NoError(t, saveErr)
+	assert.Equal(t, 1, found.Step, "O estado gravado deve ser retornado")
+	assert.Nil(t, missing, "Sagas inexistentes devem retornar nil")
+	assert.Equal(t, "sessions", again.History[0].Step, "Alterar o estado retornado não deve afetar o armazenado")
+	assert.Error(t, store.Save(newTestState("Other")), "Gravar uma saga inexistente deve retornar erro")
+}
+
+func TestSagaMemoryStore_Claim(t *testing.T) {
+	// Configuração
+	store := NewSagaMemoryStore()
+	running := newTestState("DeleteAccount")
+	compensating := newTestState("DeleteAccount")
+	compensating.Status = saga.StatusCompensating
+	completed := newTestState("DeleteAccount")
+	completed.Status = saga.StatusCompleted
+	later := newTestState("DeleteAccount")
+	later.NextAttemptAt = time.Now().Add(time.Hour)
+	for _, state := range []*saga.State{running, compensating, completed, later} {
+		_ = store.Create(state)
+	}
+	now := time.Now()
+
+	// Execução
+	claimed, err := store.Claim(now, time.Minute, 10)
+	again, _ := store.Claim(now, time.Minute, 10)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 2, "Apenas sagas em andamento e prontas devem ser reservadas")
+	assert.Equal(t, now.Add(time.Minute), claimed[0].NextAttemptAt, "A reserva deve adiar a próxima execução")
+	assert.Empty(t, again, "Sagas reservadas não devem ser reservadas de novo antes do fim da reserva")
+}
+
+func TestSagaMemoryStore_Find(t *testing.T) {
+	// Configuração
+	store := NewSagaMemoryStore()
+	failed := newTestState("DeleteAccount")
+	failed.Status = saga.StatusFailed
+	failed.UpdatedAt = time.Now().Add(time.Second)
+	_ = store.Create(newTestState("DeleteAccount"))
+	_ = store.Create(failed)
+	_ = store.Create(newTestState("Other"))
+
+	// Execução
+	all, _ := store.Find(saga.Filter{})
+	byStatus, _ := store.Find(saga.Filter{Status: saga.StatusFailed})
+	byName, _ := store.Find(saga.Filter{Name: "DeleteAccount", Limit: 1})
+
+	// Verificações
+	assert.Len(t, all, 3)
+	assert.Len(t, byStatus, 1, "O filtro por estado deve ser aplicado")
+	assert.Equal(t, []uuid.UUID{failed.ID}, []uuid.UUID{byName[0].ID}, "A saga atualizada mais recentemente deve vir primeiro")
+}
+
+func TestSagaMemoryStore_WithTransaction(t *testing.T) {
+	// Configuração
+	store := NewSagaMemoryStore()
+	running := newTestState("running")
+	assert.NoError(t, store.Create(running))
+	provider := memory.NewTransactionProvider()
+
+	// Execução - transação desfeita
+	tx, _ := provider.Begin(context.Background())
+	assert.NoError(t, store.WithTransaction(tx).Create(newTestState("rolled back")))
+	running.Step = 1
+	assert.NoError(t, store.Save(running))
+	assert.NoError(t, tx.Rollback())
+
+	// Verificações
+	assert.Len(t, store.States, 1, "Sagas criadas em transação desfeita devem ser descartadas")
+	stored, _ := store.Get(running.ID)
+	assert.Equal(t, 1, stored.Step, "As alterações feitas fora da transação não devem ser desfeitas")
+
+	// Execução - transação confirmada
+	tx, _ = provider.Begin(context.Background())
+	committed := newTestState("committed")
+	assert.NoError(t, store.WithTransaction(tx).Create(committed))
+	assert.NoError(t, tx.Commit())
+
+	// Verificações
+	stored, _ = store.Get(committed.ID)
+	assert.NotNil(t, stored, "Sagas criadas em transação confirmada devem ser incluídas")
+}
@@ -0,0 +1,173 @@
+package saga
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SagaSQLSchema cria a tabela usada por SagaSQLStore
+const SagaSQLSchema = `CREATE TABLE IF NOT EXISTS sagas (
+	id UUID PRIMARY KEY,
+	name TEXT NOT NULL,
+	status TEXT NOT NULL,
+	step INT NOT NULL,
+	data TEXT NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	last_error TEXT NOT NULL DEFAULT '',
+	history TEXT NOT NULL,
+	deadline TIMESTAMP NOT NULL,
+	next_attempt_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	principal TEXT NOT NULL,
+	correlation_id TEXT NOT NULL DEFAULT ''
+)`
+
+const sagaSQLColumns = `id, name, status, step, data, attempts, last_error, history, deadline, next_attempt_at, created_at, updated_at, principal, correlation_id`
+
+// SagaSQLStore é a implementação de saga.Store em banco de dados SQL
+type SagaSQLStore struct {
+	db sqlstore.DBTX
+}
+
+// NewSagaSQLStore cria um armazenamento de sagas que usa a conexão ou transação informada
+func NewSagaSQLStore(db sqlstore.DBTX) *SagaSQLStore {
+	return &SagaSQLStore{db: db}
+}
+
+func (s *SagaSQLStore) Create(state *saga.State) error {
+	history, principal, err := encode(state)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(context.Background(),
+		`INSERT INTO sagas (`+sagaSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)`,
+		state.ID, state.Name, state.Status, state.Step, string(state.Data), state.Attempts, state.LastError, history,
+		state.Deadline, state.NextAttemptAt, state.CreatedAt, state.UpdatedAt, principal, state.CorrelationID)
+	return err
+}
+
+func (s *SagaSQLStore) Save(state *saga.State) error {
+	history, _, err := encode(state)
+	if err != nil {
+		return err
+	}
+	result, err := s.db.ExecContext(context.Background(),
+		`UPDATE sagas SET status = $1, step = $2, data = $3, attempts = $4, last_error = $5, history = $6,
+			next_attempt_at = $7, updated_at = $8 WHERE id = $9`,
+		state.Status, state.Step, string(state.Data), state.Attempts, state.LastError, history,
+		state.NextAttemptAt, state.UpdatedAt, state.ID)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("saga %s not found", state.ID)
+	}
+	return nil
+}
+
+func (s *SagaSQLStore) Get(id uuid.UUID) (*saga.State, error) {
+	found, err := s.query(`SELECT `+sagaSQLColumns+` FROM sagas WHERE id = $1`, id)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return &found[0], nil
+}
+
+// Claim usa FOR UPDATE SKIP LOCKED para que executores concorrentes não reservem a mesma saga
+func (s *SagaSQLStore) Claim(now time.Time, lease time.Duration, limit int) ([]saga.State, error) {
+	return s.query(`UPDATE sagas SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM sagas WHERE status IN ($2, $3) AND next_attempt_at <= $4
+			ORDER BY created_at LIMIT $5 FOR UPDATE SKIP LOCKED
+		) RETURNING `+sagaSQLColumns,
+		now.Add(lease), saga.StatusRunning, saga.StatusCompensating, now, limit)
+}
+
+func (s *SagaSQLStore) Find(filter saga.Filter) ([]saga.State, error) {
+	var conditions []string
+	var args []interface{}
+	addCondition := func(condition string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+	if filter.Name != "" {
+		addCondition("name = $%d", filter.Name)
+	}
+	if filter.Status != "" {
+		addCondition("status = $%d", filter.Status)
+	}
+
+	query := `SELECT ` + sagaSQLColumns + ` FROM sagas`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY updated_at DESC`
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(` LIMIT $%d`, len(args))
+	}
+	return s.query(query, args...)
+}
+
+// WithTransaction cria um armazenamento que grava as sagas na transação SQL informada.
+// Outros tipos de transação não são suportados e o próprio armazenamento é retornado.
+func (s *SagaSQLStore) WithTransaction(tx uow.Transaction) saga.Store {
+	sqlTransaction, ok := tx.(*sqlstore.Transaction)
+	if !ok {
+		return s
+	}
+	return NewSagaSQLStore(sqlTransaction)
+}
+
+func (s *SagaSQLStore) query(query string, args ...interface{}) ([]saga.State, error) {
+	rows, err := s.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []saga.State
+	for rows.Next() {
+		var state saga.State
+		var data, history, principal string
+		if err := rows.Scan(&state.ID, &state.Name, &state.Status, &state.Step, &data, &state.Attempts, &state.LastError,
+			&history, &state.Deadline, &state.NextAttemptAt, &state.CreatedAt, &state.UpdatedAt, &principal, &state.CorrelationID); err != nil {
+			return nil, err
+		}
+		state.Data = []byte(data)
+		if err := json.Unmarshal([]byte(history), &state.History); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(principal), &state.Principal); err != nil {
+			return nil, err
+		}
+		found = append(found, state)
+	}
+	return found, rows.Err()
+}
+
+// encode serializa o histórico e o usuário da saga
+func encode(state *saga.State) (string, string, error) {
+	history, err := json.Marshal(state.History)
+	if err != nil {
+		return "", "", err
+	}
+	principal, err := json.Marshal(state.Principal)
+	if err != nil {
+		return "", "", err
+	}
+	return string(history), string(principal), nil
+}
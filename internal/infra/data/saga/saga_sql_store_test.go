@@ -0,0 +1,126 @@
+package saga
+
+import (
+	"context"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/infra/data/memory"
+	"flickly/internal/infra/data/sqlstore"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var sagaSQLColumnNames = []string{"id", "name", "status", "step", "data", "attempts", "last_error", "history", "deadline",
+	"next_attempt_at", "created_at", "updated_at", "principal", "correlation_id"}
+
+func TestSagaSQLStore_Create(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	state := newTestState("DeleteAccount")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO sagas")).
+		WithArgs(state.ID, "DeleteAccount", saga.StatusRunning, 0, `{}`, 0, "", `[]`, state.Deadline, state.NextAttemptAt,
+			state.CreatedAt, state.UpdatedAt, `{"sub":"user-1"}`, "corr-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewSagaSQLStore(db).Create(state)
+
+	// Verificações
+	assert.NoError(t, err, "Create não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSagaSQLStore_Save(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	state := newTestState("DeleteAccount")
+	state.Status = saga.StatusCompensating
+	state.Step = 2
+	state.LastError = "boom"
+	state.History = []saga.Transition{{Step: "media", Action: saga.ActionExecute, Error: "boom", At: state.CreatedAt}}
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sagas SET status = $1, step = $2")).
+		WithArgs(saga.StatusCompensating, 2, `{}`, 0, "boom", sqlmock.AnyArg(), state.NextAttemptAt, state.UpdatedAt, state.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE sagas")).WillReturnResult(sqlmock.NewResult(0, 0))
+	store := NewSagaSQLStore(db)
+
+	// Execução e verificações
+	assert.NoError(t, store.Save(state), "Save não deve retornar erro")
+	assert.Error(t, store.Save(state), "Gravar uma saga inexistente deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSagaSQLStore_Claim(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE SKIP LOCKED")).
+		WithArgs(now.Add(time.Minute), saga.StatusRunning, saga.StatusCompensating, now, 5).
+		WillReturnRows(sqlmock.NewRows(sagaSQLColumnNames).
+			AddRow(id, "DeleteAccount", saga.StatusCompensating, 1, `{"userId":"1"}`, 2, "boom",
+				`[{"step":"sessions","action":"execute","at":"2024-01-01T00:00:00Z"}]`, now, now.Add(time.Minute),
+				now, now, `{"sub":"user-1","roles":["admin"]}`, "corr-1"))
+
+	// Execução
+	claimed, err := NewSagaSQLStore(db).Claim(now, time.Minute, 5)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, id, claimed[0].ID, "O ID deve ser lido corretamente")
+	assert.JSONEq(t, `{"userId":"1"}`, string(claimed[0].Data), "Os dados devem ser lidos corretamente")
+	assert.Equal(t, "sessions", claimed[0].History[0].Step, "O histórico deve ser lido corretamente")
+	assert.True(t, claimed[0].Principal.HasRole("admin"), "O usuário deve ser lido corretamente")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSagaSQLStore_GetAndFind(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sagas WHERE id = $1")).WillReturnRows(sqlmock.NewRows(sagaSQLColumnNames))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sagas WHERE name = $1 AND status = $2 ORDER BY updated_at DESC LIMIT $3")).
+		WithArgs("DeleteAccount", saga.StatusFailed, 10).
+		WillReturnRows(sqlmock.NewRows(sagaSQLColumnNames))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM sagas ORDER BY updated_at DESC")).WillReturnRows(sqlmock.NewRows(sagaSQLColumnNames))
+	store := NewSagaSQLStore(db)
+
+	// Execução
+	missing, getErr := store.Get(uuid.New())
+	_, filteredErr := store.Find(saga.Filter{Name: "DeleteAccount", Status: saga.StatusFailed, Limit: 10})
+	_, allErr := store.Find(saga.Filter{})
+
+	// Verificações
+	assert.NoError(t, getErr)
+	assert.Nil(t, missing, "Sagas inexistentes devem retornar nil")
+	assert.NoError(t, filteredErr)
+	assert.NoError(t, allErr)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestSagaSQLStore_WithTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	store := NewSagaSQLStore(db)
+	sqlTransaction, _ := sqlstore.NewTransactionProvider(db).Begin(context.Background())
+	memoryTransaction, _ := memory.NewTransactionProvider().Begin(context.Background())
+	defer memoryTransaction.Rollback()
+
+	// Execução
+	bound := store.WithTransaction(sqlTransaction)
+	fallback := store.WithTransaction(memoryTransaction)
+
+	// Verificações
+	assert.NotSame(t, store, bound, "O armazenamento deve ser vinculado à transação SQL")
+	assert.Same(t, store, fallback, "Com outros tipos de transação, o armazenamento sem transação deve ser usado")
+}
@@ -0,0 +1,156 @@
+package schedule
+
+import (
+	"flickly/internal/domain/core/schedule"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ScheduleMemoryStore é a implementação em memória de schedule.Store; serve a uma única instância da aplicação
+type ScheduleMemoryStore struct {
+	States map[string]schedule.State
+	// History guarda as execuções de cada tarefa, das mais antigas para as mais recentes
+	History map[string][]schedule.Run
+	mu      sync.RWMutex
+}
+
+// NewScheduleMemoryStore cria um armazenamento de tarefas agendadas em memória vazio
+func NewScheduleMemoryStore() *ScheduleMemoryStore {
+	return &ScheduleMemoryStore{
+		States:  make(map[string]schedule.State),
+		History: make(map[string][]schedule.Run),
+	}
+}
+
+func (s *ScheduleMemoryStore) Sync(state *schedule.State) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	existing, ok := s.States[state.Name]
+	if !ok {
+		s.States[state.Name] = *state
+		return nil
+	}
+	if existing.Spec != state.Spec {
+		existing.Spec = state.Spec
+		existing.NextRunAt = state.NextRunAt
+		existing.UpdatedAt = state.UpdatedAt
+		s.States[state.Name] = existing
+	}
+	return nil
+}
+
+func (s *ScheduleMemoryStore) Claim(names []string, now time.Time, lease time.Duration, limit int) ([]schedule.State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var claimed []schedule.State
+	for _, name := range names {
+		state, ok := s.States[name]
+		if !ok || !state.Due(now) || state.LockedUntil.After(now) {
+			continue
+		}
+		if limit > 0 && len(claimed) == limit {
+			break
+		}
+		state.LockedUntil = now.Add(lease)
+		s.States[name] = state
+		claimed = append(claimed, state)
+	}
+	return claimed, nil
+}
+
+func (s *ScheduleMemoryStore) Finish(run *schedule.Run, lockedUntil, nextRunAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.States[run.Name]
+	if !ok {
+		return fmt.Errorf("scheduled task %s not found", run.Name)
+	}
+	if !state.LockedUntil.Equal(lockedUntil) {
+		return fmt.Errorf("%w: %s", schedule.ErrLeaseLost, run.Name)
+	}
+	state.NextRunAt = nextRunAt
+	state.LockedUntil = time.Time{}
+	state.Triggered = false
+	state.LastRunAt = run.StartedAt
+	state.LastStatus = run.Status
+	state.LastError = run.Error
+	state.UpdatedAt = run.FinishedAt
+	s.States[run.Name] = state
+
+	history := append(s.History[run.Name], *run)
+	if len(history) > schedule.MaxRuns {
+		history = history[len(history)-schedule.MaxRuns:]
+	}
+	s.History[run.Name] = history
+	return nil
+}
+
+func (s *ScheduleMemoryStore) Get(name string) (*schedule.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if state, ok := s.States[name]; ok {
+		return &state, nil
+	}
+	return nil, nil
+}
+
+func (s *ScheduleMemoryStore) List() ([]schedule.State, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	states := make([]schedule.State, 0, len(s.States))
+	for _, state := range s.States {
+		states = append(states, state)
+	}
+	sort.Slice(states, func(i, j int) bool {
+		return states[i].Name < states[j].Name
+	})
+	return states, nil
+}
+
+func (s *ScheduleMemoryStore) Runs(name string, limit int) ([]schedule.Run, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	history := s.History[name]
+	runs := make([]schedule.Run, 0, len(history))
+	for i := len(history) - 1; i >= 0; i-- {
+		if limit > 0 && len(runs) == limit {
+			break
+		}
+		runs = append(runs, history[i])
+	}
+	return runs, nil
+}
+
+func (s *ScheduleMemoryStore) Pause(name string) (bool, error) {
+	return s.update(name, func(state *schedule.State) {
+		state.Paused = true
+	})
+}
+
+func (s *ScheduleMemoryStore) Resume(name string, nextRunAt time.Time) (bool, error) {
+	return s.update(name, func(state *schedule.State) {
+		state.Paused = false
+		state.NextRunAt = nextRunAt
+	})
+}
+
+func (s *ScheduleMemoryStore) Trigger(name string) (bool, error) {
+	return s.update(name, func(state *schedule.State) {
+		state.Triggered = true
+	})
+}
+
+func (s *ScheduleMemoryStore) update(name string, update func(state *schedule.State)) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.States[name]
+	if !ok {
+		return false, nil
+	}
+	update(&state)
+	state.UpdatedAt = time.Now()
+	s.States[name] = state
+	return true, nil
+}
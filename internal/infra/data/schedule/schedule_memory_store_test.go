@@ -0,0 +1,132 @@
+package schedule
+
+import (
+	"flickly/internal/domain/core/schedule"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestRun(name string, startedAt time.Time) *schedule.Run {
+	return &schedule.Run{ID: uuid.New(), Name: name, Trigger: schedule.TriggerSchedule, Status: schedule.RunSucceeded,
+		ScheduledAt: startedAt, StartedAt: startedAt, FinishedAt: startedAt.Add(time.Second)}
+}
+
+func TestScheduleMemoryStore_Sync(t *testing.T) {
+	// Configuração
+	store := NewScheduleMemoryStore()
+	now := time.Now()
+	_ = store.Sync(&schedule.State{Name: "purge", Spec: "@hourly", NextRunAt: now})
+	_, _ = store.Pause("purge")
+
+	// Execução
+	sameErr := store.Sync(&schedule.State{Name: "purge", Spec: "@hourly", NextRunAt: now.Add(time.Hour)})
+	same, _ := store.Get("purge")
+	changedErr := store.Sync(&schedule.State{Name: "purge", Spec: "@daily", NextRunAt: now.Add(2 * time.Hour)})
+	changed, _ := store.Get("purge")
+
+	// Verificações
+	assert.NoError(t, sameErr)
+	assert.NoError(t, changedErr)
+	assert.Equal(t, now, same.NextRunAt, "A próxima execução não deve mudar se a expressão for a mesma")
+	assert.Equal(t, "@daily", changed.Spec, "Uma nova expressão deve ser gravada")
+	assert.Equal(t, now.Add(2*time.Hour), changed.NextRunAt, "Uma nova expressão deve reagendar a tarefa")
+	assert.True(t, changed.Paused, "A pausa deve ser mantida entre as inicializações")
+}
+
+func TestScheduleMemoryStore_Claim(t *testing.T) {
+	// Configuração
+	store := NewScheduleMemoryStore()
+	now := time.Now()
+	_ = store.Sync(&schedule.State{Name: "due", NextRunAt: now})
+	_ = store.Sync(&schedule.State{Name: "later", NextRunAt: now.Add(time.Hour)})
+	_ = store.Sync(&schedule.State{Name: "paused", NextRunAt: now})
+	_ = store.Sync(&schedule.State{Name: "triggered", NextRunAt: now.Add(time.Hour)})
+	_ = store.Sync(&schedule.State{Name: "unknown", NextRunAt: now})
+	_, _ = store.Pause("paused")
+	_, _ = store.Trigger("triggered")
+	names := []string{"due", "later", "paused", "triggered"}
+
+	// Execução
+	claimed, err := store.Claim(names, now, time.Minute, 10)
+	again, _ := store.Claim(names, now.Add(30*time.Second), time.Minute, 10)
+	expired, _ := store.Claim(names, now.Add(2*time.Minute), time.Minute, 1)
+
+	// Verificações
+	assert.NoError(t, err, "Claim não deve retornar erro")
+	assert.Len(t, claimed, 2, "Apenas tarefas prontas ou com execução manual devem ser reservadas")
+	assert.Equal(t, "due", claimed[0].Name)
+	assert.Equal(t, "triggered", claimed[1].Name)
+	assert.Empty(t, again, "Tarefas reservadas não devem ser reservadas por outra instância")
+	assert.Len(t, expired, 1, "Tarefas com reserva vencida devem ser reservadas de novo, respeitando o limite")
+}
+
+func TestScheduleMemoryStore_FinishAndRuns(t *testing.T) {
+	// Configuração
+	store := NewScheduleMemoryStore()
+	now := time.Now()
+	_ = store.Sync(&schedule.State{Name: "purge", NextRunAt: now})
+
+	// Execução
+	for i := 0; i < schedule.MaxRuns+5; i++ {
+		_, _ = store.Trigger("purge")
+		claimed, _ := store.Claim([]string{"purge"}, now, time.Minute, 1)
+		assert.NoError(t, store.Finish(newTestRun("purge", now.Add(time.Duration(i)*time.Minute)), claimed[0].LockedUntil, now.Add(time.Hour)))
+	}
+	state, _ := store.Get("purge")
+	runs, _ := store.Runs("purge", 3)
+	all, _ := store.Runs("purge", 0)
+
+	// Verificações
+	assert.Equal(t, now.Add(time.Hour), state.NextRunAt, "A próxima execução deve ser gravada")
+	assert.True(t, state.LockedUntil.IsZero(), "A reserva deve ser liberada")
+	assert.False(t, state.Triggered, "A execução manual deve ser consumida")
+	assert.Equal(t, schedule.RunSucceeded, state.LastStatus)
+	assert.Len(t, runs, 3)
+	assert.Equal(t, now.Add(time.Duration(schedule.MaxRuns+4)*time.Minute), runs[0].StartedAt, "As execuções mais recentes devem vir primeiro")
+	assert.Len(t, all, schedule.MaxRuns, "O histórico deve ser limitado")
+	assert.Error(t, store.Finish(newTestRun("unknown", now), now, now), "Finalizar uma tarefa inexistente deve retornar erro")
+}
+
+func TestScheduleMemoryStore_FinishLeaseLost(t *testing.T) {
+	// Configuração
+	store := NewScheduleMemoryStore()
+	now := time.Now()
+	_ = store.Sync(&schedule.State{Name: "purge", NextRunAt: now})
+	expired, _ := store.Claim([]string{"purge"}, now, time.Minute, 1)
+	_, _ = store.Claim([]string{"purge"}, now.Add(2*time.Minute), time.Minute, 1)
+
+	// Execução
+	err := store.Finish(newTestRun("purge", now), expired[0].LockedUntil, now.Add(time.Hour))
+	runs, _ := store.Runs("purge", 0)
+
+	// Verificações
+	assert.ErrorIs(t, err, schedule.ErrLeaseLost, "Finalizar com uma reserva vencida e obtida por outra instância deve falhar")
+	assert.Empty(t, runs, "A execução não deve ser gravada sem a reserva")
+}
+
+func TestScheduleMemoryStore_PauseResumeTrigger(t *testing.T) {
+	// Configuração
+	store := NewScheduleMemoryStore()
+	now := time.Now()
+	_ = store.Sync(&schedule.State{Name: "purge", NextRunAt: now})
+
+	// Execução
+	paused, _ := store.Pause("purge")
+	pausedState, _ := store.Get("purge")
+	resumed, _ := store.Resume("purge", now.Add(time.Hour))
+	resumedState, _ := store.Get("purge")
+	missing, _ := store.Trigger("unknown")
+	states, _ := store.List()
+
+	// Verificações
+	assert.True(t, paused)
+	assert.True(t, pausedState.Paused)
+	assert.True(t, resumed)
+	assert.False(t, resumedState.Paused)
+	assert.Equal(t, now.Add(time.Hour), resumedState.NextRunAt, "A retomada deve reagendar a tarefa")
+	assert.False(t, missing, "Tarefas inexistentes devem ser reportadas")
+	assert.Len(t, states, 1)
+}
@@ -0,0 +1,185 @@
+package schedule
+
+import (
+	"context"
+	"database/sql"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ScheduleSQLMigrations contém as instruções, em ordem, para criar as tabelas usadas por ScheduleSQLStore
+var ScheduleSQLMigrations = []string{
+	`CREATE TABLE IF NOT EXISTS scheduled_tasks (
+	name TEXT PRIMARY KEY,
+	spec TEXT NOT NULL,
+	paused BOOLEAN NOT NULL DEFAULT FALSE,
+	triggered BOOLEAN NOT NULL DEFAULT FALSE,
+	next_run_at TIMESTAMP NOT NULL,
+	locked_until TIMESTAMP NOT NULL,
+	last_run_at TIMESTAMP NULL,
+	last_status TEXT NOT NULL DEFAULT '',
+	last_error TEXT NOT NULL DEFAULT '',
+	updated_at TIMESTAMP NOT NULL
+)`,
+	`CREATE TABLE IF NOT EXISTS scheduled_task_runs (
+	id UUID PRIMARY KEY,
+	name TEXT NOT NULL,
+	trigger TEXT NOT NULL,
+	status TEXT NOT NULL,
+	error TEXT NOT NULL DEFAULT '',
+	scheduled_at TIMESTAMP NOT NULL,
+	started_at TIMESTAMP NOT NULL,
+	finished_at TIMESTAMP NOT NULL
+)`,
+	`CREATE INDEX IF NOT EXISTS scheduled_task_runs_name_started_at ON scheduled_task_runs (name, started_at DESC)`,
+}
+
+const scheduleSQLColumns = `name, spec, paused, triggered, next_run_at, locked_until, last_run_at, last_status, last_error, updated_at`
+
+const scheduleRunSQLColumns = `id, name, trigger, status, error, scheduled_at, started_at, finished_at`
+
+// ScheduleSQLStore é a implementação de schedule.Store em banco de dados SQL, compartilhada pelas instâncias da aplicação
+type ScheduleSQLStore struct {
+	db sqlstore.DBTX
+}
+
+// NewScheduleSQLStore cria um armazenamento de tarefas agendadas que usa a conexão informada
+func NewScheduleSQLStore(db sqlstore.DBTX) *ScheduleSQLStore {
+	return &ScheduleSQLStore{db: db}
+}
+
+func (s *ScheduleSQLStore) Sync(state *schedule.State) error {
+	_, err := s.db.ExecContext(context.Background(),
+		`INSERT INTO scheduled_tasks (name, spec, next_run_at, locked_until, updated_at) VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (name) DO UPDATE SET spec = EXCLUDED.spec, next_run_at = EXCLUDED.next_run_at, updated_at = EXCLUDED.updated_at
+		WHERE scheduled_tasks.spec <> EXCLUDED.spec`,
+		state.Name, state.Spec, state.NextRunAt, time.Time{}, state.UpdatedAt)
+	return err
+}
+
+// Claim usa FOR UPDATE SKIP LOCKED para que instâncias concorrentes não reservem a mesma tarefa
+func (s *ScheduleSQLStore) Claim(names []string, now time.Time, lease time.Duration, limit int) ([]schedule.State, error) {
+	if len(names) == 0 {
+		return nil, nil
+	}
+	args := []interface{}{now.Add(lease), now, limit}
+	placeholders := make([]string, len(names))
+	for i, name := range names {
+		args = append(args, name)
+		placeholders[i] = fmt.Sprintf("$%d", len(args))
+	}
+	return s.query(`UPDATE scheduled_tasks SET locked_until = $1
+		WHERE name IN (
+			SELECT name FROM scheduled_tasks
+			WHERE name IN (`+strings.Join(placeholders, ", ")+`) AND locked_until <= $2
+				AND (triggered OR (NOT paused AND next_run_at <= $2))
+			ORDER BY next_run_at LIMIT $3 FOR UPDATE SKIP LOCKED
+		) RETURNING `+scheduleSQLColumns, args...)
+}
+
+// Finish libera a reserva apenas se ela ainda for a obtida em Claim e grava a execução na mesma transação
+func (s *ScheduleSQLStore) Finish(run *schedule.Run, lockedUntil, nextRunAt time.Time) error {
+	ctx := context.Background()
+	return sqlstore.InTransaction(ctx, s.db, func(tx sqlstore.DBTX) error {
+		result, err := tx.ExecContext(ctx,
+			`UPDATE scheduled_tasks SET next_run_at = $1, locked_until = $2, triggered = FALSE, last_run_at = $3,
+				last_status = $4, last_error = $5, updated_at = $6 WHERE name = $7 AND locked_until = $8`,
+			nextRunAt, time.Time{}, run.StartedAt, run.Status, run.Error, run.FinishedAt, run.Name, lockedUntil)
+		if err != nil {
+			return err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return fmt.Errorf("%w: %s", schedule.ErrLeaseLost, run.Name)
+		}
+		if _, err = tx.ExecContext(ctx,
+			`INSERT INTO scheduled_task_runs (`+scheduleRunSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+			run.ID, run.Name, run.Trigger, run.Status, run.Error, run.ScheduledAt, run.StartedAt, run.FinishedAt); err != nil {
+			return err
+		}
+		_, err = tx.ExecContext(ctx,
+			`DELETE FROM scheduled_task_runs WHERE name = $1 AND id NOT IN (
+				SELECT id FROM scheduled_task_runs WHERE name = $1 ORDER BY started_at DESC LIMIT $2
+			)`, run.Name, schedule.MaxRuns)
+		return err
+	})
+}
+
+func (s *ScheduleSQLStore) Get(name string) (*schedule.State, error) {
+	found, err := s.query(`SELECT `+scheduleSQLColumns+` FROM scheduled_tasks WHERE name = $1`, name)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return &found[0], nil
+}
+
+func (s *ScheduleSQLStore) List() ([]schedule.State, error) {
+	return s.query(`SELECT ` + scheduleSQLColumns + ` FROM scheduled_tasks ORDER BY name`)
+}
+
+func (s *ScheduleSQLStore) Runs(name string, limit int) ([]schedule.Run, error) {
+	rows, err := s.db.QueryContext(context.Background(),
+		`SELECT `+scheduleRunSQLColumns+` FROM scheduled_task_runs WHERE name = $1 ORDER BY started_at DESC LIMIT $2`, name, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var runs []schedule.Run
+	for rows.Next() {
+		var run schedule.Run
+		if err := rows.Scan(&run.ID, &run.Name, &run.Trigger, &run.Status, &run.Error, &run.ScheduledAt, &run.StartedAt, &run.FinishedAt); err != nil {
+			return nil, err
+		}
+		runs = append(runs, run)
+	}
+	return runs, rows.Err()
+}
+
+func (s *ScheduleSQLStore) Pause(name string) (bool, error) {
+	return s.update(`UPDATE scheduled_tasks SET paused = TRUE, updated_at = $1 WHERE name = $2`, time.Now(), name)
+}
+
+func (s *ScheduleSQLStore) Resume(name string, nextRunAt time.Time) (bool, error) {
+	return s.update(`UPDATE scheduled_tasks SET paused = FALSE, next_run_at = $1, updated_at = $2 WHERE name = $3`, nextRunAt, time.Now(), name)
+}
+
+func (s *ScheduleSQLStore) Trigger(name string) (bool, error) {
+	return s.update(`UPDATE scheduled_tasks SET triggered = TRUE, updated_at = $1 WHERE name = $2`, time.Now(), name)
+}
+
+func (s *ScheduleSQLStore) update(query string, args ...interface{}) (bool, error) {
+	result, err := s.db.ExecContext(context.Background(), query, args...)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func (s *ScheduleSQLStore) query(query string, args ...interface{}) ([]schedule.State, error) {
+	rows, err := s.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []schedule.State
+	for rows.Next() {
+		var state schedule.State
+		var lastRunAt sql.NullTime
+		if err := rows.Scan(&state.Name, &state.Spec, &state.Paused, &state.Triggered, &state.NextRunAt, &state.LockedUntil,
+			&lastRunAt, &state.LastStatus, &state.LastError, &state.UpdatedAt); err != nil {
+			return nil, err
+		}
+		state.LastRunAt = lastRunAt.Time
+		found = append(found, state)
+	}
+	return found, rows.Err()
+}
@@ -0,0 +1,165 @@
+package schedule
+
+import (
+	"flickly/internal/domain/core/schedule"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var scheduleSQLColumnNames = []string{"name", "spec", "paused", "triggered", "next_run_at", "locked_until", "last_run_at",
+	"last_status", "last_error", "updated_at"}
+
+func TestScheduleSQLStore_Sync(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (name) DO UPDATE SET spec = EXCLUDED.spec")).
+		WithArgs("purge", "@hourly", now, time.Time{}, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewScheduleSQLStore(db).Sync(&schedule.State{Name: "purge", Spec: "@hourly", NextRunAt: now, UpdatedAt: now})
+
+	// Verificações
+	assert.NoError(t, err, "Sync não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleSQLStore_Claim(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("WHERE name IN ($4, $5) AND locked_until <= $2")).
+		WithArgs(now.Add(time.Minute), now, 5, "digest", "purge").
+		WillReturnRows(sqlmock.NewRows(scheduleSQLColumnNames).
+			AddRow("purge", "@hourly", false, true, now, now.Add(time.Minute), nil, "", "", now))
+	store := NewScheduleSQLStore(db)
+
+	// Execução
+	claimed, err := store.Claim([]string{"digest", "purge"}, now, time.Minute, 5)
+	empty, emptyErr := store.Claim(nil, now, time.Minute, 5)
+
+	// Verificações
+	assert.NoError(t, err, "Claim não deve retornar erro")
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, "purge", claimed[0].Name, "O nome deve ser lido corretamente")
+	assert.True(t, claimed[0].Triggered, "A execução manual deve ser lida corretamente")
+	assert.True(t, claimed[0].LastRunAt.IsZero(), "Tarefas nunca executadas devem ter LastRunAt zero")
+	assert.NoError(t, emptyErr)
+	assert.Empty(t, empty, "Sem tarefas registradas, nada deve ser reservado")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleSQLStore_Finish(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	lease := now.Add(time.Minute)
+	run := newTestRun("purge", now)
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE scheduled_tasks SET next_run_at = $1, locked_until = $2, triggered = FALSE")).
+		WithArgs(now.Add(time.Hour), time.Time{}, run.StartedAt, schedule.RunSucceeded, "", run.FinishedAt, "purge", lease).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO scheduled_task_runs")).
+		WithArgs(run.ID, "purge", schedule.TriggerSchedule, schedule.RunSucceeded, "", run.ScheduledAt, run.StartedAt, run.FinishedAt).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM scheduled_task_runs")).
+		WithArgs("purge", schedule.MaxRuns).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectCommit()
+	store := NewScheduleSQLStore(db)
+
+	// Execução
+	err := store.Finish(run, lease, now.Add(time.Hour))
+
+	// Verificações
+	assert.NoError(t, err, "Finish não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet(), "As instruções devem ser executadas em uma única transação")
+}
+
+func TestScheduleSQLStore_FinishLeaseLost(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	mock.ExpectBegin()
+	mock.ExpectExec(regexp.QuoteMeta("WHERE name = $7 AND locked_until = $8")).WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectRollback()
+	store := NewScheduleSQLStore(db)
+
+	// Execução
+	err := store.Finish(newTestRun("purge", now), now.Add(time.Minute), now.Add(time.Hour))
+
+	// Verificações
+	assert.ErrorIs(t, err, schedule.ErrLeaseLost, "Finalizar sem a reserva vigente deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet(), "A execução não deve ser gravada sem a reserva")
+}
+
+func TestScheduleSQLStore_Queries(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	run := newTestRun("purge", now)
+	mock.ExpectQuery(regexp.QuoteMeta("FROM scheduled_tasks WHERE name = $1")).WithArgs("unknown").
+		WillReturnRows(sqlmock.NewRows(scheduleSQLColumnNames))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM scheduled_tasks ORDER BY name")).
+		WillReturnRows(sqlmock.NewRows(scheduleSQLColumnNames).
+			AddRow("purge", "@hourly", true, false, now, time.Time{}, now, schedule.RunFailed, "boom", now))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM scheduled_task_runs WHERE name = $1 ORDER BY started_at DESC LIMIT $2")).
+		WithArgs("purge", 10).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "trigger", "status", "error", "scheduled_at", "started_at", "finished_at"}).
+			AddRow(run.ID, "purge", schedule.TriggerManual, schedule.RunSucceeded, "", now, now, now))
+	store := NewScheduleSQLStore(db)
+
+	// Execução
+	missing, getErr := store.Get("unknown")
+	states, listErr := store.List()
+	runs, runsErr := store.Runs("purge", 10)
+
+	// Verificações
+	assert.NoError(t, getErr)
+	assert.Nil(t, missing, "Tarefas inexistentes devem retornar nil")
+	assert.NoError(t, listErr)
+	assert.Equal(t, now, states[0].LastRunAt, "A última execução deve ser lida corretamente")
+	assert.True(t, states[0].Paused)
+	assert.NoError(t, runsErr)
+	assert.Equal(t, run.ID, runs[0].ID, "A execução deve ser lida corretamente")
+	assert.Equal(t, schedule.TriggerManual, runs[0].Trigger)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestScheduleSQLStore_PauseResumeTrigger(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	next := time.Now().Add(time.Hour)
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE scheduled_tasks SET paused = TRUE")).
+		WithArgs(sqlmock.AnyArg(), "purge").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE scheduled_tasks SET paused = FALSE, next_run_at = $1")).
+		WithArgs(next, sqlmock.AnyArg(), "purge").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE scheduled_tasks SET triggered = TRUE")).
+		WithArgs(sqlmock.AnyArg(), "unknown").WillReturnResult(sqlmock.NewResult(0, 0))
+	store := NewScheduleSQLStore(db)
+
+	// Execução
+	paused, pauseErr := store.Pause("purge")
+	resumed, resumeErr := store.Resume("purge", next)
+	triggered, triggerErr := store.Trigger("unknown")
+
+	// Verificações
+	assert.NoError(t, pauseErr)
+	assert.NoError(t, resumeErr)
+	assert.NoError(t, triggerErr)
+	assert.True(t, paused)
+	assert.True(t, resumed)
+	assert.False(t, triggered, "Tarefas inexistentes devem ser reportadas")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
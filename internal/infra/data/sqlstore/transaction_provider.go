@@ -0,0 +1,66 @@
+package sqlstore
+
+import (
+	"context"
+	"database/sql"
+	"flickly/internal/domain/core/uow"
+)
+
+// DBTX é o subconjunto comum entre *sql.DB e *sql.Tx usado pelos repositórios
+type DBTX interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...interface{}) *sql.Row
+}
+
+// TransactionProvider abre transações em um banco de dados SQL
+type TransactionProvider struct {
+	db *sql.DB
+}
+
+// NewTransactionProvider cria um novo provedor de transações SQL
+func NewTransactionProvider(db *sql.DB) *TransactionProvider {
+	return &TransactionProvider{db: db}
+}
+
+// Begin abre uma transação no banco de dados
+func (p *TransactionProvider) Begin(ctx context.Context) (uow.Transaction, error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &Transaction{Tx: tx}, nil
+}
+
+// Transaction é uma transação SQL que também pode ser usada como DBTX pelos repositórios
+type Transaction struct {
+	*sql.Tx
+}
+
+// InTransaction executa fn em uma transação aberta em db, confirmada apenas se fn não retornar erro.
+// Se db já for uma transação, fn é executada nela e a confirmação fica a cargo de quem a abriu.
+func InTransaction(ctx context.Context, db DBTX, fn func(tx DBTX) error) error {
+	conn, ok := db.(*sql.DB)
+	if !ok {
+		return fn(db)
+	}
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err = fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// Migrate executa as instruções de criação de esquema informadas, em ordem
+func Migrate(ctx context.Context, db DBTX, statements ...string) error {
+	for _, statement := range statements {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
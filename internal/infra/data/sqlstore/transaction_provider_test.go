@@ -0,0 +1,124 @@
+package sqlstore
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransactionProvider_BeginCommit(t *testing.T) {
+	// Configuração
+	db, mock, err := sqlmock.New()
+	assert.NoError(t, err)
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectCommit()
+	provider := NewTransactionProvider(db)
+
+	// Execução
+	tx, err := provider.Begin(context.Background())
+	assert.NoError(t, err, "Begin não deve retornar erro")
+	err = tx.Commit()
+
+	// Verificações
+	assert.NoError(t, err, "Commit não deve retornar erro")
+	_, isDBTX := tx.(DBTX)
+	assert.True(t, isDBTX, "A transação deve poder ser usada pelos repositórios como DBTX")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionProvider_BeginRollback(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectRollback()
+	provider := NewTransactionProvider(db)
+
+	// Execução
+	tx, _ := provider.Begin(context.Background())
+	err := tx.Rollback()
+
+	// Verificações
+	assert.NoError(t, err, "Rollback não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestTransactionProvider_BeginError(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin().WillReturnError(errors.New("connection refused"))
+
+	// Execução
+	tx, err := NewTransactionProvider(db).Begin(context.Background())
+
+	// Verificações
+	assert.Error(t, err, "Begin deve propagar o erro do banco")
+	assert.Nil(t, tx, "Nenhuma transação deve ser retornada em caso de erro")
+}
+
+func TestInTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectCommit()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectRollback()
+	insert := func(tx DBTX) error {
+		_, err := tx.ExecContext(context.Background(), "INSERT INTO a (id) VALUES (1)")
+		return err
+	}
+
+	// Execução
+	committedErr := InTransaction(context.Background(), db, insert)
+	rolledBackErr := InTransaction(context.Background(), db, func(tx DBTX) error {
+		_ = insert(tx)
+		return errors.New("conflict")
+	})
+
+	// Verificações
+	assert.NoError(t, committedErr, "A transação deve ser confirmada quando fn não retorna erro")
+	assert.EqualError(t, rolledBackErr, "conflict", "O erro de fn deve ser propagado")
+	assert.NoError(t, mock.ExpectationsWereMet(), "A transação deve ser desfeita quando fn retorna erro")
+}
+
+func TestInTransaction_ExistingTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	mock.ExpectExec("INSERT INTO a").WillReturnResult(sqlmock.NewResult(0, 1))
+	tx, _ := NewTransactionProvider(db).Begin(context.Background())
+
+	// Execução
+	err := InTransaction(context.Background(), tx.(*Transaction), func(inner DBTX) error {
+		_, err := inner.ExecContext(context.Background(), "INSERT INTO a (id) VALUES (1)")
+		return err
+	})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet(), "Uma transação existente não deve ser confirmada por InTransaction")
+}
+
+func TestMigrate(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectExec("CREATE TABLE a").WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectExec("CREATE TABLE b").WillReturnError(errors.New("syntax error"))
+
+	// Execução
+	err := Migrate(context.Background(), db, "CREATE TABLE a (id INT)", "CREATE TABLE b (", "CREATE TABLE c (id INT)")
+
+	// Verificações
+	assert.Error(t, err, "Migrate deve parar no primeiro erro")
+	assert.NoError(t, mock.ExpectationsWereMet(), "As instruções após o erro não devem ser executadas")
+}
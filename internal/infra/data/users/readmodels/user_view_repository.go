@@ -0,0 +1,38 @@
+package readmodels
+
+import (
+	"flickly/internal/domain/users/readmodels"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// UserViewRepository é a implementação em memória de IUserViewRepository
+type UserViewRepository struct {
+	Views map[uuid.UUID]readmodels.UserView
+	mu    sync.RWMutex
+}
+
+func NewUserViewRepository() *UserViewRepository {
+	return &UserViewRepository{Views: make(map[uuid.UUID]readmodels.UserView)}
+}
+
+func (r *UserViewRepository) GetUserView(id uuid.UUID) (*readmodels.UserView, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	view, ok := r.Views[id]
+	if !ok {
+		return nil, nil
+	}
+	return &view, nil
+}
+
+// SaveUserView ignora visões mais antigas que a armazenada, para que eventos fora de ordem não a regridam
+func (r *UserViewRepository) SaveUserView(view *readmodels.UserView) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.Views[view.ID]; ok && stored.Version > view.Version {
+		return nil
+	}
+	r.Views[view.ID] = *view
+	return nil
+}
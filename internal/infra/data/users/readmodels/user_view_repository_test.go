@@ -0,0 +1,31 @@
+package readmodels
+
+import (
+	"flickly/internal/domain/users/readmodels"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserViewRepository_SaveAndGet(t *testing.T) {
+	// Configuração
+	repository := NewUserViewRepository()
+	view := &readmodels.UserView{ID: uuid.New(), Name: "Nome", Email: "nome@example.com", CreatedAt: time.Now(), Version: 2}
+
+	// Execução
+	err := repository.SaveUserView(view)
+	stale := repository.SaveUserView(&readmodels.UserView{ID: view.ID, Name: "Antigo", Version: 1})
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.NoError(t, stale)
+	stored, err := repository.GetUserView(view.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, *view, *stored, "Uma visão mais antiga não deve substituir a armazenada")
+
+	missing, err := repository.GetUserView(uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, missing, "GetUserView deve retornar nil para usuários inexistentes")
+}
@@ -0,0 +1,58 @@
+package readmodels
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flickly/internal/domain/users/readmodels"
+	"flickly/internal/infra/data/sqlstore"
+	"github.com/google/uuid"
+)
+
+// UserViewSQLSchema cria a tabela usada por UserViewSQLRepository
+const UserViewSQLSchema = `CREATE TABLE IF NOT EXISTS user_views (
+	id UUID PRIMARY KEY,
+	name TEXT NOT NULL,
+	email TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	last_update_at TIMESTAMP NULL,
+	version BIGINT NOT NULL
+)`
+
+const userViewSQLColumns = `id, name, email, created_at, last_update_at, version`
+
+// UserViewSQLRepository é a implementação de IUserViewRepository em banco de dados SQL.
+// Pode usar um banco diferente do usado pelos comandos, como uma réplica de leitura.
+type UserViewSQLRepository struct {
+	db sqlstore.DBTX
+}
+
+// NewUserViewSQLRepository cria um repositório que usa a conexão informada
+func NewUserViewSQLRepository(db sqlstore.DBTX) *UserViewSQLRepository {
+	return &UserViewSQLRepository{db: db}
+}
+
+func (r *UserViewSQLRepository) GetUserView(id uuid.UUID) (*readmodels.UserView, error) {
+	var view readmodels.UserView
+	err := r.db.QueryRowContext(context.Background(),
+		`SELECT `+userViewSQLColumns+` FROM user_views WHERE id = $1`, id).
+		Scan(&view.ID, &view.Name, &view.Email, &view.CreatedAt, &view.LastUpdateAt, &view.Version)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &view, nil
+}
+
+// SaveUserView ignora visões mais antigas que a armazenada, para que eventos fora de ordem não a regridam
+func (r *UserViewSQLRepository) SaveUserView(view *readmodels.UserView) error {
+	_, err := r.db.ExecContext(context.Background(),
+		`INSERT INTO user_views (`+userViewSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email,
+			last_update_at = EXCLUDED.last_update_at, version = EXCLUDED.version
+		WHERE user_views.version <= EXCLUDED.version`,
+		view.ID, view.Name, view.Email, view.CreatedAt, view.LastUpdateAt, view.Version)
+	return err
+}
@@ -0,0 +1,57 @@
+package readmodels
+
+import (
+	"flickly/internal/domain/users/readmodels"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var userViewSQLColumnNames = []string{"id", "name", "email", "created_at", "last_update_at", "version"}
+
+func TestUserViewSQLRepository_SaveUserView(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	view := &readmodels.UserView{ID: uuid.New(), Name: "Nome", Email: "nome@example.com", CreatedAt: time.Now(), Version: 3}
+	mock.ExpectExec(regexp.QuoteMeta("ON CONFLICT (id) DO UPDATE")).
+		WithArgs(view.ID, view.Name, view.Email, view.CreatedAt, view.LastUpdateAt, view.Version).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewUserViewSQLRepository(db).SaveUserView(view)
+
+	// Verificações
+	assert.NoError(t, err, "SaveUserView não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserViewSQLRepository_GetUserView(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	id := uuid.New()
+	now := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM user_views WHERE id = $1")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(userViewSQLColumnNames).AddRow(id, "Nome", "nome@example.com", now, nil, 2))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM user_views WHERE id = $1")).
+		WillReturnRows(sqlmock.NewRows(userViewSQLColumnNames))
+	repository := NewUserViewSQLRepository(db)
+
+	// Execução
+	view, err := repository.GetUserView(id)
+	missing, missingErr := repository.GetUserView(uuid.New())
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, "Nome", view.Name, "O nome deve ser lido corretamente")
+	assert.Equal(t, int64(2), view.Version, "A versão deve ser lida corretamente")
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing, "GetUserView deve retornar nil para usuários inexistentes")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
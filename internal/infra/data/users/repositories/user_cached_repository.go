@@ -0,0 +1,182 @@
+package repositories
+
+import (
+	"context"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/cache"
+	"github.com/google/uuid"
+	"sync"
+)
+
+// transactionalUserRepository é implementado pelos repositórios que podem ser vinculados a uma transação
+type transactionalUserRepository interface {
+	WithTransaction(tx uow.Transaction) repositories.IUserRepository
+}
+
+// CachedUserRepository é um decorador de IUserRepository que guarda as leituras em cache
+// e invalida as chaves do usuário a cada escrita
+type CachedUserRepository struct {
+	inner   repositories.IUserRepository
+	cache   cache.Cache
+	Options cache.Options
+}
+
+// NewCachedUserRepository decora o repositório informado com o cache, usando cache.DefaultOptions
+func NewCachedUserRepository(inner repositories.IUserRepository, userCache cache.Cache) *CachedUserRepository {
+	return &CachedUserRepository{
+		inner:   inner,
+		cache:   userCache,
+		Options: cache.DefaultOptions,
+	}
+}
+
+func (r *CachedUserRepository) CreateUser(user *entities.User) error {
+	if err := r.inner.CreateUser(user); err != nil {
+		return err
+	}
+	invalidateUserKeys(r.cache, userCacheKeys(user.ID, user.Email))
+	return nil
+}
+
+func (r *CachedUserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	return r.load(userEmailCacheKey(email), func() (*entities.User, error) {
+		return r.inner.GetUserByEmail(email)
+	})
+}
+
+func (r *CachedUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return r.load(userIDCacheKey(id), func() (*entities.User, error) {
+		return r.inner.GetUserByID(id)
+	})
+}
+
+// cachedUser é a forma do usuário guardada no cache. Inclui o hash da senha, que o usuário não serializa.
+type cachedUser struct {
+	entities.User
+	PasswordHash string `json:"passwordHash,omitempty"`
+}
+
+// load lê o usuário do cache ou do repositório decorado
+func (r *CachedUserRepository) load(key string, load func() (*entities.User, error)) (*entities.User, error) {
+	cached, err := cache.Load(context.Background(), r.cache, key, r.Options, func() (*cachedUser, error) {
+		user, err := load()
+		if err != nil || user == nil {
+			return nil, err
+		}
+		return &cachedUser{User: *user, PasswordHash: user.PasswordHash}, nil
+	})
+	if err != nil || cached == nil {
+		return nil, err
+	}
+	user := cached.User
+	user.PasswordHash = cached.PasswordHash
+	return &user, nil
+}
+
+func (r *CachedUserRepository) UpdateUser(user *entities.User) error {
+	keys, err := updatedUserKeys(r.inner, user)
+	if err != nil {
+		return err
+	}
+	if err = r.inner.UpdateUser(user); err != nil {
+		return err
+	}
+	invalidateUserKeys(r.cache, keys)
+	return nil
+}
+
+// WithTransaction vincula o repositório decorado à transação. Dentro da transação as leituras não usam o cache
+// e as chaves alteradas são invalidadas novamente após o commit.
+func (r *CachedUserRepository) WithTransaction(tx uow.Transaction) repositories.IUserRepository {
+	inner := r.inner
+	if transactional, ok := inner.(transactionalUserRepository); ok {
+		inner = transactional.WithTransaction(tx)
+	}
+	return &transactionCachedUserRepository{inner: inner, cache: r.cache}
+}
+
+// transactionCachedUserRepository é o decorador usado dentro de uma unidade de trabalho
+type transactionCachedUserRepository struct {
+	inner   repositories.IUserRepository
+	cache   cache.Cache
+	pending []string
+	mu      sync.Mutex
+}
+
+func (r *transactionCachedUserRepository) CreateUser(user *entities.User) error {
+	if err := r.inner.CreateUser(user); err != nil {
+		return err
+	}
+	r.invalidate(userCacheKeys(user.ID, user.Email))
+	return nil
+}
+
+func (r *transactionCachedUserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	return r.inner.GetUserByEmail(email)
+}
+
+func (r *transactionCachedUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	return r.inner.GetUserByID(id)
+}
+
+func (r *transactionCachedUserRepository) UpdateUser(user *entities.User) error {
+	keys, err := updatedUserKeys(r.inner, user)
+	if err != nil {
+		return err
+	}
+	if err = r.inner.UpdateUser(user); err != nil {
+		return err
+	}
+	r.invalidate(keys)
+	return nil
+}
+
+// AfterCommit invalida novamente as chaves alteradas, descartando valores lidos antes do commit
+func (r *transactionCachedUserRepository) AfterCommit() {
+	r.mu.Lock()
+	keys := r.pending
+	r.pending = nil
+	r.mu.Unlock()
+	invalidateUserKeys(r.cache, keys)
+}
+
+func (r *transactionCachedUserRepository) invalidate(keys []string) {
+	r.mu.Lock()
+	r.pending = append(r.pending, keys...)
+	r.mu.Unlock()
+	invalidateUserKeys(r.cache, keys)
+}
+
+// updatedUserKeys retorna as chaves afetadas pela atualização, incluindo as do email anterior
+func updatedUserKeys(repository repositories.IUserRepository, user *entities.User) ([]string, error) {
+	keys := userCacheKeys(user.ID, user.Email)
+	storedUser, err := repository.GetUserByID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if storedUser != nil && storedUser.Email != user.Email {
+		keys = append(keys, userEmailCacheKey(storedUser.Email))
+	}
+	return keys, nil
+}
+
+// invalidateUserKeys remove as chaves do cache; uma falha apenas mantém o valor até o fim do TTL
+func invalidateUserKeys(userCache cache.Cache, keys []string) {
+	if len(keys) > 0 {
+		_ = userCache.Delete(context.Background(), keys...)
+	}
+}
+
+func userCacheKeys(id uuid.UUID, email string) []string {
+	return []string{userIDCacheKey(id), userEmailCacheKey(email)}
+}
+
+func userIDCacheKey(id uuid.UUID) string {
+	return "users:id:" + id.String()
+}
+
+func userEmailCacheKey(email string) string {
+	return "users:email:" + email
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/cache"
+	"flickly/internal/infra/data/memory"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+// countingUserRepository conta as leituras que chegam ao repositório decorado, inclusive dentro das transações
+type countingUserRepository struct {
+	repositories.IUserRepository
+	*readCounts
+}
+
+// readCounts é compartilhado entre o repositório e os repositórios de suas transações
+type readCounts struct {
+	EmailReads int
+	IDReads    int
+}
+
+func (r *countingUserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	r.EmailReads++
+	return r.IUserRepository.GetUserByEmail(email)
+}
+
+func (r *countingUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	r.IDReads++
+	return r.IUserRepository.GetUserByID(id)
+}
+
+func (r *countingUserRepository) WithTransaction(tx uow.Transaction) repositories.IUserRepository {
+	transactional := r.IUserRepository.(transactionalUserRepository).WithTransaction(tx)
+	return &countingUserRepository{IUserRepository: transactional, readCounts: r.readCounts}
+}
+
+func newCachedRepositoryForTest() (*CachedUserRepository, *countingUserRepository, *cache.LRUCache) {
+	inner := &countingUserRepository{IUserRepository: NewUserRepository(), readCounts: &readCounts{}}
+	userCache := cache.NewLRUCache(100)
+	return NewCachedUserRepository(inner, userCache), inner, userCache
+}
+
+func TestCachedUserRepository_GetUserByEmail(t *testing.T) {
+	// Configuração
+	repository, inner, _ := newCachedRepositoryForTest()
+	user := entities.NewUser("Test User", "test@example.com")
+	user.SetPasswordHash("hash")
+	_ = inner.IUserRepository.CreateUser(user)
+
+	// Execução
+	first, err := repository.GetUserByEmail("test@example.com")
+	assert.NoError(t, err)
+	second, err := repository.GetUserByEmail("test@example.com")
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, 1, inner.EmailReads, "A segunda leitura deve vir do cache")
+	assert.Equal(t, user.ID, second.ID, "O usuário em cache deve ser igual ao armazenado")
+	assert.True(t, first.CreatedAt.Equal(second.CreatedAt), "As datas devem ser preservadas pelo cache")
+	assert.NotSame(t, first, second, "Cada leitura deve retornar uma cópia do usuário")
+	assert.Equal(t, "hash", second.PasswordHash, "O hash da senha deve ser preservado pelo cache")
+}
+
+func TestCachedUserRepository_NegativeCachingAndCreate(t *testing.T) {
+	// Configuração
+	repository, inner, _ := newCachedRepositoryForTest()
+
+	// Execução e verificações - cache negativo
+	missing, _ := repository.GetUserByEmail("new@example.com")
+	_, _ = repository.GetUserByEmail("new@example.com")
+	assert.Nil(t, missing)
+	assert.Equal(t, 1, inner.EmailReads, "Usuários inexistentes também devem ser guardados em cache")
+
+	// Execução e verificações - a criação invalida o cache negativo
+	user := entities.NewUser("New User", "new@example.com")
+	assert.NoError(t, repository.CreateUser(user))
+	found, _ := repository.GetUserByEmail("new@example.com")
+	assert.NotNil(t, found, "O usuário criado deve ser encontrado após a invalidação")
+	assert.Equal(t, user.ID, found.ID)
+}
+
+func TestCachedUserRepository_UpdateInvalidatesKeys(t *testing.T) {
+	// Configuração
+	repository, _, _ := newCachedRepositoryForTest()
+	user := entities.NewUser("Test User", "old@example.com")
+	_ = repository.CreateUser(user)
+	_, _ = repository.GetUserByEmail("old@example.com")
+	_, _ = repository.GetUserByID(user.ID)
+
+	// Execução
+	user.Email = "new@example.com"
+	err := repository.UpdateUser(user)
+
+	// Verificações
+	assert.NoError(t, err)
+	old, _ := repository.GetUserByEmail("old@example.com")
+	assert.Nil(t, old, "O email anterior não deve mais ser encontrado")
+	byID, _ := repository.GetUserByID(user.ID)
+	assert.Equal(t, "new@example.com", byID.Email, "A leitura por ID deve refletir a atualização")
+	assert.Equal(t, int64(2), byID.Version)
+}
+
+func TestCachedUserRepository_WithTransaction(t *testing.T) {
+	// Configuração
+	repository, inner, userCache := newCachedRepositoryForTest()
+	user := entities.NewUser("Test User", "test@example.com")
+	_ = repository.CreateUser(user)
+	provider := memory.NewTransactionProvider()
+	factory := uow.NewFactory(provider)
+	uow.AddRepository[repositories.IUserRepository](factory, repository.WithTransaction)
+	unitOfWork := factory.New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	transactional, _ := uow.GetRepository[repositories.IUserRepository](unitOfWork)
+
+	// Execução
+	stored, _ := transactional.GetUserByID(user.ID)
+	stored.Name = "Updated"
+	assert.NoError(t, transactional.UpdateUser(stored))
+	_, _ = repository.GetUserByID(user.ID)
+	assert.Equal(t, 1, userCache.Len(), "Leituras fora da transação ainda podem popular o cache antes do commit")
+	assert.NoError(t, unitOfWork.Commit())
+
+	// Verificações
+	assert.Equal(t, 0, userCache.Len(), "As chaves alteradas devem ser invalidadas após o commit")
+	reads := inner.IDReads
+	_, _ = transactional.GetUserByID(user.ID)
+	assert.Equal(t, reads+1, inner.IDReads, "Leituras dentro da transação não devem usar o cache")
+}
+
+func TestCachedUserRepository_WithTransaction_Rollback(t *testing.T) {
+	// Configuração
+	repository, _, _ := newCachedRepositoryForTest()
+	user := entities.NewUser("Test User", "test@example.com")
+	_ = repository.CreateUser(user)
+	factory := uow.NewFactory(memory.NewTransactionProvider())
+	uow.AddRepository[repositories.IUserRepository](factory, repository.WithTransaction)
+	unitOfWork := factory.New()
+	assert.NoError(t, unitOfWork.Begin(context.Background()))
+	transactional, _ := uow.GetRepository[repositories.IUserRepository](unitOfWork)
+
+	// Execução
+	stored, _ := transactional.GetUserByID(user.ID)
+	stored.Name = "Updated"
+	_ = transactional.UpdateUser(stored)
+	assert.NoError(t, unitOfWork.Rollback())
+
+	// Verificações
+	found, _ := repository.GetUserByID(user.ID)
+	assert.Equal(t, "Test User", found.Name, "O cache não deve guardar alterações desfeitas")
+}
@@ -2,11 +2,20 @@ package repositories
 
 import (
 	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/uow"
 	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/data/memory"
+	"fmt"
+	"github.com/google/uuid"
+	"slices"
+	"sync"
 )
 
 type UserRepository struct {
 	Users []entities.User
+	mu    sync.RWMutex
 }
 
 func NewUserRepository() *UserRepository {
@@ -14,20 +23,206 @@ func NewUserRepository() *UserRepository {
 }
 
 func (r *UserRepository) CreateUser(user *entities.User) error {
-	for _, existingUser := range r.Users {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := validateCreate(r.Users, user); err != nil {
+		return err
+	}
+	r.Users = append(r.Users, stored(user))
+	return nil
+}
+
+func (r *UserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return findUser(r.Users, func(user entities.User) bool { return user.Email == email }), nil
+}
+
+func (r *UserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return findUser(r.Users, func(user entities.User) bool { return user.ID == id }), nil
+}
+
+func (r *UserRepository) UpdateUser(user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	index, err := validateUpdate(r.Users, user)
+	if err != nil {
+		return err
+	}
+	user.Version++
+	r.Users[index] = stored(user)
+	return nil
+}
+
+// WithTransaction cria um repositório que grava os usuários apenas no commit da transação em memória informada.
+// As leituras feitas por ele já consideram as gravações da própria transação.
+func (r *UserRepository) WithTransaction(tx uow.Transaction) repositories.IUserRepository {
+	memoryTransaction, ok := tx.(*memory.Transaction)
+	if !ok {
+		return r
+	}
+	return &transactionUserRepository{store: r, tx: memoryTransaction}
+}
+
+// transactionUserRepository é o repositório usado dentro de uma transação em memória. As gravações ficam pendentes
+// até o commit, quando as versões lidas são comparadas novamente com as armazenadas.
+type transactionUserRepository struct {
+	store   *UserRepository
+	tx      *memory.Transaction
+	pending []entities.User
+	// versions guarda, por usuário gravado, a versão armazenada quando ele foi lido (0 para usuários novos)
+	versions map[uuid.UUID]int64
+	mu       sync.Mutex
+}
+
+func (r *transactionUserRepository) CreateUser(user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if err := validateCreate(r.users(), user); err != nil {
+		return err
+	}
+	return r.write(stored(user), 0)
+}
+
+func (r *transactionUserRepository) GetUserByEmail(email string) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return findUser(r.users(), func(user entities.User) bool { return user.Email == email }), nil
+}
+
+func (r *transactionUserRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return findUser(r.users(), func(user entities.User) bool { return user.ID == id }), nil
+}
+
+func (r *transactionUserRepository) UpdateUser(user *entities.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, err := validateUpdate(r.users(), user); err != nil {
+		return err
+	}
+	version := user.Version
+	user.Version++
+	return r.write(stored(user), version)
+}
+
+// write acumula a gravação do usuário lido na versão informada e a torna visível às leituras deste repositório
+func (r *transactionUserRepository) write(user entities.User, version int64) error {
+	if r.versions == nil {
+		if err := r.tx.DeferChecked(r.commit); err != nil {
+			return err
+		}
+		r.versions = make(map[uuid.UUID]int64)
+	}
+	if _, ok := r.versions[user.ID]; !ok {
+		r.versions[user.ID] = version
+	}
+	for i := range r.pending {
+		if r.pending[i].ID == user.ID {
+			r.pending[i] = user
+			return nil
+		}
+	}
+	r.pending = append(r.pending, user)
+	return nil
+}
+
+// commit aplica as gravações pendentes se nenhum usuário gravado foi alterado fora da transação desde que foi
+// lido e nenhum email ficou duplicado; caso contrário, nada é gravado
+func (r *transactionUserRepository) commit() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.store.mu.Lock()
+	defer r.store.mu.Unlock()
+	users := append([]entities.User(nil), r.store.Users...)
+	for _, user := range r.pending {
+		index := slices.IndexFunc(users, func(existing entities.User) bool { return existing.ID == user.ID })
+		var found int64
+		if index >= 0 {
+			found = users[index].Version
+		}
+		if expected := r.versions[user.ID]; found != expected {
+			return core.ErrConcurrencyConflict(fmt.Errorf("user %s: expected version %d, found %d", user.ID, expected, found))
+		}
+		if index < 0 {
+			users = append(users, user)
+		} else {
+			users[index] = user
+		}
+	}
+	emails := make(map[string]bool, len(users))
+	for _, user := range users {
+		if emails[user.Email] {
+			return errors.New("user already exists")
+		}
+		emails[user.Email] = true
+	}
+	r.store.Users = users
+	return nil
+}
+
+// users retorna os usuários confirmados com as gravações da transação aplicadas
+func (r *transactionUserRepository) users() []entities.User {
+	r.store.mu.RLock()
+	users := append([]entities.User(nil), r.store.Users...)
+	r.store.mu.RUnlock()
+	for _, user := range r.pending {
+		index := slices.IndexFunc(users, func(existing entities.User) bool { return existing.ID == user.ID })
+		if index < 0 {
+			users = append(users, user)
+		} else {
+			users[index] = user
+		}
+	}
+	return users
+}
+
+// stored retorna a cópia do usuário guardada pelo repositório, sem os eventos ainda não despachados do agregado,
+// para que eles não sejam registrados novamente nas leituras seguintes
+func stored(user *entities.User) entities.User {
+	copied := *user
+	copied.AggregateRoot = core.AggregateRoot{}
+	return copied
+}
+
+// validateCreate impede usuários com o mesmo email
+func validateCreate(users []entities.User, user *entities.User) error {
+	for _, existingUser := range users {
 		if user.Email == existingUser.Email {
 			return errors.New("user already exists")
 		}
 	}
-	r.Users = append(r.Users, *user)
 	return nil
 }
 
-func (r *UserRepository) GetUserByEmail(email string) (*entities.User, error) {
-	for _, user := range r.Users {
-		if user.Email == email {
-			return &user, nil
+// validateUpdate retorna a posição do usuário, verificando o email e a versão esperada
+func validateUpdate(users []entities.User, user *entities.User) (int, error) {
+	index := -1
+	for i, existingUser := range users {
+		if existingUser.ID == user.ID {
+			index = i
+		} else if existingUser.Email == user.Email {
+			return -1, errors.New("user already exists")
 		}
 	}
-	return nil, nil
+	if index < 0 {
+		return -1, core.ErrUserNotFound(fmt.Errorf("user %s not found", user.ID))
+	}
+	if users[index].Version != user.Version {
+		return -1, core.ErrConcurrencyConflict(fmt.Errorf("user %s: expected version %d, found %d", user.ID, user.Version, users[index].Version))
+	}
+	return index, nil
+}
+
+// findUser retorna uma cópia do primeiro usuário que atende ao critério, ou nil
+func findUser(users []entities.User, match func(user entities.User) bool) *entities.User {
+	for _, user := range users {
+		if match(user) {
+			return &user
+		}
+	}
+	return nil
 }
@@ -1,7 +1,12 @@
 package repositories
 
 import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
 	"flickly/internal/domain/users/entities"
+	"flickly/internal/infra/data/memory"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -60,4 +65,170 @@ func TestGetUserByEmail(t *testing.T) {
 	// Verificações
 	assert.NoError(t, err, "Não deve ocorrer erro ao buscar usuário não existente")
 	assert.Nil(t, retrievedUser, "Deve retornar nil para usuário não encontrado")
-} 
\ No newline at end of file
+}
+
+func TestUserRepository_WithTransaction(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	assert.NoError(t, repository.CreateUser(entities.NewUser("Existing User", "existing@example.com")))
+	provider := memory.NewTransactionProvider()
+
+	// Execução - transação desfeita
+	tx, _ := provider.Begin(context.Background())
+	err := repository.WithTransaction(tx).CreateUser(entities.NewUser("Rolled Back", "rollback@example.com"))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Rollback())
+
+	// Verificações
+	retrievedUser, _ := repository.GetUserByEmail("rollback@example.com")
+	assert.Nil(t, retrievedUser, "O usuário criado na transação desfeita não deve existir")
+	assert.Len(t, repository.Users, 1, "O repositório deve voltar ao estado anterior à transação")
+
+	// Execução - transação confirmada
+	tx, _ = provider.Begin(context.Background())
+	err = repository.WithTransaction(tx).CreateUser(entities.NewUser("Committed", "commit@example.com"))
+	assert.NoError(t, err)
+	assert.NoError(t, tx.Commit())
+
+	// Verificações
+	retrievedUser, _ = repository.GetUserByEmail("commit@example.com")
+	assert.NotNil(t, retrievedUser, "O usuário criado na transação confirmada deve existir")
+}
+
+func TestUserRepository_WithTransaction_BuffersWrites(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	existing := entities.NewUser("Existing User", "existing@example.com")
+	assert.NoError(t, repository.CreateUser(existing))
+	tx, _ := memory.NewTransactionProvider().Begin(context.Background())
+	transactional := repository.WithTransaction(tx)
+
+	// Execução
+	created := entities.NewUser("Created", "created@example.com")
+	assert.NoError(t, transactional.CreateUser(created))
+	outside, _ := repository.GetUserByID(existing.ID)
+	outside.Name = "Updated Outside"
+	assert.NoError(t, repository.UpdateUser(outside))
+
+	// Verificações
+	found, _ := transactional.GetUserByEmail("created@example.com")
+	assert.NotNil(t, found, "A transação deve ler as próprias gravações")
+	found, _ = repository.GetUserByEmail("created@example.com")
+	assert.Nil(t, found, "As gravações da transação não devem ser visíveis antes do commit")
+	assert.EqualError(t, transactional.CreateUser(entities.NewUser("Duplicate", "created@example.com")), "user already exists")
+
+	assert.NoError(t, tx.Rollback())
+	stored, _ := repository.GetUserByID(existing.ID)
+	assert.Equal(t, "Updated Outside", stored.Name, "As alterações feitas fora da transação não devem ser desfeitas")
+}
+
+func TestUserRepository_WithTransaction_ConflictOnCommit(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	existing := entities.NewUser("Existing User", "existing@example.com")
+	assert.NoError(t, repository.CreateUser(existing))
+	tx, _ := memory.NewTransactionProvider().Begin(context.Background())
+	transactional := repository.WithTransaction(tx)
+	inTransaction, _ := transactional.GetUserByID(existing.ID)
+	inTransaction.Name = "Updated In Transaction"
+	assert.NoError(t, transactional.UpdateUser(inTransaction))
+	created := entities.NewUser("Created", "created@example.com")
+	assert.NoError(t, transactional.CreateUser(created))
+
+	// Execução
+	outside, _ := repository.GetUserByID(existing.ID)
+	outside.Name = "Updated Outside"
+	assert.NoError(t, repository.UpdateUser(outside))
+	err := tx.Commit()
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "O commit deve falhar com um conflito de versão")
+	assert.Equal(t, 409, domainError.StatusCode)
+	stored, _ := repository.GetUserByID(existing.ID)
+	assert.Equal(t, "Updated Outside", stored.Name, "A gravação feita fora da transação não deve ser sobrescrita")
+	found, _ := repository.GetUserByEmail("created@example.com")
+	assert.Nil(t, found, "Nenhuma gravação da transação deve ser aplicada após o conflito")
+}
+
+func TestGetUserByID(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	user := entities.NewUser("Test User", "test@example.com")
+	assert.NoError(t, repository.CreateUser(user))
+
+	// Execução e verificações
+	retrievedUser, err := repository.GetUserByID(user.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, user.Email, retrievedUser.Email, "Deve retornar o usuário com o ID informado")
+
+	retrievedUser, err = repository.GetUserByID(uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, retrievedUser, "Deve retornar nil para ID inexistente")
+}
+
+func TestUpdateUser_OptimisticConcurrency(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	user := entities.NewUser("Test User", "test@example.com")
+	assert.NoError(t, repository.CreateUser(user))
+	first, _ := repository.GetUserByID(user.ID)
+	second, _ := repository.GetUserByID(user.ID)
+
+	// Execução - primeira atualização
+	first.Name = "First Writer"
+	err := repository.UpdateUser(first)
+
+	// Verificações
+	assert.NoError(t, err, "A primeira atualização deve ser aceita")
+	assert.Equal(t, int64(2), first.Version, "A versão deve ser incrementada após a atualização")
+
+	// Execução - segunda atualização com versão antiga
+	second.Name = "Second Writer"
+	err = repository.UpdateUser(second)
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "O conflito deve ser um DomainError")
+	assert.Equal(t, 409, domainError.StatusCode, "O conflito deve ter status 409")
+	stored, _ := repository.GetUserByID(user.ID)
+	assert.Equal(t, "First Writer", stored.Name, "A atualização conflitante não deve sobrescrever a anterior")
+}
+
+func TestUpdateUser_Errors(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	user := entities.NewUser("Test User", "test@example.com")
+	other := entities.NewUser("Other User", "other@example.com")
+	assert.NoError(t, repository.CreateUser(user))
+	assert.NoError(t, repository.CreateUser(other))
+
+	// Execução e verificações - email duplicado
+	duplicated := *user
+	duplicated.Email = other.Email
+	assert.EqualError(t, repository.UpdateUser(&duplicated), "user already exists")
+
+	// Execução e verificações - usuário inexistente
+	var domainError *core.DomainError
+	err := repository.UpdateUser(entities.NewUser("Ghost", "ghost@example.com"))
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, 404, domainError.StatusCode, "Atualizar usuário inexistente deve retornar 404")
+}
+
+func TestUserRepository_DoesNotStoreEvents(t *testing.T) {
+	// Configuração
+	repository := NewUserRepository()
+	user := entities.NewUser("Test User", "test@example.com")
+
+	// Execução
+	assert.NoError(t, repository.CreateUser(user))
+	loaded, _ := repository.GetUserByID(user.ID)
+	loaded.Rename("Renamed")
+	assert.NoError(t, repository.UpdateUser(loaded))
+	reloaded, _ := repository.GetUserByID(user.ID)
+
+	// Verificações
+	assert.Len(t, user.Events(), 1, "Os eventos do agregado gravado devem ser mantidos para o despacho")
+	assert.Len(t, loaded.Events(), 1, "Apenas a alteração deve ser registrada no usuário lido")
+	assert.Empty(t, reloaded.Events(), "O usuário lido não deve trazer eventos já registrados")
+}
@@ -0,0 +1,131 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/domain/users/repositories"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"github.com/google/uuid"
+)
+
+// UserSQLSchema cria a tabela usada por UserSQLRepository
+const UserSQLSchema = `CREATE TABLE IF NOT EXISTS users (
+	id UUID PRIMARY KEY,
+	name TEXT NOT NULL,
+	email TEXT NOT NULL UNIQUE,
+	created_at TIMESTAMP NOT NULL,
+	last_update_at TIMESTAMP NULL,
+	deleted_at TIMESTAMP NULL,
+	version BIGINT NOT NULL DEFAULT 1,
+	password_hash TEXT NOT NULL DEFAULT ''
+)`
+
+// UserSQLMigrations contém as instruções, em ordem, para criar e atualizar o esquema de usuários
+var UserSQLMigrations = []string{
+	UserSQLSchema,
+	`ALTER TABLE users ADD COLUMN IF NOT EXISTS version BIGINT NOT NULL DEFAULT 1`,
+	`ALTER TABLE users ADD COLUMN IF NOT EXISTS password_hash TEXT NOT NULL DEFAULT ''`,
+}
+
+const userSQLColumns = `id, name, email, created_at, last_update_at, deleted_at, version, password_hash`
+
+// UserSQLRepository é a implementação de IUserRepository em banco de dados SQL
+type UserSQLRepository struct {
+	db sqlstore.DBTX
+}
+
+// NewUserSQLRepository cria um repositório que usa a conexão ou transação informada
+func NewUserSQLRepository(db sqlstore.DBTX) *UserSQLRepository {
+	return &UserSQLRepository{db: db}
+}
+
+func (r *UserSQLRepository) CreateUser(user *entities.User) error {
+	existingUser, err := r.GetUserByEmail(user.Email)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil {
+		return errors.New("user already exists")
+	}
+	_, err = r.db.ExecContext(context.Background(),
+		`INSERT INTO users (`+userSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`,
+		user.ID, user.Name, user.Email, user.CreatedAt, user.LastUpdateAt, user.DeletedAt, user.Version, user.PasswordHash)
+	return err
+}
+
+func (r *UserSQLRepository) GetUserByEmail(email string) (*entities.User, error) {
+	row := r.db.QueryRowContext(context.Background(),
+		`SELECT `+userSQLColumns+` FROM users WHERE email = $1 AND deleted_at IS NULL`, email)
+	return scanUser(row)
+}
+
+func (r *UserSQLRepository) GetUserByID(id uuid.UUID) (*entities.User, error) {
+	row := r.db.QueryRowContext(context.Background(),
+		`SELECT `+userSQLColumns+` FROM users WHERE id = $1 AND deleted_at IS NULL`, id)
+	return scanUser(row)
+}
+
+func (r *UserSQLRepository) UpdateUser(user *entities.User) error {
+	existingUser, err := r.GetUserByEmail(user.Email)
+	if err != nil {
+		return err
+	}
+	if existingUser != nil && existingUser.ID != user.ID {
+		return errors.New("user already exists")
+	}
+
+	result, err := r.db.ExecContext(context.Background(),
+		`UPDATE users SET name = $1, email = $2, last_update_at = $3, version = version + 1 WHERE id = $4 AND version = $5`,
+		user.Name, user.Email, user.LastUpdateAt, user.ID, user.Version)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return r.updateFailure(user)
+	}
+	user.Version++
+	return nil
+}
+
+// WithTransaction cria um repositório que executa os comandos na transação SQL informada.
+// Outros tipos de transação não são suportados e o próprio repositório é retornado.
+func (r *UserSQLRepository) WithTransaction(tx uow.Transaction) repositories.IUserRepository {
+	sqlTransaction, ok := tx.(*sqlstore.Transaction)
+	if !ok {
+		return r
+	}
+	return NewUserSQLRepository(sqlTransaction)
+}
+
+// updateFailure distingue um usuário inexistente de um conflito de versão
+func (r *UserSQLRepository) updateFailure(user *entities.User) error {
+	storedUser, err := r.GetUserByID(user.ID)
+	if err != nil {
+		return err
+	}
+	if storedUser == nil {
+		return core.ErrUserNotFound(fmt.Errorf("user %s not found", user.ID))
+	}
+	return core.ErrConcurrencyConflict(fmt.Errorf("user %s: expected version %d, found %d", user.ID, user.Version, storedUser.Version))
+}
+
+func scanUser(row *sql.Row) (*entities.User, error) {
+	var user entities.User
+	err := row.Scan(&user.ID, &user.Name, &user.Email, &user.CreatedAt, &user.LastUpdateAt, &user.DeletedAt, &user.Version, &user.PasswordHash)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
@@ -0,0 +1,180 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core"
+	"flickly/internal/domain/users/entities"
+	"flickly/internal/infra/data/memory"
+	"flickly/internal/infra/data/sqlstore"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var userSQLColumnNames = []string{"id", "name", "email", "created_at", "last_update_at", "deleted_at", "version", "password_hash"}
+
+func TestUserSQLRepository_CreateUser(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	user := entities.NewUser("Test User", "test@example.com")
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WithArgs(user.Email).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames))
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO users")).
+		WithArgs(user.ID, user.Name, user.Email, user.CreatedAt, user.LastUpdateAt, user.DeletedAt, user.Version, user.PasswordHash).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewUserSQLRepository(db).CreateUser(user)
+
+	// Verificações
+	assert.NoError(t, err, "Não deve ocorrer erro ao criar o usuário")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSQLRepository_CreateUser_AlreadyExists(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	existing := entities.NewUser("Existing User", "test@example.com")
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames).
+			AddRow(existing.ID, existing.Name, existing.Email, existing.CreatedAt, nil, nil, 1, ""))
+
+	// Execução
+	err := NewUserSQLRepository(db).CreateUser(entities.NewUser("Duplicate", "test@example.com"))
+
+	// Verificações
+	assert.Error(t, err, "Deve ocorrer erro ao criar usuário com email duplicado")
+	assert.Equal(t, "user already exists", err.Error(), "Mensagem de erro incorreta")
+	assert.NoError(t, mock.ExpectationsWereMet(), "O INSERT não deve ser executado")
+}
+
+func TestUserSQLRepository_GetUserByEmail(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	existing := entities.NewUser("Test User", "test@example.com")
+	updatedAt := time.Now()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WithArgs("test@example.com").
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames).
+			AddRow(existing.ID, existing.Name, existing.Email, existing.CreatedAt, updatedAt, nil, 2, ""))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WithArgs("nonexistent@example.com").
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WillReturnError(errors.New("connection reset"))
+	repository := NewUserSQLRepository(db)
+
+	// Execução e verificações - usuário existente
+	user, err := repository.GetUserByEmail("test@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, existing.ID, user.ID, "O ID do usuário deve ser lido corretamente")
+	assert.Equal(t, existing.Name, user.Name, "O nome do usuário deve ser lido corretamente")
+	assert.NotNil(t, user.LastUpdateAt, "LastUpdateAt deve ser lido quando presente")
+	assert.Nil(t, user.DeletedAt, "DeletedAt deve ser nulo quando ausente")
+	assert.Equal(t, int64(2), user.Version, "A versão deve ser lida corretamente")
+
+	// Execução e verificações - usuário inexistente
+	user, err = repository.GetUserByEmail("nonexistent@example.com")
+	assert.NoError(t, err, "Não deve ocorrer erro ao buscar usuário não existente")
+	assert.Nil(t, user, "Deve retornar nil para usuário não encontrado")
+
+	// Execução e verificações - erro do banco
+	_, err = repository.GetUserByEmail("test@example.com")
+	assert.Error(t, err, "Erros do banco devem ser propagados")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSQLRepository_UpdateUser(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	user := entities.NewUser("Test User", "test@example.com")
+	user.Touch()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames).
+			AddRow(user.ID, user.Name, user.Email, user.CreatedAt, nil, nil, 1, ""))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET name = $1, email = $2, last_update_at = $3, version = version + 1 WHERE id = $4 AND version = $5")).
+		WithArgs(user.Name, user.Email, user.LastUpdateAt, user.ID, int64(1)).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewUserSQLRepository(db).UpdateUser(user)
+
+	// Verificações
+	assert.NoError(t, err, "A atualização com a versão correta deve ser aceita")
+	assert.Equal(t, int64(2), user.Version, "A versão deve ser incrementada após a atualização")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSQLRepository_UpdateUser_Conflict(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	user := entities.NewUser("Test User", "test@example.com")
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE id = $1")).
+		WithArgs(user.ID).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames).
+			AddRow(user.ID, user.Name, user.Email, user.CreatedAt, nil, nil, 5, ""))
+
+	// Execução
+	err := NewUserSQLRepository(db).UpdateUser(user)
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError), "O conflito deve ser um DomainError")
+	assert.Equal(t, 409, domainError.StatusCode, "O conflito deve ter status 409")
+	assert.Equal(t, int64(1), user.Version, "A versão não deve ser alterada em caso de conflito")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestUserSQLRepository_UpdateUser_NotFound(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	user := entities.NewUser("Test User", "test@example.com")
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE email = $1")).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE users SET")).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM users WHERE id = $1")).
+		WillReturnRows(sqlmock.NewRows(userSQLColumnNames))
+
+	// Execução
+	err := NewUserSQLRepository(db).UpdateUser(user)
+
+	// Verificações
+	var domainError *core.DomainError
+	assert.True(t, errors.As(err, &domainError))
+	assert.Equal(t, 404, domainError.StatusCode, "Atualizar usuário inexistente deve retornar 404")
+}
+
+func TestUserSQLRepository_WithTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	repository := NewUserSQLRepository(db)
+	sqlTransaction, _ := sqlstore.NewTransactionProvider(db).Begin(context.Background())
+	memoryTransaction, _ := memory.NewTransactionProvider().Begin(context.Background())
+	defer memoryTransaction.Rollback()
+
+	// Execução
+	bound := repository.WithTransaction(sqlTransaction)
+	fallback := repository.WithTransaction(memoryTransaction)
+
+	// Verificações
+	assert.NotSame(t, repository, bound, "O repositório deve ser vinculado à transação SQL")
+	assert.Same(t, repository, fallback, "Com outros tipos de transação, o repositório sem transação deve ser usado")
+}
@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"sync"
+)
+
+// background executa o laço Run de um executor em segundo plano entre Start e Stop, permitindo que o
+// contêiner de serviços o inicie e o pare
+type background struct {
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// start executa run em uma goroutine; chamadas seguidas sem stop são ignoradas
+func (b *background) start(run func(ctx context.Context)) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.cancel != nil {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	b.cancel, b.done = cancel, make(chan struct{})
+	go func(done chan struct{}) {
+		defer close(done)
+		run(ctx)
+	}(b.done)
+}
+
+// stop cancela o laço e espera ele terminar ou o prazo do contexto vencer
+func (b *background) stop(ctx context.Context) error {
+	b.mu.Lock()
+	cancel, done := b.cancel, b.done
+	b.cancel, b.done = nil, nil
+	b.mu.Unlock()
+	if cancel == nil {
+		return nil
+	}
+	cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,45 @@
+package messaging
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelay_StartStop(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	relay := NewRelay(store, &MockSink{})
+	relay.PollInterval = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Execução
+	startErr := relay.Start(ctx)
+	stopErr := relay.Stop(ctx)
+
+	// Verificações
+	assert.NoError(t, startErr, "Start não deve retornar erro")
+	assert.NoError(t, stopErr, "Stop deve esperar o laço terminar dentro do prazo")
+	assert.NoError(t, relay.Stop(ctx), "Parar um executor já parado não deve falhar")
+}
+
+func TestBackground_StopDeadline(t *testing.T) {
+	// Configuração
+	var loop background
+	release := make(chan struct{})
+	defer close(release)
+	loop.start(func(ctx context.Context) {
+		<-release
+	})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Execução
+	err := loop.stop(ctx)
+
+	// Verificações
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Stop deve desistir de esperar quando o prazo vence")
+}
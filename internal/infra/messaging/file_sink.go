@@ -0,0 +1,39 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/outbox"
+	"os"
+	"sync"
+)
+
+// FileSink é um Sink que acrescenta cada mensagem como uma linha JSON em um arquivo
+type FileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileSink cria um Sink que grava no arquivo informado
+func NewFileSink(path string) *FileSink {
+	return &FileSink{path: path}
+}
+
+func (s *FileSink) Publish(ctx context.Context, message outbox.Message) error {
+	line, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		_ = file.Close()
+		return err
+	}
+	return file.Close()
+}
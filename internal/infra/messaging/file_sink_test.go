@@ -0,0 +1,47 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/outbox"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSink_Publish(t *testing.T) {
+	// Configuração
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink := NewFileSink(path)
+	first := newTestMessage(t, "first")
+	second := newTestMessage(t, "second")
+
+	// Execução
+	assert.NoError(t, sink.Publish(context.Background(), *first))
+	assert.NoError(t, sink.Publish(context.Background(), *second))
+
+	// Verificações
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	assert.Len(t, lines, 2, "Cada mensagem deve ser gravada em uma linha")
+
+	var stored outbox.Message
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &stored))
+	assert.Equal(t, second.ID, stored.ID, "As mensagens devem ser acrescentadas em ordem")
+	assert.JSONEq(t, `{"value":"second"}`, string(stored.Payload))
+}
+
+func TestFileSink_PublishError(t *testing.T) {
+	// Configuração
+	sink := NewFileSink(filepath.Join(t.TempDir(), "missing", "events.jsonl"))
+
+	// Execução
+	err := sink.Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.Error(t, err, "Publish deve falhar quando o arquivo não pode ser aberto")
+}
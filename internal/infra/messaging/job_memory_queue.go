@@ -0,0 +1,148 @@
+package messaging
+
+import (
+	"encoding/json"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/memory"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+// JobMemoryQueue é a implementação em memória de jobs.Queue
+type JobMemoryQueue struct {
+	Jobs []jobs.Job
+	mu   sync.RWMutex
+}
+
+// NewJobMemoryQueue cria uma nova fila de jobs em memória
+func NewJobMemoryQueue() *JobMemoryQueue {
+	return &JobMemoryQueue{}
+}
+
+func (q *JobMemoryQueue) Enqueue(job *jobs.Job) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.Jobs = append(q.Jobs, *job)
+	return nil
+}
+
+func (q *JobMemoryQueue) Claim(now time.Time, lease time.Duration, limit int) ([]jobs.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var ready []*jobs.Job
+	for i := range q.Jobs {
+		job := &q.Jobs[i]
+		if (job.Status == jobs.StatusPending || job.Status == jobs.StatusRunning) && !job.NextAttemptAt.After(now) {
+			ready = append(ready, job)
+		}
+	}
+	sort.SliceStable(ready, func(i, j int) bool {
+		return ready[i].CreatedAt.Before(ready[j].CreatedAt)
+	})
+	if limit > 0 && len(ready) > limit {
+		ready = ready[:limit]
+	}
+	claimed := make([]jobs.Job, 0, len(ready))
+	for _, job := range ready {
+		job.Status = jobs.StatusRunning
+		job.Attempts++
+		job.NextAttemptAt = now.Add(lease)
+		job.UpdatedAt = now
+		claimed = append(claimed, *job)
+	}
+	return claimed, nil
+}
+
+func (q *JobMemoryQueue) Complete(id uuid.UUID, result json.RawMessage, completedAt time.Time) error {
+	return q.update(id, func(job *jobs.Job) {
+		job.Status = jobs.StatusSucceeded
+		job.Result = result
+		job.LastError = ""
+		job.UpdatedAt = completedAt
+	})
+}
+
+func (q *JobMemoryQueue) Retry(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return q.update(id, func(job *jobs.Job) {
+		job.Status = jobs.StatusPending
+		job.LastError = lastError
+		job.NextAttemptAt = nextAttemptAt
+		job.UpdatedAt = time.Now()
+	})
+}
+
+func (q *JobMemoryQueue) DeadLetter(id uuid.UUID, lastError string, failedAt time.Time) error {
+	return q.update(id, func(job *jobs.Job) {
+		job.Status = jobs.StatusDeadLetter
+		job.LastError = lastError
+		job.UpdatedAt = failedAt
+	})
+}
+
+func (q *JobMemoryQueue) Get(id uuid.UUID) (*jobs.Job, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	for _, job := range q.Jobs {
+		if job.ID == id {
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (q *JobMemoryQueue) DeadLetters(limit int) ([]jobs.Job, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	var deadLetters []jobs.Job
+	for _, job := range q.Jobs {
+		if job.Status == jobs.StatusDeadLetter {
+			deadLetters = append(deadLetters, job)
+		}
+	}
+	sort.SliceStable(deadLetters, func(i, j int) bool {
+		return deadLetters[i].UpdatedAt.After(deadLetters[j].UpdatedAt)
+	})
+	if limit > 0 && len(deadLetters) > limit {
+		deadLetters = deadLetters[:limit]
+	}
+	return deadLetters, nil
+}
+
+func (q *JobMemoryQueue) update(id uuid.UUID, change func(job *jobs.Job)) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for i := range q.Jobs {
+		if q.Jobs[i].ID == id {
+			change(&q.Jobs[i])
+			return nil
+		}
+	}
+	return fmt.Errorf("job %s not found", id)
+}
+
+// WithTransaction cria uma fila que inclui os jobs enfileirados apenas no commit da transação em memória informada.
+// As demais operações não participam da transação.
+func (q *JobMemoryQueue) WithTransaction(tx uow.Transaction) jobs.Queue {
+	memoryTransaction, ok := tx.(*memory.Transaction)
+	if !ok {
+		return q
+	}
+	return &transactionJobMemoryQueue{JobMemoryQueue: q, tx: memoryTransaction}
+}
+
+// transactionJobMemoryQueue é a fila usada dentro de uma transação em memória
+type transactionJobMemoryQueue struct {
+	*JobMemoryQueue
+	tx *memory.Transaction
+}
+
+func (q *transactionJobMemoryQueue) Enqueue(job *jobs.Job) error {
+	enqueued := *job
+	return q.tx.Defer(func() {
+		_ = q.JobMemoryQueue.Enqueue(&enqueued)
+	})
+}
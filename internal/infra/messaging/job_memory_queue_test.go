@@ -0,0 +1,128 @@
+package messaging
+
+import (
+	"context"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/data/memory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testJobRequest struct {
+	Value string `json:"value"`
+}
+
+func newTestJob(t *testing.T, value string) *jobs.Job {
+	job, err := jobs.NewJob("messaging.testJobRequest", testJobRequest{Value: value}, security.Principal{Subject: "user-1"}, "corr-1")
+	assert.NoError(t, err)
+	return job
+}
+
+func TestJobMemoryQueue_Claim(t *testing.T) {
+	// Configuração
+	queue := NewJobMemoryQueue()
+	first := newTestJob(t, "first")
+	second := newTestJob(t, "second")
+	second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+	delayed := newTestJob(t, "delayed")
+	delayed.NextAttemptAt = time.Now().Add(time.Hour)
+	assert.NoError(t, queue.Enqueue(second))
+	assert.NoError(t, queue.Enqueue(first))
+	assert.NoError(t, queue.Enqueue(delayed))
+	now := time.Now()
+
+	// Execução
+	claimed, err := queue.Claim(now, time.Minute, 10)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 2, "Jobs com execução futura não devem ser reservados")
+	assert.Equal(t, first.ID, claimed[0].ID, "Os jobs devem ser reservados em ordem de criação")
+	assert.Equal(t, jobs.StatusRunning, claimed[0].Status, "Os jobs reservados devem estar em execução")
+	assert.Equal(t, 1, claimed[0].Attempts, "A reserva deve contar a tentativa")
+	assert.Equal(t, now.Add(time.Minute), claimed[0].NextAttemptAt, "A reserva deve durar o tempo informado")
+
+	again, _ := queue.Claim(now, time.Minute, 10)
+	assert.Empty(t, again, "Jobs reservados não devem ser reservados de novo durante a reserva")
+	expired, _ := queue.Claim(now.Add(2*time.Minute), time.Minute, 1)
+	assert.Len(t, expired, 1, "Jobs cuja reserva venceu devem ser reservados de novo, respeitando o limite")
+	assert.Equal(t, 2, expired[0].Attempts)
+}
+
+func TestJobMemoryQueue_CompleteRetryAndDeadLetter(t *testing.T) {
+	// Configuração
+	queue := NewJobMemoryQueue()
+	completed := newTestJob(t, "completed")
+	retried := newTestJob(t, "retried")
+	dead := newTestJob(t, "dead")
+	for _, job := range []*jobs.Job{completed, retried, dead} {
+		assert.NoError(t, queue.Enqueue(job))
+	}
+	now := time.Now()
+	retryAt := now.Add(time.Minute)
+
+	// Execução
+	assert.NoError(t, queue.Complete(completed.ID, []byte(`{"ok":true}`), now))
+	assert.NoError(t, queue.Retry(retried.ID, "timeout", retryAt))
+	assert.NoError(t, queue.DeadLetter(dead.ID, "boom", now))
+
+	// Verificações
+	job, err := queue.Get(completed.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status)
+	assert.JSONEq(t, `{"ok":true}`, string(job.Result), "O resultado deve ser guardado")
+	job, _ = queue.Get(retried.ID)
+	assert.Equal(t, jobs.StatusPending, job.Status, "Jobs com nova tentativa voltam a ficar pendentes")
+	assert.Equal(t, "timeout", job.LastError)
+	assert.Equal(t, retryAt, job.NextAttemptAt)
+	deadLetters, _ := queue.DeadLetters(10)
+	assert.Len(t, deadLetters, 1, "Jobs que esgotaram as tentativas devem ir para o dead-letter")
+	assert.Equal(t, dead.ID, deadLetters[0].ID)
+	claimed, _ := queue.Claim(now, time.Minute, 10)
+	assert.Empty(t, claimed, "Jobs finalizados não devem ser reservados")
+
+	missing, err := queue.Get(uuid.New())
+	assert.NoError(t, err)
+	assert.Nil(t, missing, "Get deve retornar nil para jobs inexistentes")
+	assert.Error(t, queue.Complete(uuid.New(), nil, now), "Complete deve falhar para jobs inexistentes")
+}
+
+func TestJobMemoryQueue_Rollback(t *testing.T) {
+	// Configuração
+	queue := NewJobMemoryQueue()
+	claimed := newTestJob(t, "claimed")
+	assert.NoError(t, queue.Enqueue(claimed))
+	provider := memory.NewTransactionProvider()
+	tx, _ := provider.Begin(context.Background())
+
+	// Execução
+	assert.NoError(t, queue.WithTransaction(tx).Enqueue(newTestJob(t, "discarded")))
+	_, _ = queue.Claim(time.Now(), time.Minute, 10)
+	assert.NoError(t, tx.Rollback())
+
+	// Verificações
+	assert.Len(t, queue.Jobs, 1, "Os jobs enfileirados devem ser descartados no rollback")
+	stored, _ := queue.Get(claimed.ID)
+	assert.Equal(t, jobs.StatusRunning, stored.Status, "As reservas feitas fora da transação não devem ser desfeitas")
+}
+
+func TestJobMemoryQueue_Commit(t *testing.T) {
+	// Configuração
+	queue := NewJobMemoryQueue()
+	provider := memory.NewTransactionProvider()
+	tx, _ := provider.Begin(context.Background())
+	job := newTestJob(t, "committed")
+
+	// Execução
+	assert.NoError(t, queue.WithTransaction(tx).Enqueue(job))
+	assert.Empty(t, queue.Jobs, "O job só deve ser incluído no commit")
+	assert.NoError(t, tx.Commit())
+
+	// Verificações
+	stored, _ := queue.Get(job.ID)
+	assert.NotNil(t, stored, "O job deve ser incluído no commit")
+}
@@ -0,0 +1,153 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"github.com/google/uuid"
+	"time"
+)
+
+// JobSQLSchema cria a tabela usada por JobSQLQueue
+const JobSQLSchema = `CREATE TABLE IF NOT EXISTS jobs (
+	id UUID PRIMARY KEY,
+	request_type TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	status TEXT NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	max_attempts INT NOT NULL,
+	next_attempt_at TIMESTAMP NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	updated_at TIMESTAMP NOT NULL,
+	last_error TEXT NOT NULL DEFAULT '',
+	result TEXT NULL,
+	principal TEXT NOT NULL,
+	correlation_id TEXT NOT NULL DEFAULT ''
+)`
+
+const jobSQLColumns = `id, request_type, payload, status, attempts, max_attempts, next_attempt_at, created_at, updated_at, last_error, result, principal, correlation_id`
+
+// JobSQLQueue é a implementação de jobs.Queue em banco de dados SQL
+type JobSQLQueue struct {
+	db sqlstore.DBTX
+}
+
+// NewJobSQLQueue cria uma fila de jobs que usa a conexão ou transação informada
+func NewJobSQLQueue(db sqlstore.DBTX) *JobSQLQueue {
+	return &JobSQLQueue{db: db}
+}
+
+func (q *JobSQLQueue) Enqueue(job *jobs.Job) error {
+	principal, err := json.Marshal(job.Principal)
+	if err != nil {
+		return err
+	}
+	_, err = q.db.ExecContext(context.Background(),
+		`INSERT INTO jobs (`+jobSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)`,
+		job.ID, job.RequestType, string(job.Payload), job.Status, job.Attempts, job.MaxAttempts, job.NextAttemptAt,
+		job.CreatedAt, job.UpdatedAt, job.LastError, nullableJSON(job.Result), string(principal), job.CorrelationID)
+	return err
+}
+
+// Claim usa FOR UPDATE SKIP LOCKED para que workers concorrentes não reservem o mesmo job
+func (q *JobSQLQueue) Claim(now time.Time, lease time.Duration, limit int) ([]jobs.Job, error) {
+	return q.query(`UPDATE jobs SET status = $1, attempts = attempts + 1, next_attempt_at = $2, updated_at = $3
+		WHERE id IN (
+			SELECT id FROM jobs WHERE status IN ($4, $5) AND next_attempt_at <= $3
+			ORDER BY created_at LIMIT $6 FOR UPDATE SKIP LOCKED
+		) RETURNING `+jobSQLColumns,
+		jobs.StatusRunning, now.Add(lease), now, jobs.StatusPending, jobs.StatusRunning, limit)
+}
+
+func (q *JobSQLQueue) Complete(id uuid.UUID, result json.RawMessage, completedAt time.Time) error {
+	return q.update(`UPDATE jobs SET status = $1, result = $2, last_error = '', updated_at = $3 WHERE id = $4`,
+		id, jobs.StatusSucceeded, nullableJSON(result), completedAt, id)
+}
+
+func (q *JobSQLQueue) Retry(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return q.update(`UPDATE jobs SET status = $1, last_error = $2, next_attempt_at = $3, updated_at = $4 WHERE id = $5`,
+		id, jobs.StatusPending, lastError, nextAttemptAt, time.Now(), id)
+}
+
+func (q *JobSQLQueue) DeadLetter(id uuid.UUID, lastError string, failedAt time.Time) error {
+	return q.update(`UPDATE jobs SET status = $1, last_error = $2, updated_at = $3 WHERE id = $4`,
+		id, jobs.StatusDeadLetter, lastError, failedAt, id)
+}
+
+func (q *JobSQLQueue) Get(id uuid.UUID) (*jobs.Job, error) {
+	found, err := q.query(`SELECT `+jobSQLColumns+` FROM jobs WHERE id = $1`, id)
+	if err != nil || len(found) == 0 {
+		return nil, err
+	}
+	return &found[0], nil
+}
+
+func (q *JobSQLQueue) DeadLetters(limit int) ([]jobs.Job, error) {
+	return q.query(`SELECT `+jobSQLColumns+` FROM jobs WHERE status = $1 ORDER BY updated_at DESC LIMIT $2`,
+		jobs.StatusDeadLetter, limit)
+}
+
+// WithTransaction cria uma fila que enfileira os jobs na transação SQL informada.
+// Outros tipos de transação não são suportados e a própria fila é retornada.
+func (q *JobSQLQueue) WithTransaction(tx uow.Transaction) jobs.Queue {
+	sqlTransaction, ok := tx.(*sqlstore.Transaction)
+	if !ok {
+		return q
+	}
+	return NewJobSQLQueue(sqlTransaction)
+}
+
+func (q *JobSQLQueue) query(query string, args ...interface{}) ([]jobs.Job, error) {
+	rows, err := q.db.QueryContext(context.Background(), query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var found []jobs.Job
+	for rows.Next() {
+		var job jobs.Job
+		var payload, principal string
+		var result sql.NullString
+		if err := rows.Scan(&job.ID, &job.RequestType, &payload, &job.Status, &job.Attempts, &job.MaxAttempts,
+			&job.NextAttemptAt, &job.CreatedAt, &job.UpdatedAt, &job.LastError, &result, &principal, &job.CorrelationID); err != nil {
+			return nil, err
+		}
+		job.Payload = []byte(payload)
+		if result.Valid {
+			job.Result = []byte(result.String)
+		}
+		if err := json.Unmarshal([]byte(principal), &job.Principal); err != nil {
+			return nil, err
+		}
+		found = append(found, job)
+	}
+	return found, rows.Err()
+}
+
+func (q *JobSQLQueue) update(query string, id uuid.UUID, args ...interface{}) error {
+	result, err := q.db.ExecContext(context.Background(), query, args...)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("job %s not found", id)
+	}
+	return nil
+}
+
+// nullableJSON grava NULL quando não há conteúdo
+func nullableJSON(value json.RawMessage) interface{} {
+	if len(value) == 0 {
+		return nil
+	}
+	return string(value)
+}
@@ -0,0 +1,134 @@
+package messaging
+
+import (
+	"context"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/infra/data/memory"
+	"flickly/internal/infra/data/sqlstore"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var jobSQLColumnNames = []string{"id", "request_type", "payload", "status", "attempts", "max_attempts", "next_attempt_at",
+	"created_at", "updated_at", "last_error", "result", "principal", "correlation_id"}
+
+func TestJobSQLQueue_Enqueue(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	job := newTestJob(t, "value")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO jobs")).
+		WithArgs(job.ID, "messaging.testJobRequest", `{"value":"value"}`, jobs.StatusPending, 0, jobs.DefaultMaxAttempts,
+			job.NextAttemptAt, job.CreatedAt, job.UpdatedAt, "", nil, `{"sub":"user-1"}`, "corr-1").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewJobSQLQueue(db).Enqueue(job)
+
+	// Verificações
+	assert.NoError(t, err, "Enqueue não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobSQLQueue_Claim(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta("FOR UPDATE SKIP LOCKED")).
+		WithArgs(jobs.StatusRunning, now.Add(time.Minute), now, jobs.StatusPending, jobs.StatusRunning, 5).
+		WillReturnRows(sqlmock.NewRows(jobSQLColumnNames).
+			AddRow(id, "messaging.testJobRequest", `{"value":"a"}`, jobs.StatusRunning, 2, 5, now.Add(time.Minute),
+				now, now, "timeout", nil, `{"sub":"user-1","roles":["admin"]}`, "corr-1"))
+
+	// Execução
+	claimed, err := NewJobSQLQueue(db).Claim(now, time.Minute, 5)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, claimed, 1)
+	assert.Equal(t, id, claimed[0].ID, "O ID deve ser lido corretamente")
+	assert.JSONEq(t, `{"value":"a"}`, string(claimed[0].Payload), "O payload deve ser lido corretamente")
+	assert.Equal(t, 2, claimed[0].Attempts, "As tentativas devem ser lidas corretamente")
+	assert.Nil(t, claimed[0].Result, "Um resultado nulo não deve ser lido")
+	assert.Equal(t, "user-1", claimed[0].Principal.Subject, "O usuário deve ser lido corretamente")
+	assert.True(t, claimed[0].Principal.HasRole("admin"), "Os papéis do usuário devem ser lidos corretamente")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobSQLQueue_CompleteRetryAndDeadLetter(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	id := uuid.New()
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE jobs SET status = $1, result = $2")).
+		WithArgs(jobs.StatusSucceeded, `{"ok":true}`, now, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE jobs SET status = $1, last_error = $2, next_attempt_at = $3")).
+		WithArgs(jobs.StatusPending, "timeout", now, sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE jobs SET status = $1, last_error = $2, updated_at = $3")).
+		WithArgs(jobs.StatusDeadLetter, "boom", now, id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	queue := NewJobSQLQueue(db)
+
+	// Execução e verificações
+	assert.NoError(t, queue.Complete(id, []byte(`{"ok":true}`), now), "Complete não deve retornar erro")
+	assert.NoError(t, queue.Retry(id, "timeout", now), "Retry não deve retornar erro")
+	assert.Error(t, queue.DeadLetter(id, "boom", now), "DeadLetter deve falhar quando o job não existe")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobSQLQueue_Get(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	id := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta("FROM jobs WHERE id = $1")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows(jobSQLColumnNames).
+			AddRow(id, "messaging.testJobRequest", `{}`, jobs.StatusSucceeded, 1, 5, now, now, now, "", `{"ok":true}`, `{"sub":""}`, ""))
+	mock.ExpectQuery(regexp.QuoteMeta("FROM jobs WHERE id = $1")).
+		WithArgs(sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows(jobSQLColumnNames))
+	queue := NewJobSQLQueue(db)
+
+	// Execução
+	job, err := queue.Get(id)
+	missing, missingErr := queue.Get(uuid.New())
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status, "O status deve ser lido corretamente")
+	assert.JSONEq(t, `{"ok":true}`, string(job.Result), "O resultado deve ser lido corretamente")
+	assert.NoError(t, missingErr)
+	assert.Nil(t, missing, "Get deve retornar nil para jobs inexistentes")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestJobSQLQueue_WithTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	queue := NewJobSQLQueue(db)
+	sqlTransaction, _ := sqlstore.NewTransactionProvider(db).Begin(context.Background())
+	memoryTransaction, _ := memory.NewTransactionProvider().Begin(context.Background())
+	defer memoryTransaction.Rollback()
+
+	// Execução
+	bound := queue.WithTransaction(sqlTransaction)
+	fallback := queue.WithTransaction(memoryTransaction)
+
+	// Verificações
+	assert.NotSame(t, queue, bound, "A fila deve ser vinculado à transação SQL")
+	assert.Same(t, queue, fallback, "Com outros tipos de transação, a fila sem transação deve ser usado")
+}
@@ -0,0 +1,157 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Valores padrão usados pelo Worker
+const (
+	DefaultWorkerConcurrency  = 4
+	DefaultWorkerPollInterval = time.Second
+	DefaultWorkerBaseBackoff  = time.Second
+	DefaultWorkerMaxBackoff   = 5 * time.Minute
+	DefaultWorkerLease        = 5 * time.Minute
+)
+
+// Worker reserva os jobs prontos da fila e os executa pelo mediator.
+// Jobs com falha são reagendados com backoff exponencial e vão para o dead-letter ao esgotar as tentativas.
+type Worker struct {
+	queue        jobs.Queue
+	mediator     mediator.JobRunner
+	Concurrency  int
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease é o tempo que um job fica reservado; se o worker parar, o job volta a ser executado depois desse prazo
+	Lease time.Duration
+	Now   func() time.Time
+	// Services, quando informado, cria um escopo de serviços por job com o security.Principal do job registrado
+	Services utilities.IServiceCollection
+	loop     background
+}
+
+// NewWorker cria um worker com as configurações padrão
+func NewWorker(queue jobs.Queue, mediator mediator.JobRunner) *Worker {
+	return &Worker{
+		queue:        queue,
+		mediator:     mediator,
+		Concurrency:  DefaultWorkerConcurrency,
+		PollInterval: DefaultWorkerPollInterval,
+		BaseBackoff:  DefaultWorkerBaseBackoff,
+		MaxBackoff:   DefaultWorkerMaxBackoff,
+		Lease:        DefaultWorkerLease,
+		Now:          time.Now,
+	}
+}
+
+// RunOnce executa em paralelo até Concurrency jobs prontos e retorna quantos foram concluídos
+func (w *Worker) RunOnce(ctx context.Context) (int, error) {
+	claimed, err := w.queue.Claim(w.Now(), w.Lease, w.Concurrency)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	succeeded := 0
+	var errs []error
+	for _, job := range claimed {
+		wg.Add(1)
+		go func(job jobs.Job) {
+			defer wg.Done()
+			ok, err := w.process(ctx, job)
+			mu.Lock()
+			defer mu.Unlock()
+			if ok {
+				succeeded++
+			}
+			if err != nil {
+				errs = append(errs, err)
+			}
+		}(job)
+	}
+	wg.Wait()
+	return succeeded, errors.Join(errs...)
+}
+
+// Start executa Run em segundo plano até Stop
+func (w *Worker) Start(ctx context.Context) error {
+	w.loop.start(w.Run)
+	return nil
+}
+
+// Stop interrompe o Run iniciado por Start e espera os jobs em andamento terminarem
+func (w *Worker) Stop(ctx context.Context) error {
+	return w.loop.stop(ctx)
+}
+
+// Run executa RunOnce periodicamente até o contexto ser cancelado
+func (w *Worker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.PollInterval)
+	defer ticker.Stop()
+	for {
+		_, _ = w.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// process executa o job e registra o resultado na fila
+func (w *Worker) process(ctx context.Context, job jobs.Job) (bool, error) {
+	if w.Services != nil {
+		scope := w.Services.CreateScope()
+		utilities.AddService[security.Principal](scope, job.Principal)
+		ctx = utilities.WithScope(ctx, scope)
+		defer func() { _ = scope.Stop(ctx) }()
+	}
+	response, runErr := w.run(ctx, job)
+	if runErr == nil {
+		result, err := json.Marshal(response)
+		if err != nil {
+			return false, w.queue.DeadLetter(job.ID, err.Error(), w.Now())
+		}
+		return true, w.queue.Complete(job.ID, result, w.Now())
+	}
+	if isPermanentJobError(runErr) || job.Attempts >= job.MaxAttempts {
+		return false, w.queue.DeadLetter(job.ID, runErr.Error(), w.Now())
+	}
+	return false, w.queue.Retry(job.ID, runErr.Error(), w.Now().Add(w.backoff(job.Attempts-1)))
+}
+
+// run executa o job convertendo um panic em erro para não derrubar o worker
+func (w *Worker) run(ctx context.Context, job jobs.Job) (response mediator.Response, err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("job panicked: %v", recovered)
+		}
+	}()
+	return w.mediator.RunJob(ctx, job)
+}
+
+// isPermanentJobError indica erros que não serão resolvidos com novas tentativas
+func isPermanentJobError(err error) bool {
+	return errors.Is(err, mediator.ErrHandlerNotFound) || errors.Is(err, mediator.ErrInvalidJobPayload)
+}
+
+// backoff calcula o atraso da próxima tentativa a partir do número de tentativas já feitas
+func (w *Worker) backoff(attempts int) time.Duration {
+	delay := w.BaseBackoff
+	for i := 0; i < attempts && delay < w.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > w.MaxBackoff {
+		return w.MaxBackoff
+	}
+	return delay
+}
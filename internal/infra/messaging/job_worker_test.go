@@ -0,0 +1,145 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/infra/crosscutting/utilities"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestWorker cria um worker cujo mediator executa handler para testJobRequest
+func newTestWorker(t *testing.T, handler func(ctx context.Context, request testJobRequest) (string, error)) (*Worker, mediator.Mediator, *JobMemoryQueue) {
+	queue := NewJobMemoryQueue()
+	mediatR := mediator.NewMediatR(mediator.WithJobQueue(queue))
+	mediator.Register[testJobRequest, string](mediatR, mediator.RequestHandlerFunc[testJobRequest, string](handler))
+	return NewWorker(queue, mediatR), mediatR, queue
+}
+
+func TestWorker_RunOnce(t *testing.T) {
+	// Configuração
+	var subject string
+	worker, mediatR, queue := newTestWorker(t, func(ctx context.Context, request testJobRequest) (string, error) {
+		subject = security.PrincipalFromContext(ctx).Subject
+		return "done " + request.Value, nil
+	})
+	ctx := security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"})
+	id, err := mediatR.Enqueue(ctx, testJobRequest{Value: "value"})
+	assert.NoError(t, err)
+
+	// Execução
+	succeeded, err := worker.RunOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err, "RunOnce não deve retornar erro")
+	assert.Equal(t, 1, succeeded, "O job pendente deve ser executado")
+	assert.Equal(t, "user-1", subject, "O job deve ser executado em nome de quem o enfileirou")
+	job, _ := queue.Get(id)
+	assert.Equal(t, jobs.StatusSucceeded, job.Status, "O job deve ser concluído")
+	assert.JSONEq(t, `"done value"`, string(job.Result), "O resultado do handler deve ser guardado")
+
+	succeeded, _ = worker.RunOnce(context.Background())
+	assert.Equal(t, 0, succeeded, "Jobs concluídos não devem ser executados novamente")
+}
+
+func TestWorker_RunOnce_RetriesAndDeadLetters(t *testing.T) {
+	// Configuração
+	worker, mediatR, queue := newTestWorker(t, func(ctx context.Context, request testJobRequest) (string, error) {
+		return "", errors.New("service unavailable")
+	})
+	id, _ := mediatR.Enqueue(context.Background(), testJobRequest{Value: "value"})
+	queue.Jobs[0].MaxAttempts = 2
+	now := queue.Jobs[0].CreatedAt.Add(time.Second)
+	worker.Now = func() time.Time { return now }
+
+	// Execução e verificações - primeira falha
+	_, err := worker.RunOnce(context.Background())
+	assert.NoError(t, err)
+	job, _ := queue.Get(id)
+	assert.Equal(t, jobs.StatusPending, job.Status, "O job deve ser reagendado após a falha")
+	assert.Equal(t, "service unavailable", job.LastError, "O erro deve ser registrado")
+	assert.Equal(t, now.Add(worker.BaseBackoff), job.NextAttemptAt, "A primeira nova tentativa deve usar o backoff base")
+
+	// Execução e verificações - tentativas esgotadas
+	now = job.NextAttemptAt
+	_, _ = worker.RunOnce(context.Background())
+	job, _ = queue.Get(id)
+	assert.Equal(t, jobs.StatusDeadLetter, job.Status, "O job deve ir para o dead-letter ao esgotar as tentativas")
+	assert.Equal(t, 2, job.Attempts)
+}
+
+func TestWorker_RunOnce_RecoversFromPanic(t *testing.T) {
+	// Configuração
+	worker, mediatR, queue := newTestWorker(t, func(ctx context.Context, request testJobRequest) (string, error) {
+		panic("boom")
+	})
+	id, _ := mediatR.Enqueue(context.Background(), testJobRequest{Value: "value"})
+
+	// Execução
+	_, err := worker.RunOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err)
+	job, _ := queue.Get(id)
+	assert.Equal(t, jobs.StatusPending, job.Status, "Um panic deve ser tratado como falha recuperável")
+	assert.Contains(t, job.LastError, "boom", "O panic deve ser registrado como erro")
+}
+
+func TestWorker_RunOnce_DeadLettersUnknownRequestType(t *testing.T) {
+	// Configuração
+	worker, _, queue := newTestWorker(t, func(ctx context.Context, request testJobRequest) (string, error) {
+		return "", nil
+	})
+	job := newTestJob(t, "value")
+	_ = queue.Enqueue(job)
+
+	// Execução
+	_, _ = worker.RunOnce(context.Background())
+
+	// Verificações
+	stored, _ := queue.Get(job.ID)
+	assert.Equal(t, jobs.StatusDeadLetter, stored.Status, "Jobs sem handler não devem ser tentados novamente")
+	assert.Equal(t, 1, stored.Attempts)
+}
+
+func TestWorker_Backoff(t *testing.T) {
+	// Configuração
+	worker := NewWorker(NewJobMemoryQueue(), nil)
+	worker.BaseBackoff = time.Second
+	worker.MaxBackoff = 10 * time.Second
+
+	// Execução e verificações
+	assert.Equal(t, time.Second, worker.backoff(0))
+	assert.Equal(t, 4*time.Second, worker.backoff(2))
+	assert.Equal(t, 10*time.Second, worker.backoff(10), "O backoff deve respeitar o máximo")
+}
+
+func TestWorker_RunOnce_CreatesScopePerJob(t *testing.T) {
+	// Configuração
+	var subjects []string
+	var scopes []utilities.IServiceCollection
+	worker, mediatR, _ := newTestWorker(t, func(ctx context.Context, request testJobRequest) (string, error) {
+		scope := utilities.ScopeFromContext(ctx)
+		scopes = append(scopes, scope)
+		subjects = append(subjects, utilities.GetService[security.Principal](scope).Subject)
+		return "", nil
+	})
+	worker.Services = utilities.NewServiceCollection()
+	worker.Concurrency = 1
+	_, _ = mediatR.Enqueue(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"}), testJobRequest{})
+	_, _ = mediatR.Enqueue(security.WithPrincipal(context.Background(), security.Principal{Subject: "user-2"}), testJobRequest{})
+
+	// Execução
+	_, _ = worker.RunOnce(context.Background())
+	_, _ = worker.RunOnce(context.Background())
+
+	// Verificações
+	assert.ElementsMatch(t, []string{"user-1", "user-2"}, subjects, "O principal de cada job deve ser registrado no seu escopo")
+	assert.Len(t, scopes, 2)
+	assert.NotSame(t, scopes[0], scopes[1], "Cada job deve ter o seu próprio escopo")
+}
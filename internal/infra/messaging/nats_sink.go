@@ -0,0 +1,133 @@
+package messaging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flickly/internal/domain/core/outbox"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultNATSTimeout limita conexão e confirmação quando o contexto não tem prazo
+const defaultNATSTimeout = 5 * time.Second
+
+// NATSSink é um Sink que publica as mensagens em um servidor compatível com o protocolo NATS.
+// Cada mensagem é publicada no assunto "<prefixo>.<nome do evento>" e confirmada com PING/PONG.
+type NATSSink struct {
+	address       string
+	subjectPrefix string
+	conn          net.Conn
+	reader        *bufio.Reader
+	mu            sync.Mutex
+}
+
+// NewNATSSink cria um Sink para o servidor NATS no endereço informado (host:porta ou nats://host:porta)
+func NewNATSSink(address string, subjectPrefix string) *NATSSink {
+	return &NATSSink{
+		address:       strings.TrimPrefix(address, "nats://"),
+		subjectPrefix: subjectPrefix,
+	}
+}
+
+func (s *NATSSink) Publish(ctx context.Context, message outbox.Message) error {
+	payload, err := json.Marshal(message)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err = s.connect(ctx); err != nil {
+		return err
+	}
+	if err = s.publish(ctx, s.subject(message.EventName), payload); err != nil {
+		s.closeConnection()
+		return err
+	}
+	return nil
+}
+
+// Close encerra a conexão com o servidor
+func (s *NATSSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.closeConnection()
+	return nil
+}
+
+func (s *NATSSink) subject(eventName string) string {
+	if s.subjectPrefix == "" {
+		return eventName
+	}
+	return s.subjectPrefix + "." + eventName
+}
+
+func (s *NATSSink) connect(ctx context.Context) error {
+	if s.conn != nil {
+		return nil
+	}
+	dialer := net.Dialer{Timeout: defaultNATSTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", s.address)
+	if err != nil {
+		return err
+	}
+	s.conn = conn
+	s.reader = bufio.NewReader(conn)
+	s.setDeadline(ctx)
+
+	line, err := s.reader.ReadString('\n')
+	if err != nil || !strings.HasPrefix(line, "INFO ") {
+		s.closeConnection()
+		return fmt.Errorf("unexpected NATS greeting %q: %v", strings.TrimSpace(line), err)
+	}
+	if _, err = fmt.Fprint(conn, "CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":\"flickly\"}\r\n"); err != nil {
+		s.closeConnection()
+		return err
+	}
+	return nil
+}
+
+func (s *NATSSink) publish(ctx context.Context, subject string, payload []byte) error {
+	s.setDeadline(ctx)
+	if _, err := fmt.Fprintf(s.conn, "PUB %s %d\r\n%s\r\nPING\r\n", subject, len(payload), payload); err != nil {
+		return err
+	}
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			if _, err = fmt.Fprint(s.conn, "PONG\r\n"); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return errors.New("NATS server error: " + strings.TrimSpace(strings.TrimPrefix(line, "-ERR")))
+		}
+	}
+}
+
+func (s *NATSSink) setDeadline(ctx context.Context) {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultNATSTimeout)
+	}
+	_ = s.conn.SetDeadline(deadline)
+}
+
+func (s *NATSSink) closeConnection() {
+	if s.conn != nil {
+		_ = s.conn.Close()
+	}
+	s.conn = nil
+	s.reader = nil
+}
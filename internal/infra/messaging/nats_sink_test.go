@@ -0,0 +1,140 @@
+package messaging
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flickly/internal/domain/core/outbox"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// natsStandIn é um servidor mínimo compatível com o protocolo NATS para os testes
+type natsStandIn struct {
+	listener  net.Listener
+	mu        sync.Mutex
+	published map[string][][]byte
+	rejectPub bool
+}
+
+func newNATSStandIn(t *testing.T) *natsStandIn {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &natsStandIn{listener: listener, published: make(map[string][][]byte)}
+	go server.serve()
+	t.Cleanup(func() { _ = listener.Close() })
+	return server
+}
+
+func (s *natsStandIn) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *natsStandIn) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	fmt.Fprint(conn, "INFO {\"server_id\":\"stand-in\"}\r\n")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "PUB":
+			size, _ := strconv.Atoi(fields[len(fields)-1])
+			payload := make([]byte, size+2)
+			if _, err := io.ReadFull(reader, payload); err != nil {
+				return
+			}
+			s.mu.Lock()
+			reject := s.rejectPub
+			if !reject {
+				s.published[fields[1]] = append(s.published[fields[1]], payload[:size])
+			}
+			s.mu.Unlock()
+			if reject {
+				fmt.Fprint(conn, "-ERR 'Permissions Violation'\r\n")
+			}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func (s *natsStandIn) messages(subject string) [][]byte {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.published[subject]
+}
+
+func TestNATSSink_Publish(t *testing.T) {
+	// Configuração
+	server := newNATSStandIn(t)
+	sink := NewNATSSink(server.listener.Addr().String(), "flickly.events")
+	defer sink.Close()
+	first := newTestMessage(t, "first")
+	second := newTestMessage(t, "second")
+
+	// Execução
+	assert.NoError(t, sink.Publish(context.Background(), *first), "Publish não deve retornar erro")
+	assert.NoError(t, sink.Publish(context.Background(), *second), "A conexão deve ser reutilizada")
+
+	// Verificações
+	published := server.messages("flickly.events.test.happened")
+	assert.Len(t, published, 2, "As mensagens devem ser publicadas no assunto do evento")
+	var stored outbox.Message
+	assert.NoError(t, json.Unmarshal(published[0], &stored))
+	assert.Equal(t, first.ID, stored.ID, "A mensagem completa deve ser publicada")
+}
+
+func TestNATSSink_PublishServerError(t *testing.T) {
+	// Configuração
+	server := newNATSStandIn(t)
+	server.rejectPub = true
+	sink := NewNATSSink(server.listener.Addr().String(), "")
+	defer sink.Close()
+
+	// Execução
+	err := sink.Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.Error(t, err, "Erros do servidor devem ser retornados")
+	assert.Contains(t, err.Error(), "Permissions Violation")
+
+	// Após a falha o sink deve reconectar
+	server.mu.Lock()
+	server.rejectPub = false
+	server.mu.Unlock()
+	assert.NoError(t, sink.Publish(context.Background(), *newTestMessage(t, "value")), "O sink deve reconectar após uma falha")
+	assert.Len(t, server.messages("test.happened"), 1)
+}
+
+func TestNATSSink_PublishConnectionError(t *testing.T) {
+	// Configuração
+	listener, _ := net.Listen("tcp", "127.0.0.1:0")
+	address := listener.Addr().String()
+	_ = listener.Close()
+
+	// Execução
+	err := NewNATSSink(address, "").Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.Error(t, err, "Publish deve falhar quando o servidor está indisponível")
+}
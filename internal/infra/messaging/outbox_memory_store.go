@@ -0,0 +1,110 @@
+package messaging
+
+import (
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/memory"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"sync"
+	"time"
+)
+
+// OutboxMemoryStore é a implementação em memória de outbox.Store
+type OutboxMemoryStore struct {
+	Messages []outbox.Message
+	mu       sync.RWMutex
+}
+
+// NewOutboxMemoryStore cria um novo outbox em memória
+func NewOutboxMemoryStore() *OutboxMemoryStore {
+	return &OutboxMemoryStore{}
+}
+
+func (s *OutboxMemoryStore) Add(message *outbox.Message) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Messages = append(s.Messages, *message)
+	return nil
+}
+
+func (s *OutboxMemoryStore) Claim(now time.Time, lease time.Duration, limit int) ([]outbox.Message, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var pending []int
+	for i, message := range s.Messages {
+		if message.DeliveredAt == nil && !message.NextAttemptAt.After(now) {
+			pending = append(pending, i)
+		}
+	}
+	sort.SliceStable(pending, func(i, j int) bool {
+		return s.Messages[pending[i]].CreatedAt.Before(s.Messages[pending[j]].CreatedAt)
+	})
+	if limit > 0 && len(pending) > limit {
+		pending = pending[:limit]
+	}
+	claimed := make([]outbox.Message, 0, len(pending))
+	for _, i := range pending {
+		s.Messages[i].NextAttemptAt = now.Add(lease)
+		claimed = append(claimed, s.Messages[i])
+	}
+	return claimed, nil
+}
+
+func (s *OutboxMemoryStore) MarkHandled(id uuid.UUID, handledAt time.Time) error {
+	return s.update(id, func(message *outbox.Message) {
+		message.HandledAt = &handledAt
+	})
+}
+
+func (s *OutboxMemoryStore) MarkDelivered(id uuid.UUID, deliveredAt time.Time) error {
+	return s.update(id, func(message *outbox.Message) {
+		message.Attempts++
+		message.DeliveredAt = &deliveredAt
+		message.LastError = ""
+	})
+}
+
+func (s *OutboxMemoryStore) MarkFailed(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	return s.update(id, func(message *outbox.Message) {
+		message.Attempts++
+		message.LastError = lastError
+		message.NextAttemptAt = nextAttemptAt
+	})
+}
+
+func (s *OutboxMemoryStore) update(id uuid.UUID, change func(message *outbox.Message)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := range s.Messages {
+		if s.Messages[i].ID == id {
+			change(&s.Messages[i])
+			return nil
+		}
+	}
+	return fmt.Errorf("outbox message %s not found", id)
+}
+
+// WithTransaction cria um outbox que inclui as mensagens apenas no commit da transação em memória informada.
+// As demais operações não participam da transação.
+func (s *OutboxMemoryStore) WithTransaction(tx uow.Transaction) outbox.Store {
+	memoryTransaction, ok := tx.(*memory.Transaction)
+	if !ok {
+		return s
+	}
+	return &transactionOutboxMemoryStore{OutboxMemoryStore: s, tx: memoryTransaction}
+}
+
+// transactionOutboxMemoryStore é o outbox usado dentro de uma transação em memória
+type transactionOutboxMemoryStore struct {
+	*OutboxMemoryStore
+	tx *memory.Transaction
+}
+
+func (s *transactionOutboxMemoryStore) Add(message *outbox.Message) error {
+	added := *message
+	return s.tx.Defer(func() {
+		_ = s.OutboxMemoryStore.Add(&added)
+	})
+}
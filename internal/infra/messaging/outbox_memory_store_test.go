@@ -0,0 +1,123 @@
+package messaging
+
+import (
+	"context"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/infra/data/memory"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+type testEvent struct {
+	Value string `json:"value"`
+}
+
+func (e testEvent) EventName() string {
+	return "test.happened"
+}
+
+func newTestMessage(t *testing.T, value string) *outbox.Message {
+	message, err := outbox.NewMessage(testEvent{Value: value})
+	assert.NoError(t, err)
+	return message
+}
+
+func TestOutboxMemoryStore_Claim(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	first := newTestMessage(t, "first")
+	second := newTestMessage(t, "second")
+	second.CreatedAt = first.CreatedAt.Add(time.Millisecond)
+	delayed := newTestMessage(t, "delayed")
+	delayed.NextAttemptAt = time.Now().Add(time.Hour)
+	assert.NoError(t, store.Add(second))
+	assert.NoError(t, store.Add(first))
+	assert.NoError(t, store.Add(delayed))
+	now := time.Now()
+
+	// Execução
+	limited, err := store.Claim(now, time.Minute, 1)
+	remaining, _ := store.Claim(now, time.Minute, 10)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, limited, 1, "O limite deve ser respeitado")
+	assert.Equal(t, first.ID, limited[0].ID, "As mensagens devem ser reservadas em ordem de criação")
+	assert.Equal(t, now.Add(time.Minute), limited[0].NextAttemptAt, "A próxima tentativa deve ser adiada pela reserva")
+	assert.Len(t, remaining, 1, "Mensagens com tentativa futura ou já reservadas não devem ser retornadas")
+	assert.Equal(t, second.ID, remaining[0].ID)
+
+	claimed, _ := store.Claim(now, time.Minute, 10)
+	assert.Empty(t, claimed, "Mensagens reservadas não devem ser retornadas antes do fim da reserva")
+	claimed, _ = store.Claim(now.Add(time.Minute), time.Minute, 10)
+	assert.Len(t, claimed, 2, "Mensagens não entregues devem voltar a ficar disponíveis após a reserva")
+}
+
+func TestOutboxMemoryStore_MarkDeliveredAndFailed(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	delivered := newTestMessage(t, "delivered")
+	failed := newTestMessage(t, "failed")
+	assert.NoError(t, store.Add(delivered))
+	assert.NoError(t, store.Add(failed))
+	retryAt := time.Now().Add(time.Minute)
+
+	// Execução
+	assert.NoError(t, store.MarkDelivered(delivered.ID, time.Now()))
+	assert.NoError(t, store.MarkFailed(failed.ID, "sink unavailable", retryAt))
+
+	// Verificações
+	pending, _ := store.Claim(time.Now(), time.Minute, 10)
+	assert.Empty(t, pending, "Mensagens entregues ou aguardando nova tentativa não devem estar pendentes")
+	pending, _ = store.Claim(retryAt, time.Minute, 10)
+	assert.Len(t, pending, 1, "A mensagem com falha deve voltar a ficar pendente após o backoff")
+	assert.Equal(t, 1, pending[0].Attempts, "A tentativa deve ser contabilizada")
+	assert.Equal(t, "sink unavailable", pending[0].LastError, "O último erro deve ser guardado")
+	assert.Error(t, store.MarkDelivered(uuid.New(), time.Now()), "Marcar mensagem inexistente deve retornar erro")
+}
+
+func TestOutboxMemoryStore_MarkHandled(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	message := newTestMessage(t, "value")
+	assert.NoError(t, store.Add(message))
+	handledAt := time.Now()
+
+	// Execução
+	err := store.MarkHandled(message.ID, handledAt)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, &handledAt, store.Messages[0].HandledAt, "A mensagem deve ser marcada como tratada")
+	assert.Nil(t, store.Messages[0].DeliveredAt, "Marcar como tratada não deve marcar como entregue")
+	assert.Error(t, store.MarkHandled(uuid.New(), handledAt), "Marcar mensagem inexistente deve retornar erro")
+}
+
+func TestOutboxMemoryStore_WithTransaction(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	delivered := newTestMessage(t, "delivered")
+	assert.NoError(t, store.Add(delivered))
+	provider := memory.NewTransactionProvider()
+
+	// Execução - transação desfeita
+	tx, _ := provider.Begin(context.Background())
+	assert.NoError(t, store.WithTransaction(tx).Add(newTestMessage(t, "rolled back")))
+	assert.NoError(t, store.MarkDelivered(delivered.ID, time.Now()))
+	assert.NoError(t, tx.Rollback())
+
+	// Verificações
+	assert.Len(t, store.Messages, 1, "Mensagens gravadas em transação desfeita devem ser descartadas")
+	assert.NotNil(t, store.Messages[0].DeliveredAt, "As entregas registradas fora da transação não devem ser desfeitas")
+
+	// Execução - transação confirmada
+	tx, _ = provider.Begin(context.Background())
+	assert.NoError(t, store.WithTransaction(tx).Add(newTestMessage(t, "committed")))
+	assert.NoError(t, tx.Commit())
+
+	// Verificações
+	assert.Len(t, store.Messages, 2, "Mensagens gravadas em transação confirmada devem ser incluídas")
+}
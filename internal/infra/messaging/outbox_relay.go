@@ -0,0 +1,138 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/outbox"
+	"time"
+)
+
+// Valores padrão usados pelo Relay
+const (
+	DefaultRelayBatchSize    = 100
+	DefaultRelayPollInterval = time.Second
+	DefaultRelayBaseBackoff  = time.Second
+	DefaultRelayMaxBackoff   = 5 * time.Minute
+	DefaultRelayLease        = time.Minute
+	DefaultRelayHandlerDelay = 30 * time.Second
+)
+
+// Relay reserva as mensagens pendentes do outbox e as publica no Sink, garantindo entrega ao menos uma vez.
+// Mensagens com falha são reagendadas com backoff exponencial.
+//
+// Quando Handlers é informado, o relay também entrega aos handlers do processo as mensagens que a publicação
+// após o commit não conseguiu marcar como tratadas, esperando HandlerDelay desde a criação para não concorrer com ela.
+type Relay struct {
+	store        outbox.Store
+	sink         Sink
+	Handlers     Sink
+	BatchSize    int
+	PollInterval time.Duration
+	BaseBackoff  time.Duration
+	MaxBackoff   time.Duration
+	// Lease é por quanto tempo as mensagens reservadas ficam indisponíveis para outras instâncias
+	Lease        time.Duration
+	HandlerDelay time.Duration
+	Now          func() time.Time
+	loop         background
+}
+
+// NewRelay cria um relay com as configurações padrão
+func NewRelay(store outbox.Store, sink Sink) *Relay {
+	return &Relay{
+		store:        store,
+		sink:         sink,
+		BatchSize:    DefaultRelayBatchSize,
+		PollInterval: DefaultRelayPollInterval,
+		BaseBackoff:  DefaultRelayBaseBackoff,
+		MaxBackoff:   DefaultRelayMaxBackoff,
+		Lease:        DefaultRelayLease,
+		HandlerDelay: DefaultRelayHandlerDelay,
+		Now:          time.Now,
+	}
+}
+
+// RelayOnce publica um lote de mensagens pendentes e retorna quantas foram entregues
+func (r *Relay) RelayOnce(ctx context.Context) (int, error) {
+	messages, err := r.store.Claim(r.Now(), r.Lease, r.BatchSize)
+	if err != nil {
+		return 0, err
+	}
+	delivered := 0
+	var errs []error
+	for _, message := range messages {
+		if err = ctx.Err(); err != nil {
+			return delivered, err
+		}
+		if r.Handlers != nil && message.HandledAt == nil {
+			// A mensagem continua reservada até o fim do Lease e é retomada depois
+			if r.Now().Before(message.CreatedAt.Add(r.HandlerDelay)) {
+				continue
+			}
+			if handleErr := r.Handlers.Publish(ctx, message); handleErr != nil {
+				if err = r.fail(message, handleErr); err != nil {
+					errs = append(errs, err)
+				}
+				continue
+			}
+			if err = r.store.MarkHandled(message.ID, r.Now()); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+		}
+		if publishErr := r.sink.Publish(ctx, message); publishErr != nil {
+			if err = r.fail(message, publishErr); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		if err = r.store.MarkDelivered(message.ID, r.Now()); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		delivered++
+	}
+	return delivered, errors.Join(errs...)
+}
+
+// fail reagenda a mensagem com backoff, registrando o erro da publicação
+func (r *Relay) fail(message outbox.Message, publishErr error) error {
+	return r.store.MarkFailed(message.ID, publishErr.Error(), r.Now().Add(r.backoff(message.Attempts)))
+}
+
+// Start executa Run em segundo plano até Stop
+func (r *Relay) Start(ctx context.Context) error {
+	r.loop.start(r.Run)
+	return nil
+}
+
+// Stop interrompe o Run iniciado por Start e espera o lote em andamento terminarem
+func (r *Relay) Stop(ctx context.Context) error {
+	return r.loop.stop(ctx)
+}
+
+// Run executa RelayOnce periodicamente até o contexto ser cancelado
+func (r *Relay) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	for {
+		_, _ = r.RelayOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// backoff calcula o atraso da próxima tentativa a partir do número de tentativas já feitas
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.BaseBackoff
+	for i := 0; i < attempts && delay < r.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.MaxBackoff {
+		return r.MaxBackoff
+	}
+	return delay
+}
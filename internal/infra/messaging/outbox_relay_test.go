@@ -0,0 +1,187 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/outbox"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockSink registra as mensagens publicadas e falha enquanto FailuresLeft for positivo
+type MockSink struct {
+	Published    []outbox.Message
+	FailuresLeft int
+}
+
+func (s *MockSink) Publish(ctx context.Context, message outbox.Message) error {
+	if s.FailuresLeft > 0 {
+		s.FailuresLeft--
+		return errors.New("broker unavailable")
+	}
+	s.Published = append(s.Published, message)
+	return nil
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	message := newTestMessage(t, "value")
+	_ = store.Add(message)
+	sink := &MockSink{}
+	relay := NewRelay(store, sink)
+	relay.Now = func() time.Time { return message.CreatedAt.Add(time.Second) }
+
+	// Execução
+	delivered, err := relay.RelayOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err, "RelayOnce não deve retornar erro")
+	assert.Equal(t, 1, delivered, "A mensagem pendente deve ser entregue")
+	assert.Len(t, sink.Published, 1, "A mensagem deve ser publicada no sink")
+	assert.NotNil(t, store.Messages[0].DeliveredAt, "A mensagem deve ser marcada como entregue")
+
+	delivered, _ = relay.RelayOnce(context.Background())
+	assert.Equal(t, 0, delivered, "Mensagens entregues não devem ser publicadas novamente")
+}
+
+func TestRelay_RelayOnce_RetriesWithBackoff(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	message := newTestMessage(t, "value")
+	_ = store.Add(message)
+	sink := &MockSink{FailuresLeft: 2}
+	relay := NewRelay(store, sink)
+	now := message.CreatedAt.Add(time.Second)
+	relay.Now = func() time.Time { return now }
+
+	// Execução e verificações - primeira falha
+	delivered, err := relay.RelayOnce(context.Background())
+	assert.NoError(t, err, "Falhas de publicação não devem interromper o relay")
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, "broker unavailable", store.Messages[0].LastError, "O erro da publicação deve ser registrado")
+	assert.Equal(t, now.Add(time.Second), store.Messages[0].NextAttemptAt, "A primeira nova tentativa usa o backoff base")
+
+	// Execução e verificações - antes do prazo nada é publicado
+	delivered, _ = relay.RelayOnce(context.Background())
+	assert.Equal(t, 0, delivered, "A mensagem não deve ser publicada antes da próxima tentativa")
+	assert.Equal(t, 1, store.Messages[0].Attempts)
+
+	// Execução e verificações - segunda falha dobra o atraso
+	now = now.Add(time.Second)
+	_, _ = relay.RelayOnce(context.Background())
+	assert.Equal(t, now.Add(2*time.Second), store.Messages[0].NextAttemptAt, "O backoff deve crescer exponencialmente")
+
+	// Execução e verificações - entrega
+	now = now.Add(2 * time.Second)
+	delivered, _ = relay.RelayOnce(context.Background())
+	assert.Equal(t, 1, delivered, "A mensagem deve ser entregue após o broker voltar")
+	assert.Len(t, sink.Published, 1)
+}
+
+func TestRelay_RelayOnce_Handlers(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	handled := newTestMessage(t, "handled")
+	unhandled := newTestMessage(t, "unhandled")
+	_ = store.Add(handled)
+	_ = store.Add(unhandled)
+	_ = store.MarkHandled(handled.ID, handled.CreatedAt)
+	sink := &MockSink{}
+	handlers := &MockSink{}
+	relay := NewRelay(store, sink)
+	relay.Handlers = handlers
+	now := unhandled.CreatedAt.Add(time.Second)
+	relay.Now = func() time.Time { return now }
+
+	// Execução e verificações - antes de HandlerDelay
+	delivered, err := relay.RelayOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered, "Apenas a mensagem já tratada pelos handlers deve ser entregue")
+	assert.Empty(t, handlers.Published, "Mensagens tratadas ou recentes não devem ser entregues aos handlers")
+	assert.Nil(t, store.Messages[1].DeliveredAt, "A mensagem recente deve aguardar a publicação após o commit")
+	assert.Equal(t, now.Add(relay.Lease), store.Messages[1].NextAttemptAt, "A mensagem recente deve continuar reservada")
+
+	// Execução e verificações - após a reserva
+	now = unhandled.CreatedAt.Add(relay.HandlerDelay + relay.Lease)
+	delivered, err = relay.RelayOnce(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Len(t, handlers.Published, 1, "A mensagem não tratada deve ser entregue aos handlers")
+	assert.Equal(t, unhandled.ID, handlers.Published[0].ID)
+	assert.NotNil(t, store.Messages[1].HandledAt, "A mensagem deve ser marcada como tratada")
+	assert.Len(t, sink.Published, 2, "A mensagem deve ser publicada no sink após os handlers")
+}
+
+func TestRelay_RelayOnce_HandlersFailure(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	message := newTestMessage(t, "value")
+	_ = store.Add(message)
+	sink := &MockSink{}
+	handlers := &MockSink{FailuresLeft: 1}
+	relay := NewRelay(store, sink)
+	relay.Handlers = handlers
+	now := message.CreatedAt.Add(relay.HandlerDelay)
+	relay.Now = func() time.Time { return now }
+
+	// Execução e verificações - falha dos handlers
+	delivered, err := relay.RelayOnce(context.Background())
+	assert.NoError(t, err, "Falhas dos handlers não devem interromper o relay")
+	assert.Equal(t, 0, delivered)
+	assert.Empty(t, sink.Published, "A mensagem não deve ser publicada no sink enquanto os handlers falham")
+	assert.Nil(t, store.Messages[0].HandledAt)
+	assert.Equal(t, "broker unavailable", store.Messages[0].LastError, "O erro dos handlers deve ser registrado")
+
+	// Execução e verificações - nova tentativa
+	now = now.Add(relay.BaseBackoff)
+	delivered, _ = relay.RelayOnce(context.Background())
+	assert.Equal(t, 1, delivered, "A mensagem deve ser entregue após os handlers voltarem")
+	assert.Len(t, handlers.Published, 1)
+	assert.Len(t, sink.Published, 1)
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	// Configuração
+	relay := NewRelay(NewOutboxMemoryStore(), &MockSink{})
+	relay.BaseBackoff = time.Second
+	relay.MaxBackoff = 10 * time.Second
+
+	// Execução e verificações
+	assert.Equal(t, time.Second, relay.backoff(0))
+	assert.Equal(t, 4*time.Second, relay.backoff(2))
+	assert.Equal(t, 10*time.Second, relay.backoff(4), "O backoff deve respeitar o limite máximo")
+	assert.Equal(t, 10*time.Second, relay.backoff(100), "O backoff não deve estourar com muitas tentativas")
+}
+
+func TestRelay_Run(t *testing.T) {
+	// Configuração
+	store := NewOutboxMemoryStore()
+	_ = store.Add(newTestMessage(t, "value"))
+	sink := &MockSink{}
+	relay := NewRelay(store, sink)
+	relay.PollInterval = time.Millisecond
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	// Execução
+	go func() {
+		relay.Run(ctx)
+		close(done)
+	}()
+	assert.Eventually(t, func() bool {
+		store.mu.RLock()
+		defer store.mu.RUnlock()
+		return store.Messages[0].DeliveredAt != nil
+	}, time.Second, time.Millisecond, "O relay deve publicar as mensagens pendentes")
+	cancel()
+
+	// Verificações
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Run deve terminar quando o contexto é cancelado")
+	}
+}
@@ -0,0 +1,133 @@
+package messaging
+
+import (
+	"context"
+	"database/sql"
+	"flickly/internal/domain/core/outbox"
+	"flickly/internal/domain/core/uow"
+	"flickly/internal/infra/data/sqlstore"
+	"fmt"
+	"github.com/google/uuid"
+	"sort"
+	"time"
+)
+
+// OutboxSQLSchema cria a tabela usada por OutboxSQLStore
+const OutboxSQLSchema = `CREATE TABLE IF NOT EXISTS outbox_messages (
+	id UUID PRIMARY KEY,
+	event_name TEXT NOT NULL,
+	payload TEXT NOT NULL,
+	created_at TIMESTAMP NOT NULL,
+	attempts INT NOT NULL DEFAULT 0,
+	next_attempt_at TIMESTAMP NOT NULL,
+	delivered_at TIMESTAMP NULL,
+	last_error TEXT NOT NULL DEFAULT '',
+	handled_at TIMESTAMP NULL
+)`
+
+// OutboxSQLMigrations contém as instruções, em ordem, para criar e atualizar o esquema do outbox
+var OutboxSQLMigrations = []string{
+	OutboxSQLSchema,
+	`ALTER TABLE outbox_messages ADD COLUMN IF NOT EXISTS handled_at TIMESTAMP NULL`,
+}
+
+const outboxSQLColumns = `id, event_name, payload, created_at, attempts, next_attempt_at, delivered_at, last_error, handled_at`
+
+// OutboxSQLStore é a implementação de outbox.Store em banco de dados SQL
+type OutboxSQLStore struct {
+	db sqlstore.DBTX
+}
+
+// NewOutboxSQLStore cria um outbox que usa a conexão ou transação informada
+func NewOutboxSQLStore(db sqlstore.DBTX) *OutboxSQLStore {
+	return &OutboxSQLStore{db: db}
+}
+
+// WithTransaction cria um outbox que grava as mensagens na transação SQL informada.
+// Outros tipos de transação não são suportados e o próprio outbox é retornado.
+func (s *OutboxSQLStore) WithTransaction(tx uow.Transaction) outbox.Store {
+	sqlTransaction, ok := tx.(*sqlstore.Transaction)
+	if !ok {
+		return s
+	}
+	return NewOutboxSQLStore(sqlTransaction)
+}
+
+func (s *OutboxSQLStore) Add(message *outbox.Message) error {
+	_, err := s.db.ExecContext(context.Background(),
+		`INSERT INTO outbox_messages (`+outboxSQLColumns+`) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+		message.ID, message.EventName, string(message.Payload), message.CreatedAt, message.Attempts,
+		message.NextAttemptAt, message.DeliveredAt, message.LastError, message.HandledAt)
+	return err
+}
+
+// Claim usa FOR UPDATE SKIP LOCKED para que instâncias concorrentes não reservem as mesmas mensagens
+func (s *OutboxSQLStore) Claim(now time.Time, lease time.Duration, limit int) ([]outbox.Message, error) {
+	rows, err := s.db.QueryContext(context.Background(),
+		`UPDATE outbox_messages SET next_attempt_at = $1
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE delivered_at IS NULL AND next_attempt_at <= $2
+			ORDER BY created_at LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING `+outboxSQLColumns, now.Add(lease), now, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var messages []outbox.Message
+	for rows.Next() {
+		var message outbox.Message
+		var payload string
+		if err := rows.Scan(&message.ID, &message.EventName, &payload, &message.CreatedAt, &message.Attempts,
+			&message.NextAttemptAt, &message.DeliveredAt, &message.LastError, &message.HandledAt); err != nil {
+			return nil, err
+		}
+		message.Payload = []byte(payload)
+		messages = append(messages, message)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	// RETURNING não garante a ordem da subconsulta
+	sort.SliceStable(messages, func(i, j int) bool {
+		return messages[i].CreatedAt.Before(messages[j].CreatedAt)
+	})
+	return messages, nil
+}
+
+func (s *OutboxSQLStore) MarkHandled(id uuid.UUID, handledAt time.Time) error {
+	result, err := s.db.ExecContext(context.Background(),
+		`UPDATE outbox_messages SET handled_at = $1 WHERE id = $2`, handledAt, id)
+	return checkOutboxUpdate(result, err, id)
+}
+
+func (s *OutboxSQLStore) MarkDelivered(id uuid.UUID, deliveredAt time.Time) error {
+	result, err := s.db.ExecContext(context.Background(),
+		`UPDATE outbox_messages SET attempts = attempts + 1, delivered_at = $1, last_error = '' WHERE id = $2`,
+		deliveredAt, id)
+	return checkOutboxUpdate(result, err, id)
+}
+
+func (s *OutboxSQLStore) MarkFailed(id uuid.UUID, lastError string, nextAttemptAt time.Time) error {
+	result, err := s.db.ExecContext(context.Background(),
+		`UPDATE outbox_messages SET attempts = attempts + 1, last_error = $1, next_attempt_at = $2 WHERE id = $3`,
+		lastError, nextAttemptAt, id)
+	return checkOutboxUpdate(result, err, id)
+}
+
+func checkOutboxUpdate(result sql.Result, err error, id uuid.UUID) error {
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("outbox message %s not found", id)
+	}
+	return nil
+}
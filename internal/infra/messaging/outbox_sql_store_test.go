@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"context"
+	"flickly/internal/infra/data/memory"
+	"flickly/internal/infra/data/sqlstore"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+var outboxSQLColumnNames = []string{"id", "event_name", "payload", "created_at", "attempts", "next_attempt_at", "delivered_at", "last_error", "handled_at"}
+
+func TestOutboxSQLStore_Add(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	message := newTestMessage(t, "value")
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO outbox_messages")).
+		WithArgs(message.ID, "test.happened", `{"value":"value"}`, message.CreatedAt, 0, message.NextAttemptAt, nil, "", nil).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	// Execução
+	err := NewOutboxSQLStore(db).Add(message)
+
+	// Verificações
+	assert.NoError(t, err, "Add não deve retornar erro")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxSQLStore_Claim(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	now := time.Now()
+	id := uuid.New()
+	older := uuid.New()
+	mock.ExpectQuery(regexp.QuoteMeta("UPDATE outbox_messages SET next_attempt_at = $1")).
+		WithArgs(now.Add(time.Minute), now, 5).
+		WillReturnRows(sqlmock.NewRows(outboxSQLColumnNames).
+			AddRow(id, "test.happened", `{"value":"a"}`, now, 2, now.Add(time.Minute), nil, "timeout", nil).
+			AddRow(older, "test.happened", `{"value":"b"}`, now.Add(-time.Second), 0, now.Add(time.Minute), nil, "", now))
+
+	// Execução
+	messages, err := NewOutboxSQLStore(db).Claim(now, time.Minute, 5)
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Len(t, messages, 2)
+	assert.Equal(t, older, messages[0].ID, "As mensagens devem ser retornadas em ordem de criação")
+	assert.NotNil(t, messages[0].HandledAt, "A data de tratamento deve ser lida corretamente")
+	messages = messages[1:]
+	assert.Equal(t, id, messages[0].ID, "O ID deve ser lido corretamente")
+	assert.JSONEq(t, `{"value":"a"}`, string(messages[0].Payload), "O payload deve ser lido corretamente")
+	assert.Equal(t, 2, messages[0].Attempts, "As tentativas devem ser lidas corretamente")
+	assert.Equal(t, "timeout", messages[0].LastError, "O último erro deve ser lido corretamente")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxSQLStore_Mark(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	id := uuid.New()
+	now := time.Now()
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_messages SET handled_at = $1 WHERE id = $2")).
+		WithArgs(now, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_messages SET attempts = attempts + 1, delivered_at = $1")).
+		WithArgs(now, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	mock.ExpectExec(regexp.QuoteMeta("UPDATE outbox_messages SET attempts = attempts + 1, last_error = $1")).
+		WithArgs("boom", now, id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	store := NewOutboxSQLStore(db)
+
+	// Execução e verificações
+	assert.NoError(t, store.MarkHandled(id, now), "MarkHandled não deve retornar erro")
+	assert.NoError(t, store.MarkDelivered(id, now), "MarkDelivered não deve retornar erro")
+	assert.Error(t, store.MarkFailed(id, "boom", now), "MarkFailed deve falhar quando a mensagem não existe")
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestOutboxSQLStore_WithTransaction(t *testing.T) {
+	// Configuração
+	db, mock, _ := sqlmock.New()
+	defer db.Close()
+	mock.ExpectBegin()
+	store := NewOutboxSQLStore(db)
+	sqlTransaction, _ := sqlstore.NewTransactionProvider(db).Begin(context.Background())
+	memoryTransaction, _ := memory.NewTransactionProvider().Begin(context.Background())
+	defer memoryTransaction.Rollback()
+
+	// Execução
+	bound := store.WithTransaction(sqlTransaction)
+	fallback := store.WithTransaction(memoryTransaction)
+
+	// Verificações
+	assert.NotSame(t, store, bound, "O outbox deve ser vinculado à transação SQL")
+	assert.Same(t, store, fallback, "Com outros tipos de transação, o outbox sem transação deve ser usado")
+}
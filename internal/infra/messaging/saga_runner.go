@@ -0,0 +1,105 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/saga"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Valores padrão usados pelo SagaRunner
+const (
+	DefaultSagaRunnerConcurrency  = 4
+	DefaultSagaRunnerPollInterval = time.Second
+	DefaultSagaRunnerLease        = 5 * time.Minute
+)
+
+// SagaRunner reserva as sagas em andamento e as continua pelo saga.Manager. Sagas interrompidas por um
+// reinício voltam a ser executadas, a partir do último passo gravado, quando a reserva vence.
+type SagaRunner struct {
+	store        saga.Store
+	manager      *saga.Manager
+	Concurrency  int
+	PollInterval time.Duration
+	// Lease é o tempo que uma saga fica reservada; deve ser maior que a duração do passo mais longo
+	Lease time.Duration
+	Now   func() time.Time
+	loop  background
+}
+
+// NewSagaRunner cria um executor de sagas com as configurações padrão
+func NewSagaRunner(store saga.Store, manager *saga.Manager) *SagaRunner {
+	return &SagaRunner{
+		store:        store,
+		manager:      manager,
+		Concurrency:  DefaultSagaRunnerConcurrency,
+		PollInterval: DefaultSagaRunnerPollInterval,
+		Lease:        DefaultSagaRunnerLease,
+		Now:          time.Now,
+	}
+}
+
+// RunOnce continua em paralelo até Concurrency sagas prontas e retorna quantas foram processadas sem erro
+func (r *SagaRunner) RunOnce(ctx context.Context) (int, error) {
+	claimed, err := r.store.Claim(r.Now(), r.Lease, r.Concurrency)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	processed := 0
+	var errs []error
+	for _, state := range claimed {
+		wg.Add(1)
+		go func(state saga.State) {
+			defer wg.Done()
+			err := r.resume(ctx, state)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				processed++
+			}
+		}(state)
+	}
+	wg.Wait()
+	return processed, errors.Join(errs...)
+}
+
+// Start executa Run em segundo plano até Stop
+func (r *SagaRunner) Start(ctx context.Context) error {
+	r.loop.start(r.Run)
+	return nil
+}
+
+// Stop interrompe o Run iniciado por Start e espera as sagas em andamento terminarem
+func (r *SagaRunner) Stop(ctx context.Context) error {
+	return r.loop.stop(ctx)
+}
+
+// Run executa RunOnce periodicamente até o contexto ser cancelado
+func (r *SagaRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	for {
+		_, _ = r.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resume continua a saga convertendo um panic em erro para não derrubar o executor
+func (r *SagaRunner) resume(ctx context.Context, state saga.State) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("saga %s panicked: %v", state.ID, recovered)
+		}
+	}()
+	return r.manager.Resume(ctx, state)
+}
@@ -0,0 +1,115 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/saga"
+	"flickly/internal/domain/core/security"
+	infrasaga "flickly/internal/infra/data/saga"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// testSagaData são os dados da saga usada nos testes do executor
+type testSagaData struct {
+	Values []string `json:"values"`
+}
+
+// newTestSagaRunner cria um executor cuja saga envia testJobRequest ao mediator em cada passo
+func newTestSagaRunner(handler func(ctx context.Context, request testJobRequest) (string, error)) (*SagaRunner, *saga.Manager, *infrasaga.SagaMemoryStore) {
+	mediatR := mediator.NewMediatR()
+	mediator.Register[testJobRequest, string](mediatR, mediator.RequestHandlerFunc[testJobRequest, string](handler))
+	send := func(value string) saga.StepFunc[testSagaData] {
+		return func(ctx context.Context, m mediator.Sender, data *testSagaData) error {
+			response, err := mediator.Send[string](ctx, m, testJobRequest{Value: value})
+			if err != nil {
+				return err
+			}
+			data.Values = append(data.Values, response)
+			return nil
+		}
+	}
+	store := infrasaga.NewSagaMemoryStore()
+	manager := saga.NewManager(store, mediatR)
+	saga.Register(manager, saga.Definition[testSagaData]{
+		Name: "TestSaga",
+		Steps: []saga.Step[testSagaData]{
+			{Name: "first", Execute: send("first"), Compensate: send("undo first")},
+			{Name: "second", Execute: send("second")},
+		},
+	})
+	return NewSagaRunner(store, manager), manager, store
+}
+
+func TestSagaRunner_RunOnce(t *testing.T) {
+	// Configuração
+	var subject string
+	runner, manager, _ := newTestSagaRunner(func(ctx context.Context, request testJobRequest) (string, error) {
+		subject = security.PrincipalFromContext(ctx).Subject
+		return "done " + request.Value, nil
+	})
+	ctx := security.WithPrincipal(context.Background(), security.Principal{Subject: "user-1"})
+	id, err := manager.Start(ctx, "TestSaga", testSagaData{})
+	assert.NoError(t, err)
+
+	// Execução
+	processed, err := runner.RunOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err, "RunOnce não deve retornar erro")
+	assert.Equal(t, 1, processed, "A saga pendente deve ser executada")
+	assert.Equal(t, "user-1", subject, "Os comandos devem ser enviados em nome de quem iniciou a saga")
+	state, _ := manager.Get(id)
+	assert.Equal(t, saga.StatusCompleted, state.Status, "A saga deve ser concluída")
+	assert.JSONEq(t, `{"values":["done first","done second"]}`, string(state.Data))
+
+	processed, _ = runner.RunOnce(context.Background())
+	assert.Equal(t, 0, processed, "Sagas concluídas não devem ser executadas novamente")
+}
+
+func TestSagaRunner_RunOnce_Compensates(t *testing.T) {
+	// Configuração
+	var received []string
+	runner, manager, _ := newTestSagaRunner(func(ctx context.Context, request testJobRequest) (string, error) {
+		received = append(received, request.Value)
+		if request.Value == "second" {
+			return "", errors.New("service unavailable")
+		}
+		return "done " + request.Value, nil
+	})
+	id, _ := manager.Start(context.Background(), "TestSaga", testSagaData{})
+
+	// Execução
+	_, err := runner.RunOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"first", "second", "undo first"}, received, "O passo concluído deve ser compensado")
+	state, _ := manager.Get(id)
+	assert.Equal(t, saga.StatusCompensated, state.Status)
+	assert.Equal(t, "service unavailable", state.LastError)
+}
+
+func TestSagaRunner_RunOnce_ResumesAfterLease(t *testing.T) {
+	// Configuração
+	runner, manager, store := newTestSagaRunner(func(ctx context.Context, request testJobRequest) (string, error) {
+		return "done " + request.Value, nil
+	})
+	id, _ := manager.Start(context.Background(), "TestSaga", testSagaData{})
+	now := time.Now()
+	_, _ = store.Claim(now, runner.Lease, 1) // executor interrompido após reservar a saga
+	runner.Now = func() time.Time { return now }
+
+	// Execução e verificações
+	processed, _ := runner.RunOnce(context.Background())
+	assert.Equal(t, 0, processed, "A saga reservada não deve ser executada antes do fim da reserva")
+
+	runner.Now = func() time.Time { return now.Add(runner.Lease) }
+	processed, _ = runner.RunOnce(context.Background())
+	assert.Equal(t, 1, processed, "A saga deve ser retomada quando a reserva vence")
+	state, _ := manager.Get(id)
+	assert.Equal(t, saga.StatusCompleted, state.Status)
+}
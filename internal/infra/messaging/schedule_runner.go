@@ -0,0 +1,109 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/schedule"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Valores padrão usados pelo ScheduleRunner
+const (
+	DefaultScheduleRunnerConcurrency  = 4
+	DefaultScheduleRunnerPollInterval = 5 * time.Second
+	DefaultScheduleRunnerLease        = 10 * time.Minute
+)
+
+// ScheduleRunner reserva as tarefas agendadas prontas para executar e as executa pelo schedule.Scheduler.
+// Várias instâncias podem rodar ao mesmo tempo: a reserva no Store garante uma única execução por horário.
+type ScheduleRunner struct {
+	scheduler    *schedule.Scheduler
+	synced       bool
+	Concurrency  int
+	PollInterval time.Duration
+	// Lease é o tempo que uma tarefa fica reservada; deve ser maior que a duração da execução mais longa
+	Lease time.Duration
+	loop  background
+}
+
+// NewScheduleRunner cria um executor de tarefas agendadas com as configurações padrão
+func NewScheduleRunner(scheduler *schedule.Scheduler) *ScheduleRunner {
+	return &ScheduleRunner{
+		scheduler:    scheduler,
+		Concurrency:  DefaultScheduleRunnerConcurrency,
+		PollInterval: DefaultScheduleRunnerPollInterval,
+		Lease:        DefaultScheduleRunnerLease,
+	}
+}
+
+// RunOnce grava as tarefas registradas no primeiro uso, executa em paralelo até Concurrency tarefas prontas
+// e retorna quantas foram executadas sem erro
+func (r *ScheduleRunner) RunOnce(ctx context.Context) (int, error) {
+	if !r.synced {
+		if err := r.scheduler.Sync(); err != nil {
+			return 0, err
+		}
+		r.synced = true
+	}
+	claimed, err := r.scheduler.Claim(r.Lease, r.Concurrency)
+	if err != nil {
+		return 0, err
+	}
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	processed := 0
+	var errs []error
+	for _, state := range claimed {
+		wg.Add(1)
+		go func(state schedule.State) {
+			defer wg.Done()
+			err := r.execute(ctx, state)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			} else {
+				processed++
+			}
+		}(state)
+	}
+	wg.Wait()
+	return processed, errors.Join(errs...)
+}
+
+// Start executa Run em segundo plano até Stop
+func (r *ScheduleRunner) Start(ctx context.Context) error {
+	r.loop.start(r.Run)
+	return nil
+}
+
+// Stop interrompe o Run iniciado por Start e espera as tarefas em andamento terminarem
+func (r *ScheduleRunner) Stop(ctx context.Context) error {
+	return r.loop.stop(ctx)
+}
+
+// Run executa RunOnce periodicamente até o contexto ser cancelado
+func (r *ScheduleRunner) Run(ctx context.Context) {
+	ticker := time.NewTicker(r.PollInterval)
+	defer ticker.Stop()
+	for {
+		_, _ = r.RunOnce(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// execute executa a tarefa convertendo um panic em erro para não derrubar o executor
+func (r *ScheduleRunner) execute(ctx context.Context, state schedule.State) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("scheduled task %s panicked: %v", state.Name, recovered)
+		}
+	}()
+	return r.scheduler.Execute(ctx, state)
+}
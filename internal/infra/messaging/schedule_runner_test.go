@@ -0,0 +1,109 @@
+package messaging
+
+import (
+	"context"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/schedule"
+	"flickly/internal/domain/core/security"
+	infraschedule "flickly/internal/infra/data/schedule"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestScheduleRunner cria um executor com a tarefa "test" que envia testJobRequest a cada minuto
+func newTestScheduleRunner(store schedule.Store, handler func(ctx context.Context, request testJobRequest) (string, error)) (*ScheduleRunner, *schedule.Scheduler) {
+	mediatR := mediator.NewMediatR()
+	mediator.Register[testJobRequest, string](mediatR, mediator.RequestHandlerFunc[testJobRequest, string](handler))
+	scheduler := schedule.NewScheduler(store, mediatR)
+	_ = scheduler.Register("test", "* * * * *", testJobRequest{Value: "scheduled"})
+	return NewScheduleRunner(scheduler), scheduler
+}
+
+func TestScheduleRunner_RunOnce(t *testing.T) {
+	// Configuração
+	var subject string
+	store := infraschedule.NewScheduleMemoryStore()
+	runner, scheduler := newTestScheduleRunner(store, func(ctx context.Context, request testJobRequest) (string, error) {
+		subject = security.PrincipalFromContext(ctx).Subject
+		return request.Value, nil
+	})
+	now := time.Now()
+	scheduler.Now = func() time.Time { return now }
+
+	// Execução
+	notDue, err := runner.RunOnce(context.Background())
+	now = now.Add(time.Minute)
+	processed, _ := runner.RunOnce(context.Background())
+	again, _ := runner.RunOnce(context.Background())
+
+	// Verificações
+	assert.NoError(t, err, "RunOnce não deve retornar erro")
+	assert.Equal(t, 0, notDue, "Tarefas não devem ser executadas antes do horário")
+	assert.Equal(t, 1, processed, "A tarefa deve ser executada no horário agendado")
+	assert.Equal(t, 0, again, "A tarefa não deve ser executada de novo no mesmo horário")
+	assert.Equal(t, schedule.SystemPrincipal.Subject, subject)
+	runs, _ := scheduler.Runs("test", 10)
+	assert.Len(t, runs, 1, "A execução deve ser registrada no histórico")
+}
+
+func TestScheduleRunner_SingleExecutionAcrossInstances(t *testing.T) {
+	// Configuração
+	var calls int32
+	store := infraschedule.NewScheduleMemoryStore()
+	handler := func(ctx context.Context, request testJobRequest) (string, error) {
+		atomic.AddInt32(&calls, 1)
+		return "", nil
+	}
+	now := time.Now().Add(time.Minute)
+	var runners []*ScheduleRunner
+	for i := 0; i < 3; i++ {
+		runner, scheduler := newTestScheduleRunner(store, handler)
+		scheduler.Now = func() time.Time { return now }
+		_ = scheduler.Sync()
+		runners = append(runners, runner)
+	}
+	_, _ = store.Trigger("test")
+
+	// Execução
+	done := make(chan struct{})
+	for _, runner := range runners {
+		go func(runner *ScheduleRunner) {
+			_, _ = runner.RunOnce(context.Background())
+			done <- struct{}{}
+		}(runner)
+	}
+	for range runners {
+		<-done
+	}
+
+	// Verificações
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls), "Apenas uma instância deve executar a tarefa")
+}
+
+func TestScheduleRunner_Run(t *testing.T) {
+	// Configuração
+	executed := make(chan struct{}, 1)
+	store := infraschedule.NewScheduleMemoryStore()
+	runner, scheduler := newTestScheduleRunner(store, func(ctx context.Context, request testJobRequest) (string, error) {
+		executed <- struct{}{}
+		return "", nil
+	})
+	runner.PollInterval = 10 * time.Millisecond
+	_ = scheduler.Sync()
+	_ = scheduler.Trigger("test")
+	ctx, cancel := context.WithCancel(context.Background())
+
+	// Execução
+	go runner.Run(ctx)
+
+	// Verificações
+	select {
+	case <-executed:
+	case <-time.After(time.Second):
+		t.Fatal("A execução manual deve ser feita pelo executor")
+	}
+	cancel()
+}
@@ -0,0 +1,71 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"sync"
+)
+
+// Sink é o destino para onde o relay publica as mensagens do outbox
+type Sink interface {
+	Publish(ctx context.Context, message outbox.Message) error
+}
+
+// AllEvents pode ser usado em Subscribe para receber todas as mensagens
+const AllEvents = "*"
+
+// Subscriber recebe as mensagens publicadas no barramento em processo
+type Subscriber func(ctx context.Context, message outbox.Message) error
+
+// InProcessBus é um Sink que entrega as mensagens aos assinantes do próprio processo
+type InProcessBus struct {
+	subscribers map[string][]Subscriber
+	mu          sync.RWMutex
+}
+
+// NewInProcessBus cria um novo barramento em processo
+func NewInProcessBus() *InProcessBus {
+	return &InProcessBus{
+		subscribers: make(map[string][]Subscriber),
+	}
+}
+
+// Subscribe registra um assinante para o evento informado (ou AllEvents)
+func (b *InProcessBus) Subscribe(eventName string, subscriber Subscriber) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscribers[eventName] = append(b.subscribers[eventName], subscriber)
+}
+
+// Publish entrega a mensagem a todos os assinantes, retornando os erros agregados
+func (b *InProcessBus) Publish(ctx context.Context, message outbox.Message) error {
+	b.mu.RLock()
+	subscribers := append(append([]Subscriber(nil), b.subscribers[message.EventName]...), b.subscribers[AllEvents]...)
+	b.mu.RUnlock()
+
+	var errs []error
+	for _, subscriber := range subscribers {
+		if err := subscriber(ctx, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// MediatorSink é um Sink que entrega as mensagens aos handlers de notificação do mediator, como as projeções.
+// Usado em Relay.Handlers, garante que os handlers recebam cada evento confirmado ao menos uma vez.
+type MediatorSink struct {
+	mediator mediator.MessagePublisher
+}
+
+// NewMediatorSink cria um Sink que publica as mensagens no mediator
+func NewMediatorSink(m mediator.MessagePublisher) *MediatorSink {
+	return &MediatorSink{mediator: m}
+}
+
+// Publish decodifica a mensagem e a entrega aos handlers do evento
+func (s *MediatorSink) Publish(ctx context.Context, message outbox.Message) error {
+	return s.mediator.PublishMessage(ctx, message)
+}
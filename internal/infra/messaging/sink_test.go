@@ -0,0 +1,73 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/outbox"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInProcessBus_Publish(t *testing.T) {
+	// Configuração
+	bus := NewInProcessBus()
+	var received []string
+	bus.Subscribe("test.happened", func(ctx context.Context, message outbox.Message) error {
+		received = append(received, "specific")
+		return nil
+	})
+	bus.Subscribe(AllEvents, func(ctx context.Context, message outbox.Message) error {
+		received = append(received, "all")
+		return nil
+	})
+	bus.Subscribe("other.happened", func(ctx context.Context, message outbox.Message) error {
+		received = append(received, "other")
+		return nil
+	})
+
+	// Execução
+	err := bus.Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.NoError(t, err, "Publish não deve retornar erro")
+	assert.Equal(t, []string{"specific", "all"}, received, "Apenas os assinantes do evento e de todos os eventos devem receber a mensagem")
+}
+
+func TestInProcessBus_PublishErrors(t *testing.T) {
+	// Configuração
+	bus := NewInProcessBus()
+	delivered := false
+	bus.Subscribe("test.happened", func(ctx context.Context, message outbox.Message) error {
+		return errors.New("subscriber failed")
+	})
+	bus.Subscribe("test.happened", func(ctx context.Context, message outbox.Message) error {
+		delivered = true
+		return nil
+	})
+
+	// Execução
+	err := bus.Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.EqualError(t, err, "subscriber failed", "O erro do assinante deve ser retornado")
+	assert.True(t, delivered, "Os demais assinantes devem receber a mensagem mesmo com falha de um deles")
+}
+
+func TestMediatorSink_Publish(t *testing.T) {
+	// Configuração
+	mediatR := mediator.NewMediatR()
+	var received []testEvent
+	mediator.Subscribe[testEvent](mediatR, mediator.SubscriberFunc[testEvent](func(ctx context.Context, event testEvent) error {
+		received = append(received, event)
+		return nil
+	}))
+
+	// Execução
+	err := NewMediatorSink(mediatR).Publish(context.Background(), *newTestMessage(t, "value"))
+
+	// Verificações
+	assert.NoError(t, err)
+	assert.Equal(t, []testEvent{{Value: "value"}}, received, "A mensagem deve ser entregue aos handlers do evento")
+}
@@ -2,23 +2,33 @@ package integration_tests
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"flickly/internal/api/flickly"
-	"flickly/internal/api/users"
-	"flickly/internal/infra/crosscutting/ioc"
+	"flickly/internal/api/commons/middlewares"
+	corejobs "flickly/internal/domain/core/jobs"
+	"flickly/internal/domain/core/mediator"
+	"flickly/internal/domain/core/security"
+	"flickly/internal/domain/users/commands"
+	"flickly/internal/infra/crosscutting/ioc/ioctest"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	"flickly/internal/infra/crosscutting/utilities"
+	"flickly/internal/infra/messaging"
 	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 )
 
 // APIIntegrationTestSuite define a suite de testes de integração para a API
 type APIIntegrationTestSuite struct {
 	suite.Suite
-	router *gin.Engine
+	router            *gin.Engine
+	serviceCollection utilities.IServiceCollection
 }
 
 // SetupSuite configura a suite de testes
@@ -26,23 +36,39 @@ func (suite *APIIntegrationTestSuite) SetupSuite() {
 	// Configurar o modo de teste do Gin
 	gin.SetMode(gin.TestMode)
 
-	// Inicializar o router
-	router := gin.New()
-	serviceCollection := utilities.NewServiceCollection()
-
-	// Injetar serviços reais (não mocks)
-	ioc.InjectServices(serviceCollection)
-	ioc.InjectMediatorHandlers(serviceCollection)
-
-	// Registrar o mapper
-	mapper := utilities.NewAutoMapper()
-	utilities.AddService[utilities.Mapper](serviceCollection, mapper)
+	// Montar a aplicação com os serviços reais (não mocks), substituindo apenas os administradores
+	app := ioctest.NewApp(suite.T(), func(serviceCollection utilities.IServiceCollection) {
+		utilities.AddService[security.RoleProvider](serviceCollection, infrasecurity.NewStaticRoleProvider("admin@example.com"))
+	})
+	suite.router = app.Router
+	suite.serviceCollection = app.Services
+}
 
-	// Configurar rotas
-	users.Startup(router, serviceCollection)
-	flickly.Startup(router)
+// createUserAndLogin cadastra o usuário e retorna seu ID e um token de acesso
+func (suite *APIIntegrationTestSuite) createUserAndLogin(name string, email string) (string, string) {
+	jsonPayload, _ := json.Marshal(map[string]string{"name": name, "email": email, "password": "Senha@123"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/user", bytes.NewBuffer(jsonPayload))
+	req.Header.Set("Content-Type", "application/json")
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusCreated, w.Code)
+	var created map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &created))
 
-	suite.router = router
+	form := url.Values{}
+	form.Add("grant_type", "password")
+	form.Add("client_id", "my_client_id")
+	form.Add("client_secret", "my_client_secret")
+	form.Add("username", email)
+	form.Add("password", "Senha@123")
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest(http.MethodPost, "/oauth/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var token map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &token))
+	return created["id"].(string), token["access_token"].(string)
 }
 
 // TestHealthEndpoint testa o endpoint de saúde da API
@@ -95,6 +121,55 @@ func (suite *APIIntegrationTestSuite) TestUserRegistration() {
 	assert.Equal(suite.T(), http.StatusCreated, w.Code)
 }
 
+// TestUserRegistrationValidation testa a rejeição de cadastros inválidos com a lista de violações
+func (suite *APIIntegrationTestSuite) TestUserRegistrationValidation() {
+	jsonPayload, _ := json.Marshal(map[string]string{"name": "", "email": "invalido", "password": "123"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPost, "/user", bytes.NewBuffer(jsonPayload))
+	req.Header.Set("Content-Type", "application/json")
+	suite.router.ServeHTTP(w, req)
+
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, w.Code)
+	var response struct {
+		Code            int                 `json:"code"`
+		Message         string              `json:"message"`
+		InternalMessage string              `json:"internalMessage"`
+		Violations      []map[string]string `json:"violations"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(suite.T(), 11, response.Code)
+	assert.Equal(suite.T(), "Dados inválidos", response.Message)
+	assert.Contains(suite.T(), response.InternalMessage, "deve ser um email válido", "A mensagem interna deve vir do Error() do erro")
+	assert.Equal(suite.T(), []map[string]string{
+		{"field": "name", "rule": "required", "message": "é obrigatório"},
+		{"field": "email", "rule": "email", "message": "deve ser um email válido"},
+		{"field": "password", "rule": "min", "message": "deve ter no mínimo 8 caracteres"},
+	}, response.Violations, "Cada campo inválido deve ser informado")
+}
+
+// TestUserRegistrationIdempotency testa que a repetição de um cadastro com a mesma chave recebe a primeira resposta
+func (suite *APIIntegrationTestSuite) TestUserRegistrationIdempotency() {
+	send := func(email string) *httptest.ResponseRecorder {
+		jsonPayload, _ := json.Marshal(map[string]string{"name": "Usuário Idempotente", "email": email, "password": "Senha@123"})
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest(http.MethodPost, "/user", bytes.NewBuffer(jsonPayload))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set(middlewares.IdempotencyKeyHeader, "cadastro-1")
+		suite.router.ServeHTTP(w, req)
+		return w
+	}
+
+	first := send("idempotente@example.com")
+	retried := send("idempotente@example.com")
+	reused := send("outro@example.com")
+
+	assert.Equal(suite.T(), http.StatusCreated, first.Code)
+	assert.Equal(suite.T(), http.StatusCreated, retried.Code, "A repetição não deve receber 400 de usuário já cadastrado")
+	assert.Equal(suite.T(), first.Body.String(), retried.Body.String(), "A repetição deve receber o mesmo usuário")
+	assert.Equal(suite.T(), "true", retried.Header().Get(middlewares.IdempotentReplayedHeader))
+	assert.Equal(suite.T(), http.StatusUnprocessableEntity, reused.Code, "A chave não pode ser reutilizada com outro conteúdo")
+}
+
 // TestAuthentication testa o fluxo de autenticação
 func (suite *APIIntegrationTestSuite) TestAuthentication() {
 	// Preparar os dados de login
@@ -123,6 +198,142 @@ func (suite *APIIntegrationTestSuite) TestAuthentication() {
 	}
 }
 
+// TestOptimisticConcurrency testa o fluxo de leitura e escrita segura com ETag e If-Match
+func (suite *APIIntegrationTestSuite) TestOptimisticConcurrency() {
+	// Criar o usuário
+	userID, token := suite.createUserAndLogin("Usuário Concorrente", "concorrente@example.com")
+	_, otherToken := suite.createUserAndLogin("Outro Usuário", "outro-concorrente@example.com")
+	userPath := "/user/" + userID
+
+	// Ler o usuário e sua ETag
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodGet, userPath, nil)
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	etag := w.Header().Get("ETag")
+	assert.Equal(suite.T(), `"1"`, etag)
+
+	// Atualizar com a ETag lida
+	update := func(name string, ifMatch string, token string) *httptest.ResponseRecorder {
+		body, _ := json.Marshal(map[string]string{"name": name, "email": "concorrente@example.com"})
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodPut, userPath, bytes.NewBuffer(body))
+		request.Header.Set("Content-Type", "application/json")
+		if ifMatch != "" {
+			request.Header.Set("If-Match", ifMatch)
+		}
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		suite.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Apenas o próprio usuário pode alterá-lo, sempre informando a versão lida
+	assert.Equal(suite.T(), http.StatusUnauthorized, update("Anônimo", etag, "").Code)
+	assert.Equal(suite.T(), http.StatusForbidden, update("Outro", etag, otherToken).Code)
+	assert.Equal(suite.T(), http.StatusPreconditionRequired, update("Sem Versão", "", token).Code)
+
+	w = update("Primeira Escrita", etag, token)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), `"2"`, w.Header().Get("ETag"))
+
+	// Uma segunda escrita com a ETag antiga deve ser rejeitada
+	w = update("Segunda Escrita", etag, token)
+	assert.Equal(suite.T(), http.StatusPreconditionFailed, w.Code)
+}
+
+// TestAuditTrail testa o registro dos comandos e a consulta administrativa da trilha de auditoria
+func (suite *APIIntegrationTestSuite) TestAuditTrail() {
+	adminID, adminToken := suite.createUserAndLogin("Administrador", "admin@example.com")
+	userID, userToken := suite.createUserAndLogin("Auditado", "auditado@example.com")
+
+	// O administrador altera o usuário
+	body, _ := json.Marshal(map[string]string{"name": "Auditado Alterado", "email": "auditado@example.com"})
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest(http.MethodPut, "/user/"+userID, bytes.NewBuffer(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+adminToken)
+	req.Header.Set("If-Match", `"1"`)
+	req.Header.Set(middlewares.CorrelationIDHeader, "audit-correlation")
+	suite.router.ServeHTTP(w, req)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+
+	getAudit := func(query string, token string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "/admin/audit?"+query, nil)
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		suite.router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// Apenas administradores podem consultar a trilha
+	assert.Equal(suite.T(), http.StatusUnauthorized, getAudit("", "").Code)
+	assert.Equal(suite.T(), http.StatusForbidden, getAudit("", userToken).Code)
+
+	// A alteração deve estar registrada com o ator e o ID de correlação
+	w = getAudit("type=UpdateUserCommand&entityId="+userID, adminToken)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	var entries []map[string]interface{}
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &entries))
+	assert.Len(suite.T(), entries, 1)
+	assert.Equal(suite.T(), adminID, entries[0]["actor"])
+	assert.Equal(suite.T(), "audit-correlation", entries[0]["correlationId"])
+	assert.Equal(suite.T(), "success", entries[0]["result"])
+
+	// O cadastro deve estar registrado sem a senha
+	w = getAudit("type=CreateUserCommand&entityId="+userID, adminToken)
+	assert.NoError(suite.T(), json.Unmarshal(w.Body.Bytes(), &entries))
+	assert.Len(suite.T(), entries, 1)
+	assert.Equal(suite.T(), "anonymous", entries[0]["actor"])
+	assert.Equal(suite.T(), "***", entries[0]["payload"].(map[string]interface{})["password"], "A senha não deve ser gravada")
+}
+
+// TestBackgroundJob testa a execução de um comando enfileirado e a consulta do seu status
+func (suite *APIIntegrationTestSuite) TestBackgroundJob() {
+	userID, userToken := suite.createUserAndLogin("Assíncrono", "assincrono@example.com")
+	_, otherToken := suite.createUserAndLogin("Outro", "outro@example.com")
+
+	// O comando é enfileirado em nome do usuário
+	mediatR := utilities.GetService[mediator.Mediator](suite.serviceCollection)
+	ctx := security.WithPrincipal(context.Background(), security.Principal{Subject: userID})
+	command := commands.UpdateUserCommand{Name: "Assíncrono Alterado", Email: "assincrono@example.com"}
+	command.ID, _ = uuid.Parse(userID)
+	jobID, err := mediatR.Enqueue(ctx, command)
+	suite.Require().NoError(err)
+
+	getJob := func(token string) (*httptest.ResponseRecorder, map[string]interface{}) {
+		recorder := httptest.NewRecorder()
+		request, _ := http.NewRequest(http.MethodGet, "/jobs/"+jobID.String(), nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		suite.router.ServeHTTP(recorder, request)
+		var body map[string]interface{}
+		_ = json.Unmarshal(recorder.Body.Bytes(), &body)
+		return recorder, body
+	}
+
+	w, job := getJob(userToken)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "pending", job["status"])
+
+	// O worker executa o comando pelo pipeline do mediator
+	worker := messaging.NewWorker(utilities.GetService[corejobs.Queue](suite.serviceCollection), mediatR)
+	succeeded, err := worker.RunOnce(context.Background())
+	suite.Require().NoError(err)
+	assert.Equal(suite.T(), 1, succeeded)
+
+	w, job = getJob(userToken)
+	assert.Equal(suite.T(), http.StatusOK, w.Code)
+	assert.Equal(suite.T(), "succeeded", job["status"])
+	assert.Equal(suite.T(), "Assíncrono Alterado", job["result"].(map[string]interface{})["name"], "O resultado do comando deve ser retornado")
+
+	// Outros usuários não podem consultar o job
+	w, _ = getJob(otherToken)
+	assert.Equal(suite.T(), http.StatusNotFound, w.Code)
+}
+
 // TestRunSuite executa a suite de testes
 func TestRunSuite(t *testing.T) {
 	suite.Run(t, new(APIIntegrationTestSuite))
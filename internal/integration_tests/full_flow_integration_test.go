@@ -5,7 +5,9 @@ import (
 	"encoding/json"
 	"flickly/internal/api/flickly"
 	"flickly/internal/api/users"
+	"flickly/internal/domain/core/security"
 	"flickly/internal/infra/crosscutting/ioc"
+	infrasecurity "flickly/internal/infra/crosscutting/security"
 	"flickly/internal/infra/crosscutting/utilities"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -35,7 +37,9 @@ func (suite *FullFlowIntegrationTestSuite) SetupSuite() {
 
 	// Injetar serviços reais (não mocks)
 	ioc.InjectServices(serviceCollection)
-	ioc.InjectMediatorHandlers(serviceCollection)
+	utilities.AddService[infrasecurity.TokenSecret](serviceCollection, infrasecurity.TokenSecret("secret"))
+	utilities.AddService[security.PasswordHasher](serviceCollection, &infrasecurity.PBKDF2PasswordHasher{Iterations: 1000})
+	suite.Require().NoError(ioc.InjectMediatorHandlers(serviceCollection))
 
 	// Registrar o mapper
 	mapper := utilities.NewAutoMapper()